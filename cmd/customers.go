@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/josevitorrodriguess/client-manager/internal/config"
+	"github.com/josevitorrodriguess/client-manager/internal/config/db"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/fieldcrypt"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+)
+
+const customersUsage = `usage: client-manager customers list [--config file]
+       client-manager customers import [--config file] [--dry-run] [--mode transaction|batch] [--batch-size n] file.csv
+       client-manager customers export [--config file] [--id customer-id --actor email] [--out file]
+
+export without --id writes every customer; with --id it writes the LGPD
+export of that customer (profile, services and audit trail), recorded in the
+audit trail as done by the admin user --actor.`
+
+// runCustomers implements `client-manager customers list|import|export`.
+func runCustomers(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, customersUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "list":
+		return runCustomersList(args[1:])
+	case "import":
+		return runCustomersImport(args[1:])
+	case "export":
+		return runCustomersExport(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown customers command %q\n%s\n", args[0], customersUsage)
+		return 2
+	}
+}
+
+// customerService opens the pool and builds the CustomerService the same way
+// the server does. The caller must close the pool.
+func customerService(ctx context.Context, cfg *config.Config) (*services.CustomerService, *pgxpool.Pool, error) {
+	keys, err := loadKeys(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	pool, err := db.InitPool(ctx, cfg.Database)
+	if err != nil {
+		return nil, nil, err
+	}
+	return services.NewCustomerService(sqlc.New(pool), services.NewTxManager(pool), fieldcrypt.New(keys)), pool, nil
+}
+
+func runCustomersList(args []string) int {
+	fs := flag.NewFlagSet("customers list", flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	ctx := context.Background()
+	cs, pool, err := customerService(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "customers list: %v\n", err)
+		return 1
+	}
+	defer pool.Close()
+
+	customers, err := cs.GetAllCustomersDetails(ctx, customer.ListOptions{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "customers list: %v\n", err)
+		return 1
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTYPE\tNAME\tEMAIL\tPHONE\tACTIVE")
+	for _, c := range customers {
+		name := c.PfName
+		if c.Type == sqlc.CustomerTypePJ {
+			name = c.CompanyName
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%v\t%s\t%s\t%t\n", c.ID, c.Type, name, c.Email, c.Phone, c.IsActive)
+	}
+	tw.Flush()
+	return 0
+}
+
+func runCustomersImport(args []string) int {
+	fs := flag.NewFlagSet("customers import", flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	dryRun := fs.Bool("dry-run", false, "validate every row without writing anything")
+	mode := fs.String("mode", string(customer.ImportModeTransaction), "transaction (all rows or none) or batch")
+	batchSize := fs.Int("batch-size", customer.DefaultImportBatchSize, "rows per batch in batch mode")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, customersUsage)
+		return 2
+	}
+	opts, err := customer.ImportOptions{DryRun: *dryRun, Mode: customer.ImportMode(*mode), BatchSize: *batchSize}.Normalize()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "customers import: %v\n", err)
+		return 2
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "customers import: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+
+	rows, err := customer.ParseImportCSV(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "customers import: %v\n", err)
+		return 1
+	}
+
+	cfg := loadConfig(*configPath)
+	ctx := context.Background()
+	cs, pool, err := customerService(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "customers import: %v\n", err)
+		return 1
+	}
+	defer pool.Close()
+
+	report, err := cs.ImportCustomers(ctx, rows, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "customers import: %v\n", err)
+		return 1
+	}
+	if err := writeJSON(os.Stdout, report); err != nil {
+		fmt.Fprintf(os.Stderr, "customers import: %v\n", err)
+		return 1
+	}
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+func runCustomersExport(args []string) int {
+	fs := flag.NewFlagSet("customers export", flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	id := fs.String("id", "", "export only this customer, with services and audit trail")
+	actor := fs.String("actor", "", "email of the admin user running an --id export")
+	out := fs.String("out", "", "file to write; stdout when empty")
+	fs.Parse(args)
+
+	var customerID uuid.UUID
+	if *id != "" {
+		parsed, err := uuid.Parse(*id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "customers export: invalid --id: %v\n", err)
+			return 2
+		}
+		customerID = parsed
+		if *actor == "" {
+			fmt.Fprintln(os.Stderr, "customers export: --actor is required with --id")
+			return 2
+		}
+	}
+
+	cfg := loadConfig(*configPath)
+	ctx := context.Background()
+	cs, pool, err := customerService(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "customers export: %v\n", err)
+		return 1
+	}
+	defer pool.Close()
+
+	var data any
+	if customerID != uuid.Nil {
+		var actorID uuid.UUID
+		if actorID, err = exportActor(ctx, sqlc.New(pool), *actor); err == nil {
+			data, err = cs.ExportCustomer(ctx, customerID, actorID)
+		}
+	} else {
+		data, err = cs.GetAllCustomersDetails(ctx, customer.ListOptions{})
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "customers export: %v\n", err)
+		return 1
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "customers export: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := writeJSON(w, data); err != nil {
+		fmt.Fprintf(os.Stderr, "customers export: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// exportActor resolves the operator of an LGPD export, who must be an admin
+// like on the HTTP endpoint, so the audit trail names a person.
+func exportActor(ctx context.Context, q sqlc.Querier, email string) (uuid.UUID, error) {
+	user, err := q.GetUserByEmail(ctx, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, fmt.Errorf("--actor %s: %w", email, services.ErrUserNotFound)
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	isAdmin, err := services.NewUserService(q).CheckIsAdmin(ctx, user.ID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !isAdmin {
+		return uuid.Nil, fmt.Errorf("--actor %s is not an admin", email)
+	}
+	return user.ID, nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
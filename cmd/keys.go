@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/josevitorrodriguess/client-manager/internal/config"
+	"github.com/josevitorrodriguess/client-manager/internal/config/db"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/fieldcrypt"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+)
+
+const keysUsage = "usage: client-manager keys generate [--key-file path] [--id key-id]\n       client-manager keys rotate [--config file]"
+
+// runKeys implements `client-manager keys generate|rotate`.
+//
+// generate creates the key file, or adds a new active key to an existing one.
+// rotate then re-wraps every stored value with the active key; old keys can be
+// removed from the file once it succeeds.
+func runKeys(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, keysUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "generate":
+		return runKeysGenerate(args[1:])
+	case "rotate":
+		return runKeysRotate(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown keys command %q\n%s\n", args[0], keysUsage)
+		return 2
+	}
+}
+
+// loadKeys checks the encryption settings and reads the key file. Every
+// command that builds a fieldcrypt.Cipher goes through it.
+func loadKeys(cfg *config.Config) (*fieldcrypt.LocalKeyProvider, error) {
+	if err := cfg.Encryption.Validate(); err != nil {
+		return nil, err
+	}
+	keys, err := fieldcrypt.LoadKeyFile(cfg.Encryption.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading encryption keys (ENCRYPTION_KEY_FILE): %w", err)
+	}
+	return keys, nil
+}
+
+func runKeysGenerate(args []string) int {
+	fs := flag.NewFlagSet("keys generate", flag.ExitOnError)
+	path := fs.String("key-file", os.Getenv("ENCRYPTION_KEY_FILE"), "key file to create or update (env ENCRYPTION_KEY_FILE)")
+	keyID := fs.String("id", time.Now().UTC().Format("20060102T150405"), "id of the new key")
+	fs.Parse(args)
+
+	if *path == "" {
+		fmt.Fprintln(os.Stderr, "keys generate: --key-file is required")
+		return 2
+	}
+
+	kf, err := fieldcrypt.ReadKeyFile(*path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		kf, err = fieldcrypt.GenerateKeyFile(*keyID)
+	case err == nil:
+		err = kf.AddKey(*keyID)
+	}
+	if err == nil {
+		err = kf.Write(*path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "keys generate: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("active key %q written to %s\n", kf.ActiveKey, *path)
+	return 0
+}
+
+func runKeysRotate(args []string) int {
+	fs := flag.NewFlagSet("keys rotate", flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	keys, err := loadKeys(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "keys rotate: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	pool, err := db.InitPool(ctx, cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "keys rotate: %v\n", err)
+		return 1
+	}
+	defer pool.Close()
+
+	cs := services.NewCustomerService(sqlc.New(pool), services.NewTxManager(pool), fieldcrypt.New(keys))
+	report, err := cs.RotateEncryptionKeys(ctx)
+	fmt.Printf("%d customers checked, %d re-encrypted with key %q\n", report.Scanned, report.Updated, keys.ActiveKeyID())
+	for _, id := range report.Conflicts {
+		fmt.Printf("customer %s skipped: another customer has the same CPF/CNPJ\n", id)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "keys rotate: %v\n", err)
+		return 1
+	}
+	if len(report.Conflicts) > 0 {
+		fmt.Fprintf(os.Stderr, "keys rotate: %d customers left unencrypted, merge them and run again\n", len(report.Conflicts))
+		return 1
+	}
+	return 0
+}
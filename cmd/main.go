@@ -3,55 +3,265 @@ package main
 import (
 	"context"
 	"encoding/gob"
+	"errors"
+	"flag"
+	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/alexedwards/scs/pgxstore"
 	"github.com/alexedwards/scs/v2"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
-	"github.com/joho/godotenv"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/josevitorrodriguess/client-manager/internal/api"
+	"github.com/josevitorrodriguess/client-manager/internal/config"
 	"github.com/josevitorrodriguess/client-manager/internal/config/db"
 	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/fieldcrypt"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+	"github.com/josevitorrodriguess/client-manager/internal/metrics"
 	"github.com/josevitorrodriguess/client-manager/internal/services"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
 	_ "github.com/lib/pq"
+	"go.uber.org/zap"
 )
 
 func main() {
 	gob.Register(uuid.UUID{})
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			os.Exit(runMigrate(os.Args[2:]))
+		case "keys":
+			os.Exit(runKeys(os.Args[2:]))
+		case "users":
+			os.Exit(runUsers(os.Args[2:]))
+		case "customers":
+			os.Exit(runCustomers(os.Args[2:]))
+		case "sessions":
+			os.Exit(runSessions(os.Args[2:]))
+		case "seed":
+			os.Exit(runSeed(os.Args[2:]))
+		}
+	}
+
+	configPath := flag.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	autoMigrate := flag.Bool("auto-migrate", false,
+		"apply pending migrations before starting the server (env AUTO_MIGRATE)")
+	flag.Parse()
+
+	cfg := loadConfig(*configPath)
+	if *autoMigrate {
+		cfg.AutoMigrate = true
+	}
+
 	logger.Info("Starting the application...")
 
-	godotenv.Load()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	ctx := context.Background()
-	pool := db.InitPool(ctx)
+	if err := run(ctx, cfg); err != nil {
+		logger.Error("Server stopped with an error", err)
+		logger.Sync()
+		os.Exit(1)
+	}
+	logger.Info("Server stopped")
+	logger.Sync()
+}
 
-	err := db.CreateAdmin(ctx, pool)
+// run starts the HTTP server and blocks until ctx is cancelled, then drains
+// in-flight requests for up to cfg.HTTP.ShutdownTimeout before closing the
+// pool.
+func run(ctx context.Context, cfg *config.Config) error {
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, cfg.Log.ServiceName)
 	if err != nil {
-		logger.Error("Error creating admin", err)
+		return err
 	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Error("Failed to flush traces", err)
+		}
+	}()
 
+	keys, err := loadKeys(cfg)
+	if err != nil {
+		return err
+	}
+
+	pool, err := db.InitPool(ctx, cfg.Database)
+	if err != nil {
+		return err
+	}
 	defer pool.Close()
 
+	// The migration state is read once: Migrate returns with the schema up to
+	// date, and an instance started behind it stays unready until restarted.
+	pending := false
+	if cfg.AutoMigrate {
+		if err := db.Migrate(ctx, pool, db.MigrateUp); err != nil {
+			return err
+		}
+	} else if pending, err = db.PendingMigrations(ctx, pool); err != nil {
+		return err
+	}
+	if pending {
+		logger.Warn("Database has pending migrations; run `client-manager migrate up` and restart")
+	}
+
+	queries := sqlc.New(pool)
+
+	if err := db.CreateAdmin(ctx, queries, cfg.Admin); err != nil {
+		logger.Error("Error creating admin", err)
+	}
+
+	sameSite, _ := cfg.Session.SameSite()
+
 	s := scs.New()
 	s.Store = pgxstore.New(pool)
-	s.Lifetime = 24 * time.Hour
+	s.Lifetime = cfg.Session.Lifetime
+	s.IdleTimeout = cfg.Session.IdleTimeout
+	s.Cookie.Name = cfg.Session.CookieName
 	s.Cookie.HttpOnly = true
-	s.Cookie.SameSite = http.SameSiteLaxMode
+	s.Cookie.Secure = cfg.Session.CookieSecure
+	s.Cookie.SameSite = sameSite
+
+	if err := metrics.RegisterPool(pool); err != nil {
+		return err
+	}
 
 	api := api.Api{
 		Router:          chi.NewMux(),
-		UserService:     *services.NewUserService(pool),
-		CustomerService: *services.NewCustomerService(pool),
+		UserService:     *services.NewUserService(queries),
+		CustomerService: *services.NewCustomerService(queries, services.NewTxManager(pool), fieldcrypt.New(keys)),
+		ServiceService:  *services.NewServiceService(queries),
 		Sessions:        s,
+		Readiness:       readinessChecks(pool, pending),
+		Decoder:         jsonutils.Decoder{MaxBytes: cfg.HTTP.MaxBodyBytes},
 	}
 
 	api.BindRoutes()
 
-	port := "3080"
-	if err := http.ListenAndServe("localhost:"+port, api.Router); err != nil {
-		logger.Error("Server failed to start", err)
-		panic(err)
+	// Legacy plaintext rows have no blind index, so duplicate checks and
+	// document lookups miss them until they are indexed. This runs alongside
+	// the server in small transactions and only logs its outcome; customers
+	// whose documents collide are listed by `client-manager keys rotate`.
+	backfillCtx, cancelBackfill := context.WithCancel(ctx)
+	backfillDone := make(chan struct{})
+	go func() {
+		defer close(backfillDone)
+		api.CustomerService.EncryptLegacyCustomers(backfillCtx)
+	}()
+	defer func() {
+		cancelBackfill()
+		<-backfillDone
+	}()
+
+	srv := &http.Server{
+		Addr:              cfg.HTTP.Addr(),
+		Handler:           api.Router,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+		ErrorLog:          logger.StdLog(),
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Info("Listening", zap.String("addr", srv.Addr), zap.Bool("tls", cfg.HTTP.TLSEnabled()))
+		if cfg.HTTP.TLSEnabled() {
+			serveErr <- srv.ListenAndServeTLS(cfg.HTTP.TLSCertFile, cfg.HTTP.TLSKeyFile)
+		} else {
+			serveErr <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("Shutting down", zap.Duration("timeout", cfg.HTTP.ShutdownTimeout))
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		srv.Close()
+		return fmt.Errorf("draining connections: %w", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// readinessChecks are the dependencies /readyz probes before the instance
+// receives traffic: a ping of the pool, which also backs the session store,
+// and the migration state found at startup. Probes run often, so nothing
+// here queries tables.
+func readinessChecks(pool *pgxpool.Pool, pending bool) []api.ReadinessCheck {
+	return []api.ReadinessCheck{
+		{Name: "database", Check: pool.Ping},
+		{Name: "migrations", Check: func(context.Context) error {
+			if pending {
+				return errors.New("pending migrations at startup")
+			}
+			return nil
+		}},
+	}
+}
+
+// loadConfig loads and validates the configuration, exiting with every
+// problem listed when it is invalid.
+func loadConfig(path string) *config.Config {
+	cfg, err := config.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(1)
+	}
+	logger.Configure(cfg.Log)
+	return cfg
+}
+
+// runMigrate implements `client-manager migrate [--config file] up|down|status|redo`.
+func runMigrate(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: client-manager migrate up|down|status|redo")
+		return 2
+	}
+	command := fs.Arg(0)
+	switch command {
+	case db.MigrateUp, db.MigrateDown, db.MigrateStatus, db.MigrateRedo:
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate command %q\nusage: client-manager migrate up|down|status|redo\n", command)
+		return 2
+	}
+
+	cfg := loadConfig(*configPath)
+
+	ctx := context.Background()
+	pool, err := db.InitPool(ctx, cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+		return 1
+	}
+	defer pool.Close()
+
+	if err := db.Migrate(ctx, pool, command); err != nil {
+		return 1
 	}
+	return 0
 }
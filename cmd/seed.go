@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/seed"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+)
+
+// runSeed implements `client-manager seed`, which fills the database with
+// fake customers and services for demos and load tests.
+func runSeed(args []string) int {
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	customers := fs.Int("customers", 50, "number of customers to create")
+	pjShare := fs.Float64("pj-share", 0.3, "fraction of customers that are companies (PJ)")
+	maxServices := fs.Int("max-services", 3, "maximum number of services per customer")
+	seedValue := fs.Uint64("seed", 0, "seed for reproducible data; 0 picks a random one")
+	fs.Parse(args)
+
+	opts := seed.Options{Customers: *customers, PJShare: *pjShare, MaxServices: *maxServices, Seed: *seedValue}
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "seed: %v\n", err)
+		return 2
+	}
+
+	cfg := loadConfig(*configPath)
+	ctx := context.Background()
+	cs, pool, err := customerService(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "seed: %v\n", err)
+		return 1
+	}
+	defer pool.Close()
+
+	report, err := seed.New(cs, services.NewServiceService(sqlc.New(pool))).Run(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "seed: %v\n", err)
+	}
+	fmt.Printf("seed %d: %d PF and %d PJ customers, %d services created, %d skipped as duplicates\n",
+		report.Seed, report.PF, report.PJ, report.Services, report.Skipped)
+	if err != nil {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/josevitorrodriguess/client-manager/internal/config/db"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+)
+
+const sessionsUsage = "usage: client-manager sessions purge [--config file]"
+
+// runSessions implements `client-manager sessions purge`, which deletes
+// expired rows from the session store.
+func runSessions(args []string) int {
+	if len(args) == 0 || args[0] != "purge" {
+		fmt.Fprintln(os.Stderr, sessionsUsage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("sessions purge", flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	fs.Parse(args[1:])
+
+	cfg := loadConfig(*configPath)
+	ctx := context.Background()
+	pool, err := db.InitPool(ctx, cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sessions purge: %v\n", err)
+		return 1
+	}
+	defer pool.Close()
+
+	n, err := services.NewUserService(sqlc.New(pool)).PurgeExpiredSessions(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sessions purge: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("%d expired sessions deleted\n", n)
+	return 0
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/josevitorrodriguess/client-manager/internal/config/db"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/user"
+)
+
+const usersUsage = `usage: client-manager users create [--config file] [--admin] --name name --email email [--password pass]
+       client-manager users reset-password [--config file] --email email [--password pass]
+       client-manager users promote|demote [--config file] --email email
+       client-manager users list [--config file]
+
+without --password the password is read from the first line of stdin.`
+
+// runUsers implements `client-manager users create|reset-password|promote|demote|list`.
+func runUsers(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usersUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "create":
+		return runUsersCreate(args[1:])
+	case "reset-password":
+		return runUsersResetPassword(args[1:])
+	case "promote":
+		return runUsersSetAdmin("promote", true, args[1:])
+	case "demote":
+		return runUsersSetAdmin("demote", false, args[1:])
+	case "list":
+		return runUsersList(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown users command %q\n%s\n", args[0], usersUsage)
+		return 2
+	}
+}
+
+func runUsersCreate(args []string) int {
+	fs := flag.NewFlagSet("users create", flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	name := fs.String("name", "", "name of the user")
+	email := fs.String("email", "", "email used to log in")
+	password := fs.String("password", "", "password; read from stdin when empty")
+	admin := fs.Bool("admin", false, "create the user as admin")
+	fs.Parse(args)
+
+	req := user.UserRequest{Name: *name, Email: *email, Password: *password, IsAdmin: *admin}
+	if req.Password == "" {
+		p, err := readPassword(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "users create: %v\n", err)
+			return 1
+		}
+		req.Password = p
+	}
+	if err := req.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "users create: %v\n", err)
+		return 2
+	}
+
+	cfg := loadConfig(*configPath)
+	ctx := context.Background()
+	pool, err := db.InitPool(ctx, cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "users create: %v\n", err)
+		return 1
+	}
+	defer pool.Close()
+
+	id, err := services.NewUserService(sqlc.New(pool)).Create(ctx, req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "users create: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("user %s created (%s, admin=%t)\n", req.Email, id, req.IsAdmin)
+	return 0
+}
+
+func runUsersResetPassword(args []string) int {
+	fs := flag.NewFlagSet("users reset-password", flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	email := fs.String("email", "", "email of the user")
+	password := fs.String("password", "", "new password; read from stdin when empty")
+	fs.Parse(args)
+
+	if *email == "" {
+		fmt.Fprintln(os.Stderr, "users reset-password: --email is required")
+		return 2
+	}
+	if *password == "" {
+		p, err := readPassword(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "users reset-password: %v\n", err)
+			return 1
+		}
+		*password = p
+	}
+
+	cfg := loadConfig(*configPath)
+	ctx := context.Background()
+	pool, err := db.InitPool(ctx, cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "users reset-password: %v\n", err)
+		return 1
+	}
+	defer pool.Close()
+
+	if _, err := services.NewUserService(sqlc.New(pool)).ResetPassword(ctx, *email, *password); err != nil {
+		fmt.Fprintf(os.Stderr, "users reset-password: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("password of %s reset\n", *email)
+	return 0
+}
+
+func runUsersSetAdmin(command string, isAdmin bool, args []string) int {
+	fs := flag.NewFlagSet("users "+command, flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	email := fs.String("email", "", "email of the user")
+	fs.Parse(args)
+
+	if *email == "" {
+		fmt.Fprintf(os.Stderr, "users %s: --email is required\n", command)
+		return 2
+	}
+
+	cfg := loadConfig(*configPath)
+	ctx := context.Background()
+	pool, err := db.InitPool(ctx, cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "users %s: %v\n", command, err)
+		return 1
+	}
+	defer pool.Close()
+
+	if _, err := services.NewUserService(sqlc.New(pool)).SetAdmin(ctx, *email, isAdmin); err != nil {
+		fmt.Fprintf(os.Stderr, "users %s: %v\n", command, err)
+		return 1
+	}
+
+	fmt.Printf("%s is now admin=%t\n", *email, isAdmin)
+	return 0
+}
+
+func runUsersList(args []string) int {
+	fs := flag.NewFlagSet("users list", flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML config file (env CONFIG_FILE)")
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	ctx := context.Background()
+	pool, err := db.InitPool(ctx, cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "users list: %v\n", err)
+		return 1
+	}
+	defer pool.Close()
+
+	users, err := services.NewUserService(sqlc.New(pool)).List(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "users list: %v\n", err)
+		return 1
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tEMAIL\tADMIN\tCREATED")
+	for _, u := range users {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%s\n", u.ID, u.Name, u.Email, u.IsAdmin, u.CreatedAt.Time.Format("2006-01-02 15:04"))
+	}
+	tw.Flush()
+	return 0
+}
+
+// readPassword reads the first line of r, so passwords can be piped in
+// instead of showing up in the shell history.
+func readPassword(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("reading password from stdin: %w", err)
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return "", fmt.Errorf("no password given: use --password or pipe it on stdin")
+	}
+	return line, nil
+}
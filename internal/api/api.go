@@ -3,6 +3,7 @@ package api
 import (
 	"github.com/alexedwards/scs/v2"
 	"github.com/go-chi/chi/v5"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
 	"github.com/josevitorrodriguess/client-manager/internal/services"
 )
 
@@ -12,4 +13,6 @@ type Api struct {
 	CustomerService services.CustomerService
 	ServiceService  services.ServiceService
 	Sessions        *scs.SessionManager
+	Readiness       []ReadinessCheck
+	Decoder         jsonutils.Decoder
 }
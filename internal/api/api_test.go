@@ -0,0 +1,106 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/josevitorrodriguess/client-manager/internal/api"
+	"github.com/josevitorrodriguess/client-manager/internal/db/memstore"
+	"github.com/josevitorrodriguess/client-manager/internal/testutil"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/user"
+)
+
+const (
+	adminEmail    = "admin@example.com"
+	adminPassword = "admin-password"
+	userEmail     = "user@example.com"
+	userPassword  = "user-password"
+)
+
+type testServer struct {
+	*httptest.Server
+	api   *api.Api
+	store *memstore.Store
+}
+
+// newTestServer wires the real router to an in-memory store and seeds one
+// admin and one regular user.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	a, store := testutil.NewAPI(t)
+	for _, u := range []user.UserRequest{
+		{Name: "Admin User", Email: adminEmail, Password: adminPassword, IsAdmin: true},
+		{Name: "Regular User", Email: userEmail, Password: userPassword},
+	} {
+		if _, err := a.UserService.Create(context.Background(), u); err != nil {
+			t.Fatalf("seeding user %s: %v", u.Email, err)
+		}
+	}
+
+	srv := httptest.NewServer(a.Router)
+	t.Cleanup(srv.Close)
+	return &testServer{Server: srv, api: a, store: store}
+}
+
+// client returns an http.Client with its own cookie jar, logged in when
+// email is not empty.
+func (ts *testServer) client(t *testing.T, email, password string) *http.Client {
+	t.Helper()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &http.Client{Jar: jar}
+
+	if email != "" {
+		res := ts.do(t, c, http.MethodPost, "/api/v1/users/login", map[string]string{"email": email, "password": password})
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("login as %s: status %d", email, res.StatusCode)
+		}
+	}
+	return c
+}
+
+func (ts *testServer) do(t *testing.T, c *http.Client, method, path string, body any) *http.Response {
+	t.Helper()
+
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, ts.URL+path, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+func decode[T any](t *testing.T, res *http.Response) T {
+	t.Helper()
+
+	var v T
+	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	return v
+}
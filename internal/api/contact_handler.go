@@ -0,0 +1,111 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"go.uber.org/zap"
+)
+
+func (api *Api) HandlerAddContact(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
+		return
+	}
+
+	data, err := jsonutils.DecodeJson[customer.ContactRequest](r, api.Decoder)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if err := data.Validate(); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	contact, err := api.CustomerService.AddContact(r.Context(), customerID, data)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	logger.FromContext(r.Context()).Info("Contact added",
+		zap.String("customer_id", customerID.String()),
+		zap.Int32("contact_id", contact.ID))
+	_ = jsonutils.EncodeJson(w, r, http.StatusCreated, contact)
+}
+
+func (api *Api) HandlerListContacts(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
+		return
+	}
+
+	contacts, err := api.CustomerService.ListContacts(r.Context(), customerID)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, contacts)
+}
+
+func (api *Api) HandlerUpdateContact(w http.ResponseWriter, r *http.Request) {
+	customerID, contactID, ok := contactParams(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := jsonutils.DecodeJson[customer.ContactRequest](r, api.Decoder)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if err := data.Validate(); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	contact, err := api.CustomerService.UpdateContact(r.Context(), customerID, contactID, data)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, contact)
+}
+
+func (api *Api) HandlerDeleteContact(w http.ResponseWriter, r *http.Request) {
+	customerID, contactID, ok := contactParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := api.CustomerService.DeleteContact(r.Context(), customerID, contactID); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, map[string]string{"message": i18n.T(i18n.FromContext(r.Context()), "message.contact_deleted")})
+}
+
+// contactParams parses the {id} and {contactID} URL parameters, answering
+// 400 when either is invalid.
+func contactParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, int32, bool) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
+		return uuid.Nil, 0, false
+	}
+	contactID, err := strconv.ParseInt(chi.URLParam(r, "contactID"), 10, 32)
+	if err != nil || contactID <= 0 {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_contact_id")
+		return uuid.Nil, 0, false
+	}
+	return customerID, int32(contactID), true
+}
@@ -0,0 +1,121 @@
+package api_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+)
+
+func TestContactsCRUD(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	id := decode[map[string]uuid.UUID](t, res)["customer_id"]
+	path := "/api/v1/customers/" + id.String() + "/contacts"
+
+	res = ts.do(t, admin, http.MethodPost, path, map[string]any{
+		"name": "Carlos Souza", "role": "financeiro", "emails": []string{"carlos@example.com"}, "phones": []string{"11 98765-4321"}, "whatsapp": true, "is_primary": true,
+	})
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("add contact status = %d", res.StatusCode)
+	}
+	first := decode[customer.ContactResponse](t, res)
+
+	// A family member may share the customer's own phone.
+	res = ts.do(t, admin, http.MethodPost, path, map[string]any{
+		"name": "Joana Souza", "phones": []string{"11 98765-4321"}, "is_primary": true,
+	})
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("add second contact status = %d", res.StatusCode)
+	}
+	second := decode[customer.ContactResponse](t, res)
+
+	res = ts.do(t, admin, http.MethodGet, path, nil)
+	contacts := decode[[]customer.ContactResponse](t, res)
+	if len(contacts) != 2 || contacts[0].ID != second.ID || !contacts[0].IsPrimary || contacts[1].IsPrimary {
+		t.Fatalf("contacts = %+v, want the second one as the only primary", contacts)
+	}
+	if len(contacts[1].Emails) != 1 || contacts[1].Role.String != "financeiro" || !contacts[1].WhatsApp {
+		t.Errorf("first contact = %+v", contacts[1])
+	}
+
+	res = ts.do(t, admin, http.MethodGet, "/api/v1/customers/"+id.String(), nil)
+	if got := decode[customer.CustomerResponse](t, res); len(got.Contacts) != 2 {
+		t.Errorf("customer contacts = %+v", got.Contacts)
+	}
+	res = ts.do(t, admin, http.MethodGet, "/api/v1/customers/", nil)
+	if all := decode[[]customer.CustomerResponse](t, res); len(all) != 1 || len(all[0].Contacts) != 2 {
+		t.Errorf("list customers = %+v", all)
+	}
+
+	contactPath := path + "/" + strconv.Itoa(int(first.ID))
+	res = ts.do(t, admin, http.MethodPut, contactPath, map[string]any{"name": "Carlos Souza", "emails": []string{"carlos@empresa.com.br"}})
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("update status = %d", res.StatusCode)
+	}
+	if got := decode[customer.ContactResponse](t, res); got.Emails[0] != "carlos@empresa.com.br" || len(got.Phones) != 0 || got.Role.Valid {
+		t.Errorf("updated contact = %+v", got)
+	}
+
+	other := "/api/v1/customers/" + uuid.NewString() + "/contacts/" + strconv.Itoa(int(first.ID))
+	if res := ts.do(t, admin, http.MethodPut, other, map[string]any{"name": "Carlos", "emails": []string{"c@example.com"}}); res.StatusCode != http.StatusNotFound {
+		t.Errorf("update through another customer status = %d, want 404", res.StatusCode)
+	}
+
+	if res := ts.do(t, admin, http.MethodDelete, contactPath, nil); res.StatusCode != http.StatusOK {
+		t.Errorf("delete status = %d", res.StatusCode)
+	}
+	if res := ts.do(t, admin, http.MethodDelete, contactPath, nil); res.StatusCode != http.StatusNotFound {
+		t.Errorf("second delete status = %d, want 404", res.StatusCode)
+	}
+
+	if res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/"+id.String()+"/anonymize", nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("anonymize status = %d", res.StatusCode)
+	}
+	res = ts.do(t, admin, http.MethodGet, path, nil)
+	if contacts := decode[[]customer.ContactResponse](t, res); len(contacts) != 0 {
+		t.Errorf("contacts left after anonymize: %+v", contacts)
+	}
+}
+
+func TestContactValidation(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	id := decode[map[string]uuid.UUID](t, res)["customer_id"]
+	path := "/api/v1/customers/" + id.String() + "/contacts"
+
+	res = ts.do(t, admin, http.MethodPost, path, map[string]any{"name": "Carlos", "emails": []string{"carlos@example.com", "nope"}})
+	if res.StatusCode != http.StatusBadRequest {
+		t.Fatalf("invalid email status = %d", res.StatusCode)
+	}
+	if errs := decode[map[string]any](t, res)["errors"].(map[string]any); errs["emails[1]"] == nil {
+		t.Errorf("errors = %v, want emails[1]", errs)
+	}
+
+	if res := ts.do(t, admin, http.MethodPost, path, map[string]any{"name": "Carlos"}); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("contact without email or phone status = %d", res.StatusCode)
+	}
+	if res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/"+uuid.NewString()+"/contacts", map[string]any{"name": "Carlos", "phones": []string{"11 98765-4321"}}); res.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown customer status = %d, want 404", res.StatusCode)
+	}
+	if res := ts.do(t, admin, http.MethodDelete, path+"/abc", nil); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid contact id status = %d, want 400", res.StatusCode)
+	}
+	// Reading a customer and its subresources takes a login, changing them
+	// takes an admin.
+	user := ts.client(t, userEmail, userPassword)
+	for _, p := range []string{path, "/api/v1/customers/" + id.String() + "/notes", "/api/v1/customers/" + id.String(), "/api/v1/customers/"} {
+		if res := ts.do(t, user, http.MethodGet, p, nil); res.StatusCode != http.StatusOK {
+			t.Errorf("non-admin GET %s status = %d, want 200", p, res.StatusCode)
+		}
+	}
+	if res := ts.do(t, user, http.MethodPost, path, map[string]any{"name": "Carlos"}); res.StatusCode != http.StatusForbidden {
+		t.Errorf("non-admin POST contact status = %d, want 403", res.StatusCode)
+	}
+}
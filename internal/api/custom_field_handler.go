@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"go.uber.org/zap"
+)
+
+func (api *Api) HandlerCreateCustomField(w http.ResponseWriter, r *http.Request) {
+	data, err := jsonutils.DecodeJson[customer.CustomFieldRequest](r, api.Decoder)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if err := data.Validate(); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	field, err := api.CustomerService.CreateCustomField(r.Context(), data)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	logger.FromContext(r.Context()).Info("Custom field created", zap.String("key", field.Key))
+	_ = jsonutils.EncodeJson(w, r, http.StatusCreated, field)
+}
+
+func (api *Api) HandlerListCustomFields(w http.ResponseWriter, r *http.Request) {
+	fields, err := api.CustomerService.ListCustomFields(r.Context())
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, fields)
+}
+
+// HandlerDeleteCustomField deletes a custom field definition together with
+// the values customers had for it.
+func (api *Api) HandlerDeleteCustomField(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "key")
+	if err := api.CustomerService.DeleteCustomField(r.Context(), key); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	logger.FromContext(r.Context()).Info("Custom field deleted", zap.String("key", key))
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, map[string]string{"message": i18n.T(i18n.FromContext(r.Context()), "message.custom_field_deleted")})
+}
+
+// HandlerSetCustomFields replaces the custom field values of a customer with
+// the JSON object in the body, keyed by custom field key.
+func (api *Api) HandlerSetCustomFields(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
+		return
+	}
+
+	data, err := jsonutils.DecodeJson[customer.CustomFieldValues](r, api.Decoder)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	values, err := api.CustomerService.SetCustomFields(r.Context(), customerID, data)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, values)
+}
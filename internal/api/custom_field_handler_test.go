@@ -0,0 +1,87 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+)
+
+func TestCustomFields(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	fields := []map[string]any{
+		{"key": "segment", "label": "Segmento", "type": "select", "options": []string{"varejo", "industria"}},
+		{"key": "employees", "label": "Funcionários", "type": "number"},
+		{"key": "renewal", "label": "Renovação", "type": "date"},
+		{"key": "source", "label": "Origem", "type": "text", "options": []string{"ignored"}},
+	}
+	for _, f := range fields {
+		if res := ts.do(t, admin, http.MethodPost, "/api/v1/custom-fields/", f); res.StatusCode != http.StatusCreated {
+			t.Fatalf("create %v status = %d", f["key"], res.StatusCode)
+		}
+	}
+	if res := ts.do(t, admin, http.MethodPost, "/api/v1/custom-fields/", fields[0]); res.StatusCode != http.StatusConflict {
+		t.Errorf("duplicate key status = %d, want 409", res.StatusCode)
+	}
+	if res := ts.do(t, admin, http.MethodPost, "/api/v1/custom-fields/", map[string]any{"key": "tier", "label": "Tier", "type": "select"}); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("select without options status = %d, want 400", res.StatusCode)
+	}
+	res := ts.do(t, ts.client(t, userEmail, userPassword), http.MethodGet, "/api/v1/custom-fields/", nil)
+	if defs := decode[[]customer.CustomFieldResponse](t, res); len(defs) != 4 {
+		t.Errorf("custom fields = %+v", defs)
+	}
+
+	res = ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	id := decode[map[string]uuid.UUID](t, res)["customer_id"]
+	path := "/api/v1/customers/" + id.String() + "/custom-fields"
+
+	res = ts.do(t, admin, http.MethodPut, path, map[string]any{"segment": "varejo", "employees": 12, "renewal": "2026-01-31", "source": nil})
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("set values status = %d", res.StatusCode)
+	}
+
+	res = ts.do(t, admin, http.MethodGet, "/api/v1/customers/"+id.String(), nil)
+	got := decode[customer.CustomerResponse](t, res).CustomFields
+	if len(got) != 3 || got["segment"] != "varejo" || got["employees"] != float64(12) || got["renewal"] != "2026-01-31" {
+		t.Errorf("custom fields = %v", got)
+	}
+
+	invalid := []struct {
+		name  string
+		body  map[string]any
+		field string
+	}{
+		{"unknown key", map[string]any{"color": "blue"}, "color"},
+		{"option not allowed", map[string]any{"segment": "servicos"}, "segment"},
+		{"number as string", map[string]any{"employees": "12"}, "employees"},
+		{"bad date", map[string]any{"renewal": "31/01/2026"}, "renewal"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, admin, http.MethodPut, path, tt.body)
+			if res.StatusCode != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", res.StatusCode)
+			}
+			if errs := decode[map[string]any](t, res)["errors"].(map[string]any); errs[tt.field] == nil {
+				t.Errorf("errors = %v, want %s", errs, tt.field)
+			}
+		})
+	}
+
+	if res := ts.do(t, admin, http.MethodDelete, "/api/v1/custom-fields/segment", nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("delete field status = %d", res.StatusCode)
+	}
+	if res := ts.do(t, admin, http.MethodDelete, "/api/v1/custom-fields/segment", nil); res.StatusCode != http.StatusNotFound {
+		t.Errorf("second delete status = %d, want 404", res.StatusCode)
+	}
+	res = ts.do(t, admin, http.MethodGet, "/api/v1/customers/"+id.String(), nil)
+	if got := decode[customer.CustomerResponse](t, res).CustomFields; got["segment"] != nil || len(got) != 2 {
+		t.Errorf("custom fields after deleting segment = %v", got)
+	}
+	if res := ts.do(t, admin, http.MethodPut, "/api/v1/customers/"+uuid.NewString()+"/custom-fields", map[string]any{}); res.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown customer status = %d, want 404", res.StatusCode)
+	}
+}
@@ -2,11 +2,15 @@ package api
 
 import (
 	"errors"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
 	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
 	"github.com/josevitorrodriguess/client-manager/internal/services"
 	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
@@ -14,91 +18,84 @@ import (
 )
 
 func (api *Api) HandlerCreatePFCustomer(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
-	logger.Info("Processing PF customer creation request", zap.String("request_id", requestID))
+	log := logger.FromContext(r.Context())
+	log.Info("Processing PF customer creation request")
 
-	data, err := jsonutils.DecodeJson[customer.CustomerPFRequest](r)
+	data, err := jsonutils.DecodeJson[customer.CustomerPFRequest](r, api.Decoder)
 	if err != nil {
-		logger.Error("Failed to decode PF customer request", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err.Error())
+		log.Warn("Failed to decode PF customer request", zap.Error(err))
+		respondError(w, r, err)
 		return
 	}
 
-	ok, err := data.IsValid()
-	if !ok {
-		logger.Warn("Invalid PF customer data", zap.String("error", err.Error()), zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusBadRequest, err)
+	if err := data.Validate(); err != nil {
+		log.Warn("Invalid PF customer data", zap.String("error", err.Error()))
+		respondError(w, r, err)
 		return
 	}
 
 	id, err := api.CustomerService.CreatePFCustomer(r.Context(), data)
 	if err != nil {
 		if errors.Is(err, services.ErrDuplicatedData) {
-			logger.Warn("Duplicate PF customer data",
-				zap.String("email", data.Email),
-				zap.String("cpf", data.Cpf),
-				zap.String("request_id", requestID))
+			log.Warn("Duplicate PF customer data")
 		} else {
-			logger.Error("Failed to create PF customer", err, zap.String("request_id", requestID))
+			log.Error("Failed to create PF customer", err)
 		}
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err)
+		respondError(w, r, err)
 		return
 	}
 
-	logger.Info("PF customer created successfully",
-		zap.String("customer_id", id.String()),
-		zap.String("name", data.Name),
-		zap.String("request_id", requestID))
+	log.Info("PF customer created successfully",
+		zap.String("customer_id", id.String()))
 	_ = jsonutils.EncodeJson(w, r, http.StatusCreated, map[string]any{"customer_id": id})
 }
 
 func (api *Api) HandlerCreatePJCustomer(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
-	logger.Info("Processing PJ customer creation request", zap.String("request_id", requestID))
+	log := logger.FromContext(r.Context())
+	log.Info("Processing PJ customer creation request")
 
-	data, err := jsonutils.DecodeJson[customer.CustomerPJRequest](r)
+	data, err := jsonutils.DecodeJson[customer.CustomerPJRequest](r, api.Decoder)
 	if err != nil {
-		logger.Error("Failed to decode PJ customer request", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err.Error())
+		log.Warn("Failed to decode PJ customer request", zap.Error(err))
+		respondError(w, r, err)
 		return
 	}
 
-	ok, err := data.IsValid()
-	if !ok {
-		logger.Warn("Invalid PJ customer data", zap.String("error", err.Error()), zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusBadRequest, err)
+	if err := data.Validate(); err != nil {
+		log.Warn("Invalid PJ customer data", zap.String("error", err.Error()))
+		respondError(w, r, err)
 		return
 	}
 
 	id, err := api.CustomerService.CreatePJCustomer(r.Context(), data)
 	if err != nil {
 		if errors.Is(err, services.ErrDuplicatedData) {
-			logger.Warn("Duplicate PJ customer data",
-				zap.String("email", data.Email),
-				zap.String("cnpj", data.Cnpj),
-				zap.String("request_id", requestID))
+			log.Warn("Duplicate PJ customer data")
 		} else {
-			logger.Error("Failed to create PJ customer", err, zap.String("request_id", requestID))
+			log.Error("Failed to create PJ customer", err)
 		}
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err)
+		respondError(w, r, err)
 		return
 	}
 
-	logger.Info("PJ customer created successfully",
-		zap.String("customer_id", id.String()),
-		zap.String("company_name", data.CompanyName),
-		zap.String("request_id", requestID))
+	log.Info("PJ customer created successfully",
+		zap.String("customer_id", id.String()))
 	_ = jsonutils.EncodeJson(w, r, http.StatusCreated, map[string]any{"customer_id": id})
 }
 
 func (api *Api) HandlerAddAddressToCostumer(w http.ResponseWriter, r *http.Request) {
-	data, err := jsonutils.DecodeJson[customer.AddAddressRequest](r)
+	data, err := jsonutils.DecodeJson[customer.AddAddressRequest](r, api.Decoder)
 	if err != nil {
-		jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err.Error())
+		respondError(w, r, err)
+		return
+	}
+	if err := data.Validate(); err != nil {
+		respondError(w, r, err)
+		return
 	}
 	id, err := api.CustomerService.AddAddressToCustomer(r.Context(), data)
 	if err != nil {
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err)
+		respondError(w, r, err)
 		return
 	}
 
@@ -110,30 +107,73 @@ func (api *Api) HandlerGetCustomerById(w http.ResponseWriter, r *http.Request) {
 
 	customerID, err := uuid.Parse(ID)
 	if err != nil {
-		http.Error(w, "invalid customer id", http.StatusBadRequest)
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
 		return
 	}
 
 	customer, err := api.CustomerService.GetCustomerDetails(r.Context(), customerID)
 	if err != nil {
-		_ = jsonutils.EncodeJson(w, r, http.StatusNotFound, map[string]any{"error": err})
+		respondError(w, r, err)
 		return
 	}
-	if customer.ID == uuid.Nil {
-		_ = jsonutils.EncodeJson(w, r, http.StatusNotFound, map[string]any{"message": "no customer found"})
-	}
 	_ = jsonutils.EncodeJson(w, r, http.StatusOK, customer)
 }
 
-func (api *Api) HandleGetAllCustomers(w http.ResponseWriter, r *http.Request) {
-	customers, err := api.CustomerService.GetAllCustomersDetails(r.Context())
+// HandlerFindCustomerByDocument looks a customer up by exact CPF or CNPJ,
+// given as the cpf or cnpj query parameter.
+func (api *Api) HandlerFindCustomerByDocument(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	query := r.URL.Query()
+
+	var found customer.CustomerResponse
+	var err error
+	switch {
+	case query.Get("cpf") != "":
+		found, err = api.CustomerService.FindCustomerByCpf(r.Context(), query.Get("cpf"))
+	case query.Get("cnpj") != "":
+		found, err = api.CustomerService.FindCustomerByCnpj(r.Context(), query.Get("cnpj"))
+	default:
+		respondProblem(w, r, http.StatusBadRequest, "error.document_required")
+		return
+	}
+
 	if err != nil {
-		_ = jsonutils.EncodeJson(w, r, http.StatusInternalServerError, map[string]any{"error": err.Error()})
+		if !errors.Is(err, services.ErrCustomerNotFound) {
+			log.Error("Failed to find customer by document", err)
+		}
+		respondError(w, r, err)
 		return
 	}
 
-	if len(customers) == 0 {
-		_ = jsonutils.EncodeJson(w, r, http.StatusOK, map[string]any{"message": "no customers found"})
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, found)
+}
+
+// HandleGetAllCustomers lists the customers in id order; repeated tag query
+// parameters keep only those with every one of the tags. With limit the list
+// is paged: the next page starts after the id of the last customer returned.
+func (api *Api) HandleGetAllCustomers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := customer.ListOptions{Tags: query["tag"]}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 || n > customer.MaxListLimit {
+			respondProblem(w, r, http.StatusBadRequest, "error.invalid_limit", customer.MaxListLimit)
+			return
+		}
+		opts.Limit = n
+	}
+	if after := query.Get("after"); after != "" {
+		id, err := uuid.Parse(after)
+		if err != nil {
+			respondProblem(w, r, http.StatusBadRequest, "error.invalid_cursor")
+			return
+		}
+		opts.After = id
+	}
+
+	customers, err := api.CustomerService.GetAllCustomersDetails(r.Context(), opts)
+	if err != nil {
+		respondError(w, r, err)
 		return
 	}
 
@@ -141,12 +181,93 @@ func (api *Api) HandleGetAllCustomers(w http.ResponseWriter, r *http.Request) {
 }
 
 func (api *Api) HandlerDeleteCustomer(w http.ResponseWriter, r *http.Request) {
-	id, err := jsonutils.DecodeJson[uuid.UUID](r)
+	id, err := jsonutils.DecodeJson[uuid.UUID](r, api.Decoder)
 	if err != nil {
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, map[string]any{"error": err})
+		respondError(w, r, err)
+		return
 	}
 
 	if err = api.CustomerService.DeleteCustomer(r.Context(), id); err != nil {
-		_ = jsonutils.EncodeJson(w, r, http.StatusOK, map[string]any{"message": "Customer Deleted Sucessfully "})
+		respondError(w, r, err)
+		return
+	}
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, map[string]any{"message": i18n.T(i18n.FromContext(r.Context()), "message.customer_deleted")})
+}
+
+const maxImportSize = 10 << 20
+
+// respondTooLarge answers 413, like the JSON decoder does, when err comes
+// from reading past maxImportSize, and reports whether it did.
+func respondTooLarge(w http.ResponseWriter, r *http.Request, err error) bool {
+	var tooLarge *http.MaxBytesError
+	if !errors.As(err, &tooLarge) {
+		return false
+	}
+	respondProblem(w, r, http.StatusRequestEntityTooLarge, "json.body_too_large", tooLarge.Limit)
+	return true
+}
+
+func (api *Api) HandlerImportCustomers(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	opts := customer.ImportOptions{
+		DryRun: r.URL.Query().Get("dry_run") == "true",
+		Mode:   customer.ImportMode(r.URL.Query().Get("mode")),
+	}
+	if size := r.URL.Query().Get("batch_size"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil || n <= 0 {
+			respondProblem(w, r, http.StatusBadRequest, "error.invalid_batch_size")
+			return
+		}
+		opts.BatchSize = n
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	var body io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if respondTooLarge(w, r, err) {
+			return
+		}
+		if err != nil {
+			respondProblem(w, r, http.StatusBadRequest, "error.import_file_required")
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	rows, err := customer.ParseImportCSV(body)
+	if respondTooLarge(w, r, err) {
+		return
+	}
+	if err != nil {
+		log.Warn("Failed to parse customer import", zap.String("error", err.Error()))
+		respondError(w, r, err)
+		return
+	}
+
+	report, err := api.CustomerService.ImportCustomers(r.Context(), rows, opts)
+	if err != nil {
+		log.Error("Failed to import customers", err)
+		respondError(w, r, err)
+		return
+	}
+
+	log.Info("Customer import processed",
+		zap.Bool("dry_run", report.DryRun),
+		zap.Int("total", report.Total),
+		zap.Int("created", report.Created),
+		zap.Int("failed", report.Failed))
+
+	status := http.StatusOK
+	switch {
+	case report.Created > 0:
+		status = http.StatusCreated
+	case !report.DryRun && report.Failed > 0:
+		status = http.StatusUnprocessableEntity
 	}
+	_ = jsonutils.EncodeJson(w, r, status, report)
 }
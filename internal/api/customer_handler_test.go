@@ -0,0 +1,210 @@
+package api_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+)
+
+func pfPayload() map[string]any {
+	return map[string]any{
+		"type":         "PF",
+		"email":        "maria@example.com",
+		"phone":        "11 91234-5678",
+		"cpf":          "123.456.789-09",
+		"name":         "Maria Silva",
+		"birth_date":   "1990-05-10",
+		"address_type": "home",
+		"street":       "Rua A",
+		"number":       "10",
+		"state":        "SP",
+		"city":         "Sao Paulo",
+		"cep":          "01001-000",
+	}
+}
+
+func TestCreatePFCustomerRequiresAdmin(t *testing.T) {
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		client *http.Client
+		want   int
+	}{
+		{"anonymous", ts.client(t, "", ""), http.StatusUnauthorized},
+		{"regular user", ts.client(t, userEmail, userPassword), http.StatusForbidden},
+		{"admin", ts.client(t, adminEmail, adminPassword), http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, tt.client, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+			if res.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateAndFetchPFCustomer(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("create status = %d", res.StatusCode)
+	}
+	created := decode[map[string]uuid.UUID](t, res)
+
+	res = ts.do(t, admin, http.MethodGet, "/api/v1/customers/"+created["customer_id"].String(), nil)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("get status = %d", res.StatusCode)
+	}
+	got := decode[customer.CustomerResponse](t, res)
+	if got.Email != "maria@example.com" || got.PfName != "Maria Silva" {
+		t.Errorf("unexpected customer: %+v", got)
+	}
+
+	res = ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	if res.StatusCode != http.StatusConflict {
+		t.Errorf("duplicate create status = %d, want %d", res.StatusCode, http.StatusConflict)
+	}
+}
+
+func TestCreatePFCustomerValidation(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	payload := pfPayload()
+	payload["cpf"] = "123"
+	payload["email"] = "not-an-email"
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", payload)
+	if res.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", res.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestImportCustomersHandler(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", "customers.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write([]byte("type,email,phone,cnpj,company_name,address_type,street,number,state,city,cep\n" +
+		"PJ,loja@example.com,11 92222-2222,11.222.333/0001-81,Loja Azul,work,Rua 2,2,SP,Sao Paulo,01001-000\n"))
+	mw.Close()
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/api/v1/customers/import?dry_run=true", &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	res, err := admin.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	report := decode[customer.ImportReport](t, res)
+	if !report.DryRun || report.Valid != 1 || report.Created != 0 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
+func TestImportCustomersTooLarge(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	huge := "type,email,phone,cnpj,company_name,address_type,street,number,state,city,cep\n" + strings.Repeat("PJ,loja@example.com,11 92222-2222,11.222.333/0001-81,Loja Azul,work,Rua 2,2,SP,Sao Paulo,01001-000\n", 120_000)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", "customers.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write([]byte(huge))
+	mw.Close()
+
+	for name, send := range map[string]struct {
+		contentType string
+		body        io.Reader
+	}{
+		"multipart": {mw.FormDataContentType(), &body},
+		"csv":       {"text/csv", strings.NewReader(huge)},
+	} {
+		res, err := admin.Post(ts.URL+"/api/v1/customers/import?dry_run=true", send.contentType, send.body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusRequestEntityTooLarge {
+			t.Errorf("%s: status = %d, want %d", name, res.StatusCode, http.StatusRequestEntityTooLarge)
+		}
+	}
+}
+
+func TestFindCustomerByDocument(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	id := decode[map[string]uuid.UUID](t, res)["customer_id"]
+
+	res = ts.do(t, admin, http.MethodGet, "/api/v1/customers/search?cpf=12345678909", nil)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("search status = %d", res.StatusCode)
+	}
+	if got := decode[customer.CustomerResponse](t, res); got.ID != id || got.Cpf != "123.456.789-09" {
+		t.Errorf("unexpected customer: %+v", got)
+	}
+
+	if res := ts.do(t, admin, http.MethodGet, "/api/v1/customers/search?cnpj=11222333000181", nil); res.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown cnpj status = %d, want 404", res.StatusCode)
+	}
+	if res := ts.do(t, admin, http.MethodGet, "/api/v1/customers/search", nil); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("missing parameter status = %d, want 400", res.StatusCode)
+	}
+}
+
+func TestCreatePFCustomerStrictDecoding(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	payload := pfPayload()
+	delete(payload, "birth_date")
+	payload["birthdate"] = "1990-05-10"
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", payload)
+	if res.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unknown field status = %d, want %d", res.StatusCode, http.StatusBadRequest)
+	}
+	if p := decode[jsonutils.Problem](t, res); p.Errors["birthdate"] != "campo desconhecido" {
+		t.Errorf("errors = %v", p.Errors)
+	}
+
+	res, err := admin.Post(ts.URL+"/api/v1/customers/pf", "text/plain", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("text/plain status = %d, want %d", res.StatusCode, http.StatusUnsupportedMediaType)
+	}
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+)
+
+var (
+	errUnauthenticated = errors.New("must be logged in")
+	errForbidden       = errors.New("only admins can access this resource")
+	errInvalidSession  = errors.New("invalid session data")
+)
+
+// errorStatuses maps the errors handlers may expose to clients to their
+// status code and the i18n key of their detail. Anything not listed is
+// answered with 500 and a generic detail, so internal error messages never
+// leak. Errors wrapped in an *i18n.Error use its message instead of key.
+var errorStatuses = []struct {
+	err    error
+	status int
+	key    string
+}{
+	{jsonutils.ErrInvalidJSON, http.StatusBadRequest, "json.invalid_value"},
+	{jsonutils.ErrBodyTooLarge, http.StatusRequestEntityTooLarge, "json.body_too_large"},
+	{jsonutils.ErrUnsupportedMediaType, http.StatusUnsupportedMediaType, "json.unsupported_media_type"},
+	{customer.ErrEmptyImport, http.StatusBadRequest, "import.empty"},
+	{customer.ErrMissingTypeColumn, http.StatusBadRequest, "import.missing_type_column"},
+	{customer.ErrInvalidCSV, http.StatusBadRequest, "import.invalid_csv"},
+	{customer.ErrInvalidImportMode, http.StatusBadRequest, "import.invalid_mode"},
+	{errUnauthenticated, http.StatusUnauthorized, "error.must_be_logged_in"},
+	{services.ErrInvalidCredentials, http.StatusUnauthorized, "error.invalid_credentials"},
+	{services.ErrUserNotFound, http.StatusUnauthorized, "error.user_not_found"},
+	{errForbidden, http.StatusForbidden, "error.forbidden"},
+	{services.ErrCustomerNotFound, http.StatusNotFound, "error.customer_not_found"},
+	{services.ErrServiceNotFound, http.StatusNotFound, "error.service_not_found"},
+	{services.ErrContactNotFound, http.StatusNotFound, "error.contact_not_found"},
+	{services.ErrNoteNotFound, http.StatusNotFound, "error.note_not_found"},
+	{services.ErrCustomFieldNotFound, http.StatusNotFound, "error.custom_field_not_found"},
+	{services.ErrCustomFieldExists, http.StatusConflict, "error.custom_field_exists"},
+	{services.ErrDuplicatedData, http.StatusConflict, "error.duplicated_data"},
+	{services.ErrDuplicatedEmailOrUsername, http.StatusConflict, "error.duplicated_user"},
+	{services.ErrCustomerAnonymized, http.StatusConflict, "error.customer_anonymized"},
+	{services.ErrCustomerHasServices, http.StatusConflict, "error.customer_has_services"},
+	{errInvalidSession, http.StatusInternalServerError, "error.invalid_session"},
+}
+
+// errorStatus returns the status code and detail key for err and whether err
+// is one of the known client errors.
+func errorStatus(err error) (int, string, bool) {
+	var validation validators.ValidationErrors
+	if errors.As(err, &validation) {
+		return http.StatusBadRequest, "error.validation_failed", true
+	}
+	for _, e := range errorStatuses {
+		if errors.Is(err, e.err) {
+			return e.status, e.key, true
+		}
+	}
+	return http.StatusInternalServerError, "error.internal", false
+}
+
+// respondError writes err as a problem response with the status from
+// errorStatuses, in the language negotiated for the request. Validation
+// errors carry their field messages.
+func respondError(w http.ResponseWriter, r *http.Request, err error) {
+	lang := i18n.FromContext(r.Context())
+	status, key, known := errorStatus(err)
+	p := problem(lang, status, i18n.T(lang, key))
+	if !known {
+		_ = jsonutils.EncodeProblem(w, r, p)
+		return
+	}
+
+	var (
+		localized  *i18n.Error
+		validation validators.ValidationErrors
+	)
+	switch {
+	case errors.As(err, &validation):
+		p.Errors = validation.Localize(lang)
+	case errors.As(err, &localized):
+		p.Detail = localized.Localize(lang)
+	}
+	_ = jsonutils.EncodeProblem(w, r, p)
+}
+
+// respondProblem writes a problem response for errors raised by the handler
+// itself, such as a malformed path or query parameter. key and args select
+// the detail message from the i18n catalogs.
+func respondProblem(w http.ResponseWriter, r *http.Request, status int, key string, args ...any) {
+	lang := i18n.FromContext(r.Context())
+	_ = jsonutils.EncodeProblem(w, r, problem(lang, status, i18n.T(lang, key, args...)))
+}
+
+func problem(lang string, status int, detail string) jsonutils.Problem {
+	p := jsonutils.NewProblem(status, detail)
+	p.Title = i18n.StatusText(lang, status)
+	return p
+}
@@ -0,0 +1,123 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+)
+
+func TestErrorResponsesAreProblems(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	invalid := pfPayload()
+	invalid["cpf"] = "123"
+
+	tests := []struct {
+		name   string
+		client *http.Client
+		method string
+		path   string
+		body   any
+		want   int
+	}{
+		{"unauthenticated", ts.client(t, "", ""), http.MethodGet, "/api/v1/services/", nil, http.StatusUnauthorized},
+		{"forbidden", ts.client(t, userEmail, userPassword), http.MethodGet, "/api/v1/services/", nil, http.StatusForbidden},
+		{"bad path parameter", admin, http.MethodGet, "/api/v1/customers/not-a-uuid", nil, http.StatusBadRequest},
+		{"validation", admin, http.MethodPost, "/api/v1/customers/pf", invalid, http.StatusBadRequest},
+		{"customer not found", admin, http.MethodGet, "/api/v1/customers/" + uuid.NewString(), nil, http.StatusNotFound},
+		{"service not found", admin, http.MethodPatch, "/api/v1/services/finish", map[string]any{"id": 999, "status": true}, http.StatusNotFound},
+		{"service for unknown customer", admin, http.MethodPost, "/api/v1/services/", map[string]any{
+			"customer_id": uuid.NewString(), "type_product": "site", "description": "institutional site", "total_value": 100,
+		}, http.StatusNotFound},
+		{"unknown route", admin, http.MethodGet, "/api/v1/nothing-here", nil, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, tt.client, tt.method, tt.path, tt.body)
+			if res.StatusCode != tt.want {
+				t.Fatalf("status = %d, want %d", res.StatusCode, tt.want)
+			}
+			if ct := res.Header.Get("Content-Type"); ct != jsonutils.ProblemContentType {
+				t.Errorf("content type = %q", ct)
+			}
+			p := decode[jsonutils.Problem](t, res)
+			if p.Status != tt.want || p.Title != i18n.StatusText(i18n.Default, tt.want) || p.Detail == "" {
+				t.Errorf("unexpected problem: %+v", p)
+			}
+		})
+	}
+}
+
+func TestValidationProblemListsFields(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	payload := pfPayload()
+	payload["cpf"] = "123"
+	payload["email"] = "not-an-email"
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", payload)
+	p := decode[jsonutils.Problem](t, res)
+	if p.Errors["cpf"] == "" || p.Errors["email"] == "" {
+		t.Errorf("errors = %v, want cpf and email", p.Errors)
+	}
+	if p.Instance != "/api/v1/customers/pf" {
+		t.Errorf("instance = %q", p.Instance)
+	}
+}
+
+func TestProblemsFollowAcceptLanguage(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	payload := pfPayload()
+	payload["cpf"] = "123"
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		acceptLanguage string
+		lang           string
+		title          string
+		cpf            string
+	}{
+		{"", i18n.PtBR, "Requisição inválida", "deve ser um CPF válido"},
+		{"en-US,en;q=0.9", i18n.En, "Bad Request", "must be a valid CPF"},
+		{"fr, pt;q=0.5", i18n.PtBR, "Requisição inválida", "deve ser um CPF válido"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang+" from "+tt.acceptLanguage, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, ts.URL+"/api/v1/customers/pf", bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			res, err := admin.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if got := res.Header.Get("Content-Language"); got != tt.lang {
+				t.Errorf("content language = %q, want %q", got, tt.lang)
+			}
+			p := decode[jsonutils.Problem](t, res)
+			if p.Title != tt.title || p.Errors["cpf"] != tt.cpf {
+				t.Errorf("problem = %+v", p)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/josevitorrodriguess/client-manager/internal/buildinfo"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+	"go.uber.org/zap"
+)
+
+const readinessTimeout = 2 * time.Second
+
+// ReadinessCheck is one dependency probed by /readyz. Check must return nil
+// when the dependency can serve traffic.
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type readinessResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (api *Api) HandlerHealthz(w http.ResponseWriter, r *http.Request) {
+	jsonutils.EncodeJson(w, r, http.StatusOK, map[string]any{
+		"status": "ok",
+	})
+}
+
+// HandlerReadyz runs every readiness check concurrently and answers 503 when
+// any of them fails.
+func (api *Api) HandlerReadyz(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		ready   = true
+		results = make(map[string]readinessResult, len(api.Readiness))
+	)
+	for _, c := range api.Readiness {
+		wg.Add(1)
+		go func(c ReadinessCheck) {
+			defer wg.Done()
+			err := c.Check(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				ready = false
+				results[c.Name] = readinessResult{Status: "fail", Error: err.Error()}
+				log.Warn("Readiness check failed",
+					zap.String("check", c.Name),
+					zap.Error(err))
+				return
+			}
+			results[c.Name] = readinessResult{Status: "ok"}
+		}(c)
+	}
+	wg.Wait()
+
+	status, code := "ok", http.StatusOK
+	if !ready {
+		status, code = "unavailable", http.StatusServiceUnavailable
+	}
+	jsonutils.EncodeJson(w, r, code, map[string]any{
+		"status": status,
+		"checks": results,
+	})
+}
+
+func (api *Api) HandlerVersion(w http.ResponseWriter, r *http.Request) {
+	jsonutils.EncodeJson(w, r, http.StatusOK, buildinfo.Get())
+}
@@ -0,0 +1,74 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/josevitorrodriguess/client-manager/internal/api"
+)
+
+func TestProbesDoNotNeedSession(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client(t, "", "")
+
+	for _, path := range []string{"/healthz", "/readyz", "/version"} {
+		res := ts.do(t, c, http.MethodGet, path, nil)
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("%s status = %d", path, res.StatusCode)
+		}
+		if len(res.Cookies()) != 0 {
+			t.Errorf("%s must not touch the session", path)
+		}
+	}
+
+	res := ts.do(t, c, http.MethodGet, "/version", nil)
+	if v := decode[map[string]any](t, res); v["go_version"] == "" || v["commit"] == "" {
+		t.Errorf("unexpected version body: %v", v)
+	}
+}
+
+func TestReadyzReportsFailingCheck(t *testing.T) {
+	ts := newTestServer(t)
+	ts.api.Readiness = []api.ReadinessCheck{
+		{Name: "database", Check: func(context.Context) error { return nil }},
+		{Name: "migrations", Check: func(context.Context) error { return errors.New("pending migrations") }},
+	}
+
+	res := ts.do(t, ts.client(t, "", ""), http.MethodGet, "/readyz", nil)
+	if res.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", res.StatusCode)
+	}
+
+	body := decode[struct {
+		Checks map[string]struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"checks"`
+	}](t, res)
+	if body.Checks["database"].Status != "ok" || body.Checks["migrations"].Error != "pending migrations" {
+		t.Errorf("unexpected checks: %+v", body.Checks)
+	}
+}
+
+func TestRequestIDIsEchoed(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client(t, "", "")
+
+	res := ts.do(t, c, http.MethodGet, "/healthz", nil)
+	if res.Header.Get("X-Request-ID") == "" {
+		t.Error("expected a generated X-Request-ID")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/healthz", nil)
+	req.Header.Set("X-Request-ID", "from-client")
+	res, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if got := res.Header.Get("X-Request-ID"); got != "from-client" {
+		t.Errorf("X-Request-ID = %q, want the one sent by the client", got)
+	}
+}
@@ -0,0 +1,41 @@
+package api_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/josevitorrodriguess/client-manager/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsUseRoutePatternsAndCountLogins(t *testing.T) {
+	ts := newTestServer(t)
+
+	route := metrics.HTTPRequests.WithLabelValues(http.MethodGet, "/api/v1/customers/{id}", "400")
+	failures := metrics.LoginAttempts.WithLabelValues(metrics.LoginInvalidCredentials)
+	successes := metrics.LoginAttempts.WithLabelValues(metrics.LoginSuccess)
+	beforeRoute, beforeFail, beforeOK := testutil.ToFloat64(route), testutil.ToFloat64(failures), testutil.ToFloat64(successes)
+
+	anon := ts.client(t, "", "")
+	ts.do(t, anon, http.MethodPost, "/api/v1/users/login", map[string]string{"email": adminEmail, "password": "wrong"})
+	admin := ts.client(t, adminEmail, adminPassword)
+	ts.do(t, admin, http.MethodGet, "/api/v1/customers/not-a-uuid", nil)
+
+	if got := testutil.ToFloat64(route) - beforeRoute; got != 1 {
+		t.Errorf("route counter moved by %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(failures) - beforeFail; got != 1 {
+		t.Errorf("login failures moved by %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(successes) - beforeOK; got != 1 {
+		t.Errorf("login successes moved by %v, want 1", got)
+	}
+
+	res := ts.do(t, anon, http.MethodGet, "/metrics", nil)
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK || !strings.Contains(string(body), "client_manager_http_requests_total") {
+		t.Errorf("unexpected /metrics response: %d", res.StatusCode)
+	}
+}
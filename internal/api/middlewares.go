@@ -2,18 +2,21 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
 	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
-	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
 	"go.uber.org/zap"
 )
 
 func GetAuthenticatedUserID(ctx context.Context, session *scs.SessionManager) (uuid.UUID, error) {
-	val := session.GetString(ctx, "AuthenticatedUserId") // já faz type assertion p/ string
+	val := session.GetString(ctx, "AuthenticatedUserId") // GetString already asserts the value is a string
 	if val == "" {
 		return uuid.Nil, fmt.Errorf("AuthenticatedUserId not found in session")
 	}
@@ -28,22 +31,18 @@ func GetAuthenticatedUserID(ctx context.Context, session *scs.SessionManager) (u
 
 func (api *Api) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		requestID := r.Header.Get("X-Request-ID")
+		log := logger.FromContext(r.Context())
 
 		if !api.Sessions.Exists(r.Context(), "AuthenticatedUserId") {
-			jsonutils.EncodeJson(w, r, http.StatusUnauthorized, map[string]any{
-				"message": "must be logged in",
-			})
+			respondError(w, r, errUnauthenticated)
 			return
 		}
 
 		userIDInterface := api.Sessions.Get(r.Context(), "AuthenticatedUserId")
 		_, ok := userIDInterface.(string)
 		if !ok {
-			logger.Error("Invalid session data", nil, zap.String("request_id", requestID))
-			jsonutils.EncodeJson(w, r, http.StatusInternalServerError, map[string]any{
-				"message": "invalid session data",
-			})
+			log.Error("Invalid session data", nil)
+			respondError(w, r, errInvalidSession)
 			return
 		}
 
@@ -51,54 +50,85 @@ func (api *Api) AuthMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// AdminMiddleware answers 401 without a session, 403 for users who are not
+// admins and 500 when the admin check itself fails.
 func (api *Api) AdminMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		requestID := r.Header.Get("X-Request-ID")
+		log := logger.FromContext(r.Context())
 
 		if !api.Sessions.Exists(r.Context(), "AuthenticatedUserId") {
-			jsonutils.EncodeJson(w, r, http.StatusUnauthorized, map[string]any{
-				"message": "must be logged in",
-			})
+			respondError(w, r, errUnauthenticated)
 			return
 		}
 
 		userIDInterface := api.Sessions.Get(r.Context(), "AuthenticatedUserId")
 		userID, ok := userIDInterface.(string)
 		if !ok {
-			logger.Error("Invalid session data in admin check", nil, zap.String("request_id", requestID))
-			jsonutils.EncodeJson(w, r, http.StatusInternalServerError, map[string]any{
-				"message": "invalid session data",
-			})
+			log.Error("Invalid session data in admin check", nil)
+			respondError(w, r, errInvalidSession)
 			return
 		}
 
 		parsedUserID, err := uuid.Parse(userID)
 		if err != nil {
-			logger.Error("Invalid user ID format", err, zap.String("request_id", requestID))
-			jsonutils.EncodeJson(w, r, http.StatusInternalServerError, map[string]any{
-				"message": "invalid user ID format",
-			})
+			log.Error("Invalid user ID format", err)
+			respondError(w, r, errInvalidSession)
 			return
 		}
 
 		ok, err = api.UserService.CheckIsAdmin(r.Context(), parsedUserID)
 		if err != nil {
-			logger.Error("Failed to check admin status", err,
-				zap.String("request_id", requestID),
-				zap.String("user_id", userID))
-			jsonutils.EncodeJson(w, r, http.StatusUnauthorized, map[string]any{
-				"message": "internal server error",
-			})
+			if !errors.Is(err, services.ErrUserNotFound) {
+				log.Error("Failed to check admin status", err, zap.String("user_id", userID))
+			}
+			respondError(w, r, err)
 			return
 		}
 
 		if !ok {
-			jsonutils.EncodeJson(w, r, http.StatusUnauthorized, map[string]any{
-				"message": "only admins can access this resource",
-			})
+			respondError(w, r, errForbidden)
 			return
 		}
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+// RequestLogger replaces chi's middleware.Logger. It must run after
+// middleware.RequestID: it echoes the request id in the X-Request-ID response
+// header, stores it in the request logger and writes one access log line per
+// request once the handler returns.
+func RequestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		requestID := middleware.GetReqID(r.Context())
+		w.Header().Set("X-Request-ID", requestID)
+
+		ctx := logger.NewContext(r.Context(), zap.String("request_id", requestID))
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		logger.FromContext(ctx).Info("Request handled",
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.Int("status", status),
+			zap.Int("bytes", ww.BytesWritten()),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("remote_addr", r.RemoteAddr))
+	})
+}
+
+// sessionUser adds the logged-in user, if any, to the request logger.
+func (api *Api) sessionUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := api.Sessions.GetString(r.Context(), "AuthenticatedUserId"); id != "" {
+			logger.AddFields(r.Context(), zap.String("user_id", id))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
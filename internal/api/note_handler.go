@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"go.uber.org/zap"
+)
+
+// HandlerAddNote adds a note to the customer, authored by the logged in user.
+func (api *Api) HandlerAddNote(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
+		return
+	}
+
+	data, err := jsonutils.DecodeJson[customer.NoteRequest](r, api.Decoder)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if err := data.Validate(); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	authorID, _ := GetAuthenticatedUserID(r.Context(), api.Sessions)
+	note, err := api.CustomerService.AddNote(r.Context(), customerID, authorID, data)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	logger.FromContext(r.Context()).Info("Note added",
+		zap.String("customer_id", customerID.String()),
+		zap.Int32("note_id", note.ID))
+	_ = jsonutils.EncodeJson(w, r, http.StatusCreated, note)
+}
+
+func (api *Api) HandlerListNotes(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
+		return
+	}
+
+	notes, err := api.CustomerService.ListNotes(r.Context(), customerID)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, notes)
+}
+
+func (api *Api) HandlerDeleteNote(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
+		return
+	}
+	noteID, err := strconv.ParseInt(chi.URLParam(r, "noteID"), 10, 32)
+	if err != nil || noteID <= 0 {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_note_id")
+		return
+	}
+
+	if err := api.CustomerService.DeleteNote(r.Context(), customerID, int32(noteID)); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, map[string]string{"message": i18n.T(i18n.FromContext(r.Context()), "message.note_deleted")})
+}
@@ -0,0 +1,84 @@
+package api_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+)
+
+func TestNotes(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+	user := ts.client(t, userEmail, userPassword)
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	id := decode[map[string]uuid.UUID](t, res)["customer_id"]
+	path := "/api/v1/customers/" + id.String() + "/notes"
+
+	res = ts.do(t, user, http.MethodPost, path, map[string]any{"body": "Asked for a new quote in March."})
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("add note status = %d", res.StatusCode)
+	}
+	first := decode[customer.NoteResponse](t, res)
+	if first.AuthorID == nil || !first.AuthorName.Valid || !first.CreatedAt.Valid {
+		t.Errorf("note = %+v, want author and timestamp", first)
+	}
+
+	res = ts.do(t, admin, http.MethodPost, path, map[string]any{"body": "Quote sent."})
+	second := decode[customer.NoteResponse](t, res)
+
+	res = ts.do(t, user, http.MethodGet, path, nil)
+	notes := decode[[]customer.NoteResponse](t, res)
+	if len(notes) != 2 || notes[0].ID != second.ID || notes[1].ID != first.ID {
+		t.Fatalf("notes = %+v, want newest first", notes)
+	}
+	res = ts.do(t, admin, http.MethodGet, "/api/v1/customers/"+id.String(), nil)
+	if got := decode[customer.CustomerResponse](t, res); len(got.Notes) != 2 || got.NoteCount != 2 {
+		t.Errorf("customer notes = %+v", got.Notes)
+	}
+	res = ts.do(t, user, http.MethodGet, "/api/v1/customers/", nil)
+	list := decode[[]customer.CustomerResponse](t, res)
+	if len(list) != 1 || list[0].Notes != nil || list[0].NoteCount != 2 || list[0].LatestNote == nil || list[0].LatestNote.ID != second.ID {
+		t.Errorf("listed customer = %+v, want the count and the latest note only", list)
+	}
+
+	notePath := path + "/" + strconv.Itoa(int(first.ID))
+	if res := ts.do(t, user, http.MethodDelete, notePath, nil); res.StatusCode != http.StatusForbidden {
+		t.Errorf("non-admin delete status = %d, want 403", res.StatusCode)
+	}
+	if res := ts.do(t, admin, http.MethodDelete, notePath, nil); res.StatusCode != http.StatusOK {
+		t.Errorf("delete status = %d", res.StatusCode)
+	}
+	if res := ts.do(t, admin, http.MethodDelete, notePath, nil); res.StatusCode != http.StatusNotFound {
+		t.Errorf("second delete status = %d, want 404", res.StatusCode)
+	}
+}
+
+func TestNoteValidation(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	id := decode[map[string]uuid.UUID](t, res)["customer_id"]
+	path := "/api/v1/customers/" + id.String() + "/notes"
+
+	if res := ts.do(t, admin, http.MethodPost, path, map[string]any{"body": "  "}); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("blank note status = %d, want 400", res.StatusCode)
+	}
+	if res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/"+uuid.NewString()+"/notes", map[string]any{"body": "Hi"}); res.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown customer status = %d, want 404", res.StatusCode)
+	}
+	if res := ts.do(t, admin, http.MethodDelete, path+"/abc", nil); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid note id status = %d, want 400", res.StatusCode)
+	}
+	// Notes are embedded in the customer, so reading it needs a login too.
+	anon := ts.client(t, "", "")
+	for _, p := range []string{path, "/api/v1/customers/" + id.String(), "/api/v1/customers/"} {
+		if res := ts.do(t, anon, http.MethodGet, p, nil); res.StatusCode != http.StatusUnauthorized {
+			t.Errorf("anonymous GET %s status = %d, want 401", p, res.StatusCode)
+		}
+	}
+}
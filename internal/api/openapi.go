@@ -0,0 +1,421 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/buildinfo"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/service"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/user"
+)
+
+// access is the authentication an operation requires.
+type access int
+
+const (
+	public access = iota
+	loggedIn
+	adminOnly
+)
+
+type parameter struct {
+	name, in, description string
+	schema                map[string]any
+	required              bool
+}
+
+// operation documents one route of BindRoutes. request and the response
+// bodies are zero values of the Go types the handler decodes and encodes;
+// their schemas are generated from the types, so renaming a JSON field
+// changes the spec too.
+type operation struct {
+	method, path, tag, summary string
+	access                     access
+	params                     []parameter
+	request                    any
+	requestContent             map[string]any
+	status                     int
+	response                   any
+	responseContent            map[string]any
+	errors                     []int
+}
+
+var (
+	uuidPath      = parameter{name: "id", in: "path", required: true, schema: map[string]any{"type": "string", "format": "uuid"}}
+	contactIDPath = parameter{name: "contactID", in: "path", required: true, schema: map[string]any{"type": "integer", "format": "int32"}}
+	noteIDPath    = parameter{name: "noteID", in: "path", required: true, schema: map[string]any{"type": "integer", "format": "int32"}}
+
+	createdCustomerResponse = struct {
+		CustomerID uuid.UUID `json:"customer_id"`
+	}{}
+	messageResponse = struct {
+		Message string `json:"message"`
+	}{}
+	readinessResponse = struct {
+		Status string                     `json:"status"`
+		Checks map[string]readinessResult `json:"checks"`
+	}{}
+)
+
+// operations lists every route served by BindRoutes. TestOpenAPICoversRoutes
+// fails when a route is added or removed without updating it.
+var operations = []operation{
+	{method: http.MethodGet, path: "/healthz", tag: "health", summary: "Liveness probe", status: http.StatusOK, response: struct {
+		Status string `json:"status"`
+	}{}},
+	{method: http.MethodGet, path: "/readyz", tag: "health", summary: "Readiness probe; answers 503 when a dependency is down", status: http.StatusOK, response: readinessResponse, errors: []int{http.StatusServiceUnavailable}},
+	{method: http.MethodGet, path: "/version", tag: "health", summary: "Build information", status: http.StatusOK, response: buildinfo.Info{}},
+	{method: http.MethodGet, path: "/metrics", tag: "health", summary: "Prometheus metrics", status: http.StatusOK, responseContent: map[string]any{
+		"text/plain": map[string]any{"schema": map[string]any{"type": "string"}},
+	}},
+	{method: http.MethodGet, path: "/api/openapi.json", tag: "docs", summary: "This OpenAPI document", status: http.StatusOK, responseContent: map[string]any{
+		"application/json": map[string]any{"schema": map[string]any{"type": "object"}},
+	}},
+	{method: http.MethodGet, path: "/api/docs", tag: "docs", summary: "Swagger UI for this document", status: http.StatusOK, responseContent: map[string]any{
+		"text/html": map[string]any{"schema": map[string]any{"type": "string"}},
+	}},
+
+	{method: http.MethodPost, path: "/api/v1/users/register", tag: "users", summary: "Create a user", access: adminOnly, request: user.UserRequest{}, status: http.StatusCreated, response: struct {
+		UserID uuid.UUID `json:"user_id"`
+	}{}, errors: []int{http.StatusConflict}},
+	{method: http.MethodPost, path: "/api/v1/users/login", tag: "users", summary: "Log in and receive the session cookie", request: user.UserRequestLogin{}, status: http.StatusOK, response: messageResponse, errors: []int{http.StatusUnauthorized}},
+	{method: http.MethodPost, path: "/api/v1/users/logout", tag: "users", summary: "Log out", access: loggedIn, status: http.StatusOK, response: messageResponse},
+
+	{method: http.MethodPost, path: "/api/v1/customers/pf", tag: "customers", summary: "Create an individual (PF) customer", access: adminOnly, request: customer.CustomerPFRequest{}, status: http.StatusCreated, response: createdCustomerResponse, errors: []int{http.StatusConflict}},
+	{method: http.MethodPost, path: "/api/v1/customers/pj", tag: "customers", summary: "Create a company (PJ) customer", access: adminOnly, request: customer.CustomerPJRequest{}, status: http.StatusCreated, response: createdCustomerResponse, errors: []int{http.StatusConflict}},
+	{method: http.MethodPost, path: "/api/v1/customers/address", tag: "customers", summary: "Add an address to a customer", access: adminOnly, request: customer.AddAddressRequest{}, status: http.StatusCreated, response: struct {
+		AddressID int32 `json:"address_id"`
+	}{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodPost, path: "/api/v1/customers/import", tag: "customers", summary: "Import customers from a CSV file", access: adminOnly, params: []parameter{
+		{name: "dry_run", in: "query", description: "Validate without inserting", schema: map[string]any{"type": "boolean"}},
+		{name: "mode", in: "query", description: "transaction (all or nothing) or batch", schema: map[string]any{"type": "string", "enum": []string{string(customer.ImportModeTransaction), string(customer.ImportModeBatch)}}},
+		{name: "batch_size", in: "query", description: "Rows per transaction in batch mode", schema: map[string]any{"type": "integer", "minimum": 1}},
+	}, requestContent: map[string]any{
+		"text/csv": map[string]any{"schema": map[string]any{"type": "string"}},
+		"multipart/form-data": map[string]any{"schema": map[string]any{
+			"type":       "object",
+			"required":   []string{"file"},
+			"properties": map[string]any{"file": map[string]any{"type": "string", "format": "binary"}},
+		}},
+	}, status: http.StatusOK, response: customer.ImportReport{}, errors: []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge}},
+	{method: http.MethodGet, path: "/api/v1/customers/search", tag: "customers", summary: "Find a customer by CPF or CNPJ", access: adminOnly, params: []parameter{
+		{name: "cpf", in: "query", schema: map[string]any{"type": "string"}},
+		{name: "cnpj", in: "query", schema: map[string]any{"type": "string"}},
+	}, status: http.StatusOK, response: customer.CustomerResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodGet, path: "/api/v1/customers/{id}", tag: "customers", summary: "Get a customer", access: loggedIn, params: []parameter{uuidPath}, status: http.StatusOK, response: customer.CustomerResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodGet, path: "/api/v1/customers/{id}/export", tag: "privacy", summary: "Export everything held about a customer (LGPD)", access: adminOnly, params: []parameter{uuidPath,
+		{name: "format", in: "query", schema: map[string]any{"type": "string", "enum": []string{"json", "zip"}, "default": "json"}},
+	}, status: http.StatusOK, response: customer.CustomerExport{}, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodPost, path: "/api/v1/customers/{id}/anonymize", tag: "privacy", summary: "Anonymize a customer (LGPD)", access: adminOnly, params: []parameter{uuidPath}, status: http.StatusOK, response: customer.CustomerResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{method: http.MethodGet, path: "/api/v1/customers/{id}/contacts", tag: "contacts", summary: "List the contacts of a customer", access: loggedIn, params: []parameter{uuidPath}, status: http.StatusOK, response: []customer.ContactResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodPost, path: "/api/v1/customers/{id}/contacts", tag: "contacts", summary: "Add a contact to a customer", access: adminOnly, params: []parameter{uuidPath}, request: customer.ContactRequest{}, status: http.StatusCreated, response: customer.ContactResponse{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodPut, path: "/api/v1/customers/{id}/contacts/{contactID}", tag: "contacts", summary: "Replace a contact of a customer", access: adminOnly, params: []parameter{uuidPath, contactIDPath}, request: customer.ContactRequest{}, status: http.StatusOK, response: customer.ContactResponse{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodDelete, path: "/api/v1/customers/{id}/contacts/{contactID}", tag: "contacts", summary: "Delete a contact of a customer", access: adminOnly, params: []parameter{uuidPath, contactIDPath}, status: http.StatusOK, response: messageResponse, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodGet, path: "/api/v1/customers/{id}/notes", tag: "notes", summary: "List the notes of a customer, newest first", access: loggedIn, params: []parameter{uuidPath}, status: http.StatusOK, response: []customer.NoteResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodPost, path: "/api/v1/customers/{id}/notes", tag: "notes", summary: "Add a note to a customer as the logged in user", access: loggedIn, params: []parameter{uuidPath}, request: customer.NoteRequest{}, status: http.StatusCreated, response: customer.NoteResponse{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodDelete, path: "/api/v1/customers/{id}/notes/{noteID}", tag: "notes", summary: "Delete a note of a customer", access: adminOnly, params: []parameter{uuidPath, noteIDPath}, status: http.StatusOK, response: messageResponse, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodPut, path: "/api/v1/customers/{id}/tags", tag: "customers", summary: "Replace the tags of a customer", access: adminOnly, params: []parameter{uuidPath}, request: customer.TagsRequest{}, status: http.StatusOK, response: customer.TagsRequest{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodPut, path: "/api/v1/customers/{id}/custom-fields", tag: "custom fields", summary: "Replace the custom field values of a customer", access: adminOnly, params: []parameter{uuidPath}, request: customer.CustomFieldValues{}, status: http.StatusOK, response: map[string]any{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodGet, path: "/api/v1/customers/", tag: "customers", summary: "List customers", access: loggedIn, params: []parameter{
+		{name: "tag", in: "query", description: "Only customers with this tag; repeat to require several", schema: map[string]any{"type": "array", "items": map[string]any{"type": "string"}}},
+		{name: "limit", in: "query", description: "Page size; every customer when omitted", schema: map[string]any{"type": "integer", "minimum": 1, "maximum": customer.MaxListLimit}},
+		{name: "after", in: "query", description: "Id of the last customer of the previous page", schema: map[string]any{"type": "string", "format": "uuid"}},
+	}, status: http.StatusOK, response: []customer.CustomerResponse{}, errors: []int{http.StatusBadRequest}},
+
+	{method: http.MethodGet, path: "/api/v1/custom-fields/", tag: "custom fields", summary: "List the custom field definitions", access: loggedIn, status: http.StatusOK, response: []customer.CustomFieldResponse{}},
+	{method: http.MethodPost, path: "/api/v1/custom-fields/", tag: "custom fields", summary: "Define a custom field", access: adminOnly, request: customer.CustomFieldRequest{}, status: http.StatusCreated, response: customer.CustomFieldResponse{}, errors: []int{http.StatusConflict}},
+	{method: http.MethodDelete, path: "/api/v1/custom-fields/{key}", tag: "custom fields", summary: "Delete a custom field and every value of it", access: adminOnly, params: []parameter{
+		{name: "key", in: "path", required: true, schema: map[string]any{"type": "string"}},
+	}, status: http.StatusOK, response: messageResponse, errors: []int{http.StatusNotFound}},
+
+	{method: http.MethodPost, path: "/api/v1/services/", tag: "services", summary: "Create a service for a customer", access: adminOnly, request: service.ServiceRequest{}, status: http.StatusCreated, response: struct {
+		ServiceID int32 `json:"service_id"`
+	}{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodGet, path: "/api/v1/services/", tag: "services", summary: "List services", access: adminOnly, status: http.StatusOK, response: []sqlc.Service{}},
+	{method: http.MethodGet, path: "/api/v1/services/customer/{id}", tag: "services", summary: "List the services of a customer", access: adminOnly, params: []parameter{uuidPath}, status: http.StatusOK, response: []sqlc.Service{}, errors: []int{http.StatusBadRequest}},
+	{method: http.MethodGet, path: "/api/v1/services/count/{id}", tag: "services", summary: "Count the services of a customer", access: adminOnly, params: []parameter{uuidPath}, status: http.StatusOK, response: struct {
+		Count int64 `json:"count"`
+	}{}, errors: []int{http.StatusBadRequest}},
+	{method: http.MethodDelete, path: "/api/v1/services/", tag: "services", summary: "Delete a service", access: adminOnly, params: []parameter{
+		{name: "id", in: "query", required: true, schema: map[string]any{"type": "integer", "format": "int32"}},
+	}, status: http.StatusOK, response: messageResponse, errors: []int{http.StatusBadRequest}},
+	{method: http.MethodPatch, path: "/api/v1/services/finish", tag: "services", summary: "Mark a service as finished or not", access: adminOnly, request: service.UpdateServiceStatusRequest{}, status: http.StatusOK, response: sqlc.Service{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodPatch, path: "/api/v1/services/payment", tag: "services", summary: "Mark a service as paid or not", access: adminOnly, request: service.UpdateServiceStatusRequest{}, status: http.StatusOK, response: sqlc.Service{}, errors: []int{http.StatusNotFound}},
+}
+
+// openAPIDocument builds the OpenAPI 3 document for operations. cookieName
+// is the name of the session cookie.
+func openAPIDocument(cookieName string) map[string]any {
+	schemas := schemaRegistry{}
+	paths := map[string]any{}
+	for _, op := range operations {
+		item, ok := paths[op.path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[op.path] = item
+		}
+		item[strings.ToLower(op.method)] = op.document(schemas)
+	}
+
+	version := buildinfo.Get().Commit
+	if version == "" {
+		version = "dev"
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "client-manager API",
+			"version":     version,
+			"description": "Customer and service management. Errors are application/problem+json; messages follow Accept-Language (pt-BR or en).",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"session": map[string]any{"type": "apiKey", "in": "cookie", "name": cookieName},
+			},
+		},
+	}
+}
+
+func (op operation) document(schemas schemaRegistry) map[string]any {
+	doc := map[string]any{
+		"tags":        []string{op.tag},
+		"summary":     op.summary,
+		"operationId": operationID(op.method, op.path),
+	}
+
+	if len(op.params) > 0 {
+		params := make([]map[string]any, 0, len(op.params))
+		for _, p := range op.params {
+			param := map[string]any{"name": p.name, "in": p.in, "required": p.required, "schema": p.schema}
+			if p.description != "" {
+				param["description"] = p.description
+			}
+			params = append(params, param)
+		}
+		doc["parameters"] = params
+	}
+
+	switch {
+	case op.requestContent != nil:
+		doc["requestBody"] = map[string]any{"required": true, "content": op.requestContent}
+	case op.request != nil:
+		doc["requestBody"] = map[string]any{"required": true, "content": map[string]any{
+			"application/json": map[string]any{"schema": schemas.schemaOf(reflect.TypeOf(op.request))},
+		}}
+	}
+
+	content := op.responseContent
+	if content == nil {
+		content = map[string]any{"application/json": map[string]any{"schema": schemas.schemaOf(reflect.TypeOf(op.response))}}
+	}
+	responses := map[string]any{
+		strconv.Itoa(op.status): map[string]any{"description": http.StatusText(op.status), "content": content},
+	}
+
+	errs := append([]int{}, op.errors...)
+	if op.request != nil {
+		errs = append(errs, http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType)
+	}
+	switch op.access {
+	case loggedIn:
+		errs = append(errs, http.StatusUnauthorized)
+		doc["security"] = []map[string]any{{"session": []string{}}}
+	case adminOnly:
+		errs = append(errs, http.StatusUnauthorized, http.StatusForbidden)
+		doc["security"] = []map[string]any{{"session": []string{}}}
+	}
+	errs = append(errs, http.StatusInternalServerError)
+
+	problem := schemas.schemaOf(reflect.TypeOf(jsonutils.Problem{}))
+	for _, status := range errs {
+		responses[strconv.Itoa(status)] = map[string]any{
+			"description": http.StatusText(status),
+			"content":     map[string]any{jsonutils.ProblemContentType: map[string]any{"schema": problem}},
+		}
+	}
+	doc["responses"] = responses
+	return doc
+}
+
+// operationID turns "GET /api/v1/customers/{id}/export" into
+// "getApiV1CustomersIdExport".
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '{' || r == '}' || r == '.' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// schemaRegistry holds the component schemas of named struct types, keyed
+// by type name.
+type schemaRegistry map[string]any
+
+var (
+	uuidType        = reflect.TypeFor[uuid.UUID]()
+	timeType        = reflect.TypeFor[time.Time]()
+	rawMessageType  = reflect.TypeFor[json.RawMessage]()
+	dateType        = reflect.TypeFor[pgtype.Date]()
+	textType        = reflect.TypeFor[pgtype.Text]()
+	numericType     = reflect.TypeFor[pgtype.Numeric]()
+	timestamptzType = reflect.TypeFor[pgtype.Timestamptz]()
+	customerType    = reflect.TypeFor[sqlc.CustomerType]()
+)
+
+// schemaOf returns the JSON schema of t as encoding/json renders it. Named
+// structs are registered as components and referenced.
+func (s schemaRegistry) schemaOf(t reflect.Type) map[string]any {
+	switch t {
+	case uuidType:
+		return map[string]any{"type": "string", "format": "uuid"}
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return map[string]any{}
+	case dateType:
+		return map[string]any{"type": "string", "format": "date", "nullable": true}
+	case textType:
+		return map[string]any{"type": "string", "nullable": true}
+	case numericType:
+		return map[string]any{"type": "number", "nullable": true}
+	case timestamptzType:
+		return map[string]any{"type": "string", "format": "date-time", "nullable": true}
+	case customerType:
+		return map[string]any{"type": "string", "enum": []string{string(sqlc.CustomerTypePF), string(sqlc.CustomerTypePJ)}}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := s.schemaOf(t.Elem())
+		if _, isRef := schema["$ref"]; isRef {
+			return map[string]any{"allOf": []any{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": s.schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		if _, ok := s[t.Name()]; !ok {
+			s[t.Name()] = map[string]any{} // placeholder for recursive types
+			s[t.Name()] = s.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (s schemaRegistry) structSchema(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name := jsonName(f)
+		if name == "" {
+			continue
+		}
+		props[name] = s.schemaOf(f.Type)
+	}
+
+	schema := map[string]any{"type": "object", "properties": props}
+	if required := requiredFields(t); len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// jsonName is the name encoding/json uses for f, or "" when f is skipped.
+func jsonName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	}
+	return name
+}
+
+// requiredFields asks the type's own Validate method which fields the zero
+// value is missing, so the spec follows the request validators.
+func requiredFields(t reflect.Type) []string {
+	v, ok := reflect.New(t).Interface().(interface{ Validate() error })
+	if !ok {
+		return nil
+	}
+	var verrs validators.ValidationErrors
+	if !errors.As(v.Validate(), &verrs) {
+		return nil
+	}
+
+	var required []string
+	for i := range t.NumField() {
+		name := jsonName(t.Field(i))
+		if violation, ok := verrs.Violations[name]; ok && violation.Code == validators.CodeRequired {
+			required = append(required, name)
+		}
+	}
+	return required
+}
+
+// HandlerOpenAPI serves the OpenAPI document of the API.
+func (api *Api) HandlerOpenAPI(w http.ResponseWriter, r *http.Request) {
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, openAPIDocument(api.Sessions.Cookie.Name))
+}
+
+// HandlerDocs serves Swagger UI for /api/openapi.json. The UI assets are
+// loaded from a CDN.
+func (api *Api) HandlerDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(swaggerUI))
+}
+
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>client-manager API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/api/openapi.json", dom_id: "#swagger-ui", withCredentials: true });
+  </script>
+</body>
+</html>
+`
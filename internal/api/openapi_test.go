@@ -0,0 +1,92 @@
+package api_test
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type openAPISpec struct {
+	Paths      map[string]map[string]any `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Required   []string       `json:"required"`
+			Properties map[string]any `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+// TestOpenAPICoversRoutes fails when a route is added to or removed from
+// BindRoutes without updating the operations in openapi.go.
+func TestOpenAPICoversRoutes(t *testing.T) {
+	ts := newTestServer(t)
+
+	res := ts.do(t, ts.client(t, "", ""), http.MethodGet, "/api/openapi.json", nil)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", res.StatusCode)
+	}
+	spec := decode[openAPISpec](t, res)
+
+	var routes []string
+	err := chi.Walk(ts.api.Router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routes = append(routes, method+" "+route)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var documented []string
+	for path, item := range spec.Paths {
+		for method := range item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(routes)
+	sort.Strings(documented)
+	for _, r := range routes {
+		if !slices.Contains(documented, r) {
+			t.Errorf("route %s is not in the OpenAPI document", r)
+		}
+	}
+	for _, d := range documented {
+		if !slices.Contains(routes, d) {
+			t.Errorf("OpenAPI documents %s, which is not routed", d)
+		}
+	}
+}
+
+func TestOpenAPISchemasFollowRequestTypes(t *testing.T) {
+	ts := newTestServer(t)
+	spec := decode[openAPISpec](t, ts.do(t, ts.client(t, "", ""), http.MethodGet, "/api/openapi.json", nil))
+
+	pf, ok := spec.Components.Schemas["CustomerPFRequest"]
+	if !ok {
+		t.Fatal("CustomerPFRequest schema missing")
+	}
+	if _, ok := pf.Properties["birth_date"]; !ok {
+		t.Errorf("properties = %v, want birth_date", pf.Properties)
+	}
+	for _, field := range []string{"cpf", "birth_date", "cep"} {
+		if !slices.Contains(pf.Required, field) {
+			t.Errorf("required = %v, want %s", pf.Required, field)
+		}
+	}
+	if slices.Contains(pf.Required, "complement") {
+		t.Errorf("complement is optional, required = %v", pf.Required)
+	}
+}
+
+func TestDocsPage(t *testing.T) {
+	ts := newTestServer(t)
+
+	res := ts.do(t, ts.client(t, "", ""), http.MethodGet, "/api/docs", nil)
+	if res.StatusCode != http.StatusOK || !strings.HasPrefix(res.Header.Get("Content-Type"), "text/html") {
+		t.Errorf("status = %d, content type = %q", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+}
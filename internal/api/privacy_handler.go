@@ -0,0 +1,100 @@
+package api
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+)
+
+// HandlerExportCustomer answers an LGPD data access request. ?format=zip
+// returns one JSON file per section instead of a single document.
+func (api *Api) HandlerExportCustomer(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	customerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "zip" {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_export_format")
+		return
+	}
+
+	actorID, _ := GetAuthenticatedUserID(r.Context(), api.Sessions)
+	export, err := api.CustomerService.ExportCustomer(r.Context(), customerID, actorID)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	filename := "customer-" + customerID.String()
+	if format == "json" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".json"))
+		_ = jsonutils.EncodeJson(w, r, http.StatusOK, export)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".zip"))
+	w.WriteHeader(http.StatusOK)
+	if err := writeExportZip(w, export); err != nil {
+		log.Error("Failed to write customer export zip", err)
+	}
+}
+
+func writeExportZip(w io.Writer, export customer.CustomerExport) error {
+	zw := zip.NewWriter(w)
+	files := []struct {
+		name string
+		data any
+	}{
+		{"customer.json", export.Customer},
+		{"services.json", export.Services},
+		{"audit_trail.json", export.AuditTrail},
+		{"export.json", map[string]any{"exported_at": export.ExportedAt, "customer_id": export.Customer.ID}},
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// HandlerAnonymizeCustomer answers an LGPD erasure request.
+func (api *Api) HandlerAnonymizeCustomer(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
+		return
+	}
+
+	actorID, _ := GetAuthenticatedUserID(r.Context(), api.Sessions)
+	resp, err := api.CustomerService.AnonymizeCustomer(r.Context(), customerID, actorID)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, resp)
+}
@@ -0,0 +1,102 @@
+package api_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+)
+
+func TestAnonymizeKeepsServicesAndIsAudited(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	id := decode[map[string]uuid.UUID](t, res)["customer_id"]
+	res = ts.do(t, admin, http.MethodPost, "/api/v1/services/", map[string]any{
+		"customer_id": id, "type_product": "site", "description": "institutional site", "total_value": 100, "down_payment": 10,
+	})
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("create service status = %d", res.StatusCode)
+	}
+
+	path := "/api/v1/customers/" + id.String()
+	if res := ts.do(t, ts.client(t, userEmail, userPassword), http.MethodPost, path+"/anonymize", nil); res.StatusCode != http.StatusForbidden {
+		t.Errorf("non-admin anonymize status = %d", res.StatusCode)
+	}
+
+	res = ts.do(t, admin, http.MethodPost, path+"/anonymize", nil)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("anonymize status = %d", res.StatusCode)
+	}
+	got := decode[customer.CustomerResponse](t, res)
+	if !got.AnonymizedAt.Valid || got.IsActive || strings.Contains(got.Email, "maria") || got.Cpf != "" || got.BirthDate != "" || got.PfName != "Anonymized" {
+		t.Errorf("personal data left behind: %+v", got)
+	}
+	stored, err := ts.store.GetCustomerByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cpf, _ := stored.Cpf.(string); !strings.HasPrefix(cpf, "enc:") {
+		t.Errorf("stored cpf = %q, want an encrypted blank", cpf)
+	}
+	// The blind index is released, so the CPF can be registered again.
+	if res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload()); res.StatusCode != http.StatusCreated {
+		t.Errorf("re-registering the anonymized CPF status = %d", res.StatusCode)
+	}
+
+	if res := ts.do(t, admin, http.MethodPost, path+"/anonymize", nil); res.StatusCode != http.StatusConflict {
+		t.Errorf("second anonymize status = %d, want 409", res.StatusCode)
+	}
+
+	res = ts.do(t, admin, http.MethodGet, path+"/export", nil)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("export status = %d", res.StatusCode)
+	}
+	export := decode[customer.CustomerExport](t, res)
+	if len(export.Services) != 1 || len(export.Customer.Addresses) != 0 {
+		t.Errorf("services must be retained and addresses removed: %+v", export)
+	}
+	var actions []string
+	for _, e := range export.AuditTrail {
+		actions = append(actions, e.Action)
+	}
+	if strings.Join(actions, ",") != "customer.anonymized,customer.exported" {
+		t.Errorf("audit trail = %v", actions)
+	}
+}
+
+func TestExportZip(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	id := decode[map[string]uuid.UUID](t, res)["customer_id"]
+
+	res = ts.do(t, admin, http.MethodGet, "/api/v1/customers/"+id.String()+"/export?format=zip", nil)
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "application/zip" {
+		t.Fatalf("status = %d, content type = %q", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+	body, _ := io.ReadAll(res.Body)
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if strings.Join(names, ",") != "customer.json,services.json,audit_trail.json,export.json" {
+		t.Errorf("zip entries = %v", names)
+	}
+
+	if res := ts.do(t, admin, http.MethodGet, "/api/v1/customers/"+uuid.NewString()+"/export", nil); res.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown customer export status = %d", res.StatusCode)
+	}
+}
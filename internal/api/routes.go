@@ -1,13 +1,34 @@
 package api
 
 import (
+	"net/http"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
+	"github.com/josevitorrodriguess/client-manager/internal/metrics"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
 )
 
 func (api *Api) BindRoutes() {
-	api.Router.Use(middleware.RequestID, middleware.Recoverer, middleware.Logger, api.Sessions.LoadAndSave)
+	api.Router.Use(tracing.Middleware, metrics.Middleware, middleware.RequestID, RequestLogger, middleware.Recoverer, i18n.Middleware)
+
+	api.Router.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		respondProblem(w, r, http.StatusNotFound, "error.route_not_found")
+	})
+	api.Router.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		respondProblem(w, r, http.StatusMethodNotAllowed, "error.method_not_allowed")
+	})
+
+	api.Router.Get("/healthz", api.HandlerHealthz)
+	api.Router.Get("/readyz", api.HandlerReadyz)
+	api.Router.Get("/version", api.HandlerVersion)
+	api.Router.Method(http.MethodGet, "/metrics", metrics.Handler())
+
 	api.Router.Route("/api", func(r chi.Router) {
+		r.Use(api.Sessions.LoadAndSave, api.sessionUser)
+		r.Get("/openapi.json", api.HandlerOpenAPI)
+		r.Get("/docs", api.HandlerDocs)
 		r.Route("/v1", func(r chi.Router) {
 			r.Route("/users", func(r chi.Router) {
 				r.With(api.AdminMiddleware).Post("/register", api.SignUpUserHandler)
@@ -19,8 +40,27 @@ func (api *Api) BindRoutes() {
 				r.With(api.AuthMiddleware, api.AdminMiddleware).Post("/pf", api.HandlerCreatePFCustomer)
 				r.With(api.AuthMiddleware, api.AdminMiddleware).Post("/pj", api.HandlerCreatePJCustomer)
 				r.With(api.AuthMiddleware, api.AdminMiddleware).Post("/address", api.HandlerAddAddressToCostumer)
-				r.Get("/{id}", api.HandlerGetCustomerById)
-				r.Get("/", api.HandleGetAllCustomers)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Post("/import", api.HandlerImportCustomers)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Get("/search", api.HandlerFindCustomerByDocument)
+				r.With(api.AuthMiddleware).Get("/{id}", api.HandlerGetCustomerById)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Get("/{id}/export", api.HandlerExportCustomer)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Post("/{id}/anonymize", api.HandlerAnonymizeCustomer)
+				r.With(api.AuthMiddleware).Get("/{id}/contacts", api.HandlerListContacts)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Post("/{id}/contacts", api.HandlerAddContact)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Put("/{id}/contacts/{contactID}", api.HandlerUpdateContact)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Delete("/{id}/contacts/{contactID}", api.HandlerDeleteContact)
+				r.With(api.AuthMiddleware).Get("/{id}/notes", api.HandlerListNotes)
+				r.With(api.AuthMiddleware).Post("/{id}/notes", api.HandlerAddNote)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Delete("/{id}/notes/{noteID}", api.HandlerDeleteNote)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Put("/{id}/tags", api.HandlerSetTags)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Put("/{id}/custom-fields", api.HandlerSetCustomFields)
+				r.With(api.AuthMiddleware).Get("/", api.HandleGetAllCustomers)
+			})
+
+			r.Route("/custom-fields", func(r chi.Router) {
+				r.With(api.AuthMiddleware).Get("/", api.HandlerListCustomFields)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Post("/", api.HandlerCreateCustomField)
+				r.With(api.AuthMiddleware, api.AdminMiddleware).Delete("/{key}", api.HandlerDeleteCustomField)
 			})
 
 			r.Route("/services", func(r chi.Router) {
@@ -7,31 +7,31 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
 	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
 	"github.com/josevitorrodriguess/client-manager/internal/validators/service"
 	"go.uber.org/zap"
 )
 
 func (api *Api) HandlerCreateService(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
+	log := logger.FromContext(r.Context())
 
-	data, err := jsonutils.DecodeJson[service.ServiceRequest](r)
+	data, err := jsonutils.DecodeJson[service.ServiceRequest](r, api.Decoder)
 	if err != nil {
-		logger.Error("Failed to decode service request", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err.Error())
+		log.Warn("Failed to decode service request", zap.Error(err))
+		respondError(w, r, err)
 		return
 	}
 
-	ok, err := data.IsValid()
-	if !ok {
-		_ = jsonutils.EncodeJson(w, r, http.StatusBadRequest, err)
+	if err := data.Validate(); err != nil {
+		respondError(w, r, err)
 		return
 	}
 
 	serviceID, err := api.ServiceService.CreateService(r.Context(), data)
 	if err != nil {
-		logger.Error("Failed to create service", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err)
+		log.Error("Failed to create service", err)
+		respondError(w, r, err)
 		return
 	}
 
@@ -39,24 +39,23 @@ func (api *Api) HandlerCreateService(w http.ResponseWriter, r *http.Request) {
 }
 
 func (api *Api) HandlerGetServicesByCustomerID(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
+	log := logger.FromContext(r.Context())
 	customerIDStr := chi.URLParam(r,"id")
 	if customerIDStr == "" {
-		_ = jsonutils.EncodeJson(w, r, http.StatusBadRequest, "customer_id is required")
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
 		return
 	}
 
 	customerID, err := uuid.Parse(customerIDStr)
 	if err != nil {
-		logger.Error("Invalid customer_id format", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusBadRequest, "invalid customer_id format")
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
 		return
 	}
 
 	services, err := api.ServiceService.GetServicesByCustomerID(r.Context(), customerID)
 	if err != nil {
-		logger.Error("Failed to get services for customer", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusInternalServerError, err.Error())
+		log.Error("Failed to get services for customer", err)
+		respondError(w, r, err)
 		return
 	}
 
@@ -64,24 +63,23 @@ func (api *Api) HandlerGetServicesByCustomerID(w http.ResponseWriter, r *http.Re
 }
 
 func (api *Api) HandlerCountServicesByCustomerID(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
+	log := logger.FromContext(r.Context())
 	customerIDStr := chi.URLParam(r, "id")
 	if customerIDStr == "" {
-		_ = jsonutils.EncodeJson(w, r, http.StatusBadRequest, "customer_id is required")
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
 		return
 	}
 
 	customerID, err := uuid.Parse(customerIDStr)
 	if err != nil {
-		logger.Error("Invalid customer_id format", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusBadRequest, "invalid customer_id format")
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
 		return
 	}
 
 	count, err := api.ServiceService.CountServicesByCustomerID(r.Context(), customerID)
 	if err != nil {
-		logger.Error("Failed to count services for customer", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusInternalServerError, err.Error())
+		log.Error("Failed to count services for customer", err)
+		respondError(w, r, err)
 		return
 	}
 
@@ -89,12 +87,12 @@ func (api *Api) HandlerCountServicesByCustomerID(w http.ResponseWriter, r *http.
 }
 
 func (api *Api) HandlerListAllServices(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
+	log := logger.FromContext(r.Context())
 
 	services, err := api.ServiceService.ListAllServices(r.Context())
 	if err != nil {
-		logger.Error("Failed to list all services", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusInternalServerError, err.Error())
+		log.Error("Failed to list all services", err)
+		respondError(w, r, err)
 		return
 	}
 
@@ -102,44 +100,43 @@ func (api *Api) HandlerListAllServices(w http.ResponseWriter, r *http.Request) {
 }
 
 func (api *Api) HandlerDeleteService(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
+	log := logger.FromContext(r.Context())
 	idStr := r.URL.Query().Get("id")
 	if idStr == "" {
-		_ = jsonutils.EncodeJson(w, r, http.StatusBadRequest, "id is required")
+		respondProblem(w, r, http.StatusBadRequest, "error.service_id_required")
 		return
 	}
 
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		logger.Error("Invalid id format", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusBadRequest, "invalid id format")
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_service_id")
 		return
 	}
 
 	err = api.ServiceService.DeleteService(r.Context(), int32(id))
 	if err != nil {
-		logger.Error("Failed to delete service", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusInternalServerError, err.Error())
+		log.Error("Failed to delete service", err)
+		respondError(w, r, err)
 		return
 	}
 
-	_ = jsonutils.EncodeJson(w, r, http.StatusOK, map[string]string{"message": "service deleted successfully"})
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, map[string]string{"message": i18n.T(i18n.FromContext(r.Context()), "message.service_deleted")})
 }
 
 func (api *Api) HandlerUpdateServiceFinishStatus(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
+	log := logger.FromContext(r.Context())
 
-	request, err := jsonutils.DecodeJson[service.UpdateServiceStatusRequest](r)
+	request, err := jsonutils.DecodeJson[service.UpdateServiceStatusRequest](r, api.Decoder)
 	if err != nil {
-		logger.Error("Failed to decode request", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err.Error())
+		log.Warn("Failed to decode request", zap.Error(err))
+		respondError(w, r, err)
 		return
 	}
 
 	service, err := api.ServiceService.UpdateServiceFinishStatus(r.Context(), request.ID, request.Status)
 	if err != nil {
-		logger.Error("Failed to update service finish status", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusInternalServerError, err.Error())
+		log.Error("Failed to update service finish status", err)
+		respondError(w, r, err)
 		return
 	}
 
@@ -147,19 +144,19 @@ func (api *Api) HandlerUpdateServiceFinishStatus(w http.ResponseWriter, r *http.
 }
 
 func (api *Api) HandlerUpdateServicePaymentStatus(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
+	log := logger.FromContext(r.Context())
 
-	request, err := jsonutils.DecodeJson[service.UpdateServiceStatusRequest](r)
+	request, err := jsonutils.DecodeJson[service.UpdateServiceStatusRequest](r, api.Decoder)
 	if err != nil {
-		logger.Error("Failed to decode request", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err.Error())
+		log.Warn("Failed to decode request", zap.Error(err))
+		respondError(w, r, err)
 		return
 	}
 
 	service, err := api.ServiceService.UpdateServicePaymentStatus(r.Context(), request.ID, request.Status)
 	if err != nil {
-		logger.Error("Failed to update service payment status", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusInternalServerError, err.Error())
+		log.Error("Failed to update service payment status", err)
+		respondError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,82 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+)
+
+func TestServiceLifecycle(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("create customer status = %d", res.StatusCode)
+	}
+	customerID := decode[map[string]uuid.UUID](t, res)["customer_id"]
+
+	res = ts.do(t, admin, http.MethodPost, "/api/v1/services/", map[string]any{
+		"customer_id":  customerID,
+		"type_product": "site",
+		"description":  "institutional site",
+		"total_value":  1500.5,
+		"down_payment": 500,
+	})
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("create service status = %d", res.StatusCode)
+	}
+	serviceID := decode[map[string]int32](t, res)["service_id"]
+
+	res = ts.do(t, admin, http.MethodPatch, "/api/v1/services/payment", map[string]any{"id": serviceID, "status": true})
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("update payment status = %d", res.StatusCode)
+	}
+	if svc := decode[sqlc.Service](t, res); !svc.IsPaid {
+		t.Errorf("expected service to be paid: %+v", svc)
+	}
+
+	res = ts.do(t, admin, http.MethodGet, "/api/v1/services/count/"+customerID.String(), nil)
+	if got := decode[map[string]int64](t, res)["count"]; got != 1 {
+		t.Errorf("count = %d, want 1", got)
+	}
+
+	res = ts.do(t, admin, http.MethodGet, "/api/v1/services/customer/not-a-uuid", nil)
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid id status = %d, want %d", res.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestCreateServiceValidation(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/services/", map[string]any{
+		"customer_id":  uuid.NewString(),
+		"type_product": "site",
+		"description":  "institutional site",
+		"total_value":  100.555,
+		"down_payment": 50,
+	})
+	if res.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", res.StatusCode, http.StatusBadRequest)
+	}
+	p := decode[jsonutils.Problem](t, res)
+	if p.Errors["total_value"] == "" || p.Errors["down_payment"] != "" {
+		t.Errorf("errors = %v, want only total_value", p.Errors)
+	}
+
+	res = ts.do(t, admin, http.MethodPost, "/api/v1/services/", map[string]any{
+		"customer_id":  uuid.NewString(),
+		"type_product": "site",
+		"description":  "institutional site",
+		"total_value":  100,
+		"down_payment": 150,
+	})
+	if p := decode[jsonutils.Problem](t, res); p.Errors["down_payment"] != "não pode ser maior que total_value" {
+		t.Errorf("errors = %v, want down_payment above total_value", p.Errors)
+	}
+}
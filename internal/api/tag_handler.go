@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+)
+
+// HandlerSetTags replaces the tags of a customer.
+func (api *Api) HandlerSetTags(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondProblem(w, r, http.StatusBadRequest, "error.invalid_customer_id")
+		return
+	}
+
+	data, err := jsonutils.DecodeJson[customer.TagsRequest](r, api.Decoder)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if err := data.Validate(); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	tags, err := api.CustomerService.SetTags(r.Context(), customerID, data.Tags)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = jsonutils.EncodeJson(w, r, http.StatusOK, customer.TagsRequest{Tags: tags})
+}
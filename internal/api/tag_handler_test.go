@@ -0,0 +1,67 @@
+package api_test
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+)
+
+func TestTagsFilterCustomerList(t *testing.T) {
+	ts := newTestServer(t)
+	admin := ts.client(t, adminEmail, adminPassword)
+
+	res := ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", pfPayload())
+	maria := decode[map[string]uuid.UUID](t, res)["customer_id"]
+	other := pfPayload()
+	other["email"], other["phone"], other["cpf"], other["name"] = "joao@example.com", "21 99876-5432", "529.982.247-25", "Joao Lima"
+	res = ts.do(t, admin, http.MethodPost, "/api/v1/customers/pf", other)
+	joao := decode[map[string]uuid.UUID](t, res)["customer_id"]
+
+	res = ts.do(t, admin, http.MethodPut, "/api/v1/customers/"+maria.String()+"/tags", map[string]any{"tags": []string{" VIP ", "recorrente", "vip"}})
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("set tags status = %d", res.StatusCode)
+	}
+	if got := decode[customer.TagsRequest](t, res).Tags; len(got) != 2 || got[0] != "recorrente" || got[1] != "vip" {
+		t.Errorf("tags = %v, want [recorrente vip]", got)
+	}
+	ts.do(t, admin, http.MethodPut, "/api/v1/customers/"+joao.String()+"/tags", map[string]any{"tags": []string{"vip"}})
+
+	tests := []struct {
+		query string
+		want  []uuid.UUID
+	}{
+		{"", []uuid.UUID{maria, joao}},
+		{"?tag=vip", []uuid.UUID{maria, joao}},
+		{"?tag=VIP&tag=recorrente", []uuid.UUID{maria}},
+		{"?tag=inadimplente", nil},
+	}
+	for _, tt := range tests {
+		res := ts.do(t, admin, http.MethodGet, "/api/v1/customers/"+tt.query, nil)
+		if len(tt.want) == 0 {
+			if res.StatusCode != http.StatusOK {
+				t.Errorf("%q status = %d", tt.query, res.StatusCode)
+			}
+			continue
+		}
+		got := decode[[]customer.CustomerResponse](t, res)
+		if len(got) != len(tt.want) {
+			t.Errorf("%q listed %d customers, want %d", tt.query, len(got), len(tt.want))
+			continue
+		}
+		for _, c := range got {
+			if !slices.Contains(tt.want, c.ID) || len(c.Tags) == 0 {
+				t.Errorf("%q listed %s with tags %v", tt.query, c.ID, c.Tags)
+			}
+		}
+	}
+
+	if res := ts.do(t, admin, http.MethodPut, "/api/v1/customers/"+maria.String()+"/tags", map[string]any{"tags": []string{"não vale!"}}); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid tag status = %d, want 400", res.StatusCode)
+	}
+	if res := ts.do(t, admin, http.MethodPut, "/api/v1/customers/"+uuid.NewString()+"/tags", map[string]any{"tags": []string{"vip"}}); res.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown customer status = %d, want 404", res.StatusCode)
+	}
+}
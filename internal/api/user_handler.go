@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
 	"github.com/josevitorrodriguess/client-manager/internal/jsonutils"
 	"github.com/josevitorrodriguess/client-manager/internal/services"
 	"github.com/josevitorrodriguess/client-manager/internal/validators/user"
@@ -12,29 +13,26 @@ import (
 )
 
 func (api *Api) SignUpUserHandler(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
+	log := logger.FromContext(r.Context())
 
-	data, err := jsonutils.DecodeJson[user.UserRequest](r)
+	data, err := jsonutils.DecodeJson[user.UserRequest](r, api.Decoder)
 	if err != nil {
-		logger.Error("Failed to decode signup request", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err.Error())
+		log.Warn("Failed to decode signup request", zap.Error(err))
+		respondError(w, r, err)
 		return
 	}
 
-	ok, err := data.IsValid()
-	if !ok {
-		_ = jsonutils.EncodeJson(w, r, http.StatusBadRequest, err)
+	if err := data.Validate(); err != nil {
+		respondError(w, r, err)
 		return
 	}
 
 	id, err := api.UserService.Create(r.Context(), data)
 	if err != nil {
-		if errors.Is(err, services.ErrDuplicatedEmailOrUsername) {
-			_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err)
-			return
+		if !errors.Is(err, services.ErrDuplicatedEmailOrUsername) {
+			log.Error("Failed to create user", err)
 		}
-		logger.Error("Failed to create user", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, err)
+		respondError(w, r, err)
 		return
 	}
 
@@ -42,47 +40,45 @@ func (api *Api) SignUpUserHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func (api *Api) LoginUserHandler(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
+	log := logger.FromContext(r.Context())
 
-	data, err := jsonutils.DecodeJson[user.UserRequestLogin](r)
+	data, err := jsonutils.DecodeJson[user.UserRequestLogin](r, api.Decoder)
 	if err != nil {
-		logger.Error("Failed to decode login request", err, zap.String("request_id", requestID))
-		_ = jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, map[string]any{"error": "invalid json"})
+		log.Warn("Failed to decode login request", zap.Error(err))
+		respondError(w, r, err)
 		return
 	}
 
 	id, err := api.UserService.AuthenticateUser(r.Context(), data.Email, string(data.Password))
 	if err != nil {
-		if errors.Is(err, services.ErrInvalidCredentials) {
-			jsonutils.EncodeJson(w, r, http.StatusUnprocessableEntity, map[string]any{"error": "invalid credentials"})
-			return
+		if !errors.Is(err, services.ErrInvalidCredentials) {
+			log.Error("Authentication error", err)
 		}
-		logger.Error("Authentication error", err, zap.String("request_id", requestID))
-		jsonutils.EncodeJson(w, r, http.StatusInternalServerError, map[string]any{"error": "internal server error"})
+		respondError(w, r, err)
 		return
 	}
 
 	err = api.Sessions.RenewToken(r.Context())
 	if err != nil {
-		logger.Error("Failed to renew session token", err, zap.String("request_id", requestID))
-		jsonutils.EncodeJson(w, r, http.StatusInternalServerError, map[string]any{"error": "internal server error"})
+		log.Error("Failed to renew session token", err)
+		respondError(w, r, err)
 		return
 	}
 
 	api.Sessions.Put(r.Context(), "AuthenticatedUserId", id.String())
-	jsonutils.EncodeJson(w, r, http.StatusOK, map[string]any{"message": "logged in sucessfully"})
+	jsonutils.EncodeJson(w, r, http.StatusOK, map[string]any{"message": i18n.T(i18n.FromContext(r.Context()), "message.logged_in")})
 }
 
 func (api *Api) LogoutUserHandler(w http.ResponseWriter, r *http.Request) {
-	requestID := r.Header.Get("X-Request-ID")
+	log := logger.FromContext(r.Context())
 
 	err := api.Sessions.RenewToken(r.Context())
 	if err != nil {
-		logger.Error("Failed to renew session token during logout", err, zap.String("request_id", requestID))
-		jsonutils.EncodeJson(w, r, http.StatusInternalServerError, map[string]any{"error": "internal server error"})
+		log.Error("Failed to renew session token during logout", err)
+		respondError(w, r, err)
 		return
 	}
 
 	api.Sessions.Remove(r.Context(), "AuthenticatedUserId")
-	jsonutils.EncodeJson(w, r, http.StatusOK, map[string]any{"message": "logged out sucessfully"})
+	jsonutils.EncodeJson(w, r, http.StatusOK, map[string]any{"message": i18n.T(i18n.FromContext(r.Context()), "message.logged_out")})
 }
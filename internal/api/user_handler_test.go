@@ -0,0 +1,55 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestLogin(t *testing.T) {
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		want     int
+	}{
+		{"valid credentials", adminEmail, adminPassword, http.StatusOK},
+		{"wrong password", adminEmail, "wrong-password", http.StatusUnauthorized},
+		{"unknown email", "nobody@example.com", adminPassword, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, ts.client(t, "", ""), http.MethodPost, "/api/v1/users/login",
+				map[string]string{"email": tt.email, "password": tt.password})
+			if res.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client(t, userEmail, userPassword)
+
+	if res := ts.do(t, c, http.MethodPost, "/api/v1/users/logout", nil); res.StatusCode != http.StatusOK {
+		t.Fatalf("logout status = %d", res.StatusCode)
+	}
+	if res := ts.do(t, c, http.MethodPost, "/api/v1/users/logout", nil); res.StatusCode != http.StatusUnauthorized {
+		t.Errorf("second logout status = %d, want %d", res.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestRegisterRequiresAdmin(t *testing.T) {
+	ts := newTestServer(t)
+	payload := map[string]any{"name": "New Person", "email": "new@example.com", "password": "new-password"}
+
+	if res := ts.do(t, ts.client(t, userEmail, userPassword), http.MethodPost, "/api/v1/users/register", payload); res.StatusCode != http.StatusForbidden {
+		t.Errorf("regular user status = %d, want %d", res.StatusCode, http.StatusForbidden)
+	}
+	if res := ts.do(t, ts.client(t, adminEmail, adminPassword), http.MethodPost, "/api/v1/users/register", payload); res.StatusCode != http.StatusCreated {
+		t.Errorf("admin status = %d, want %d", res.StatusCode, http.StatusCreated)
+	}
+}
@@ -0,0 +1,429 @@
+// Package config loads the application configuration. Values are read, in
+// increasing order of precedence, from built-in defaults, an optional YAML
+// file, a .env file and the process environment, and are validated once at
+// startup so a misconfigured deploy fails fast with a clear message.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const EnvConfigFile = "CONFIG_FILE"
+
+type Config struct {
+	Database    DatabaseConfig   `yaml:"database"`
+	HTTP        HTTPConfig       `yaml:"http"`
+	Session     SessionConfig    `yaml:"session"`
+	Log         LogConfig        `yaml:"log"`
+	Tracing     TracingConfig    `yaml:"tracing"`
+	Encryption  EncryptionConfig `yaml:"encryption"`
+	Admin       AdminConfig      `yaml:"admin"`
+	AutoMigrate bool             `yaml:"auto_migrate"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Name     string `yaml:"name"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	SSLMode  string `yaml:"sslmode"`
+	MaxConns int32  `yaml:"max_conns"`
+}
+
+type HTTPConfig struct {
+	Host              string        `yaml:"host"`
+	Port              string        `yaml:"port"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+	TLSCertFile       string        `yaml:"tls_cert_file"`
+	TLSKeyFile        string        `yaml:"tls_key_file"`
+	MaxBodyBytes      int64         `yaml:"max_body_bytes"`
+}
+
+type SessionConfig struct {
+	Lifetime       time.Duration `yaml:"lifetime"`
+	IdleTimeout    time.Duration `yaml:"idle_timeout"`
+	CookieName     string        `yaml:"cookie_name"`
+	CookieSecure   bool          `yaml:"cookie_secure"`
+	CookieSameSite string        `yaml:"cookie_same_site"`
+}
+
+type LogConfig struct {
+	Level       string `yaml:"level"`
+	Output      string `yaml:"output"`
+	ServiceName string `yaml:"service_name"`
+	// PIIPolicy is mask, redact or plain; see logger.PIIPolicy.
+	PIIPolicy string `yaml:"pii_policy"`
+}
+
+// TracingConfig selects where OpenTelemetry spans go. Exporter is none
+// (tracing disabled), stdout or otlp; Endpoint is the OTLP/HTTP collector
+// address, e.g. localhost:4318.
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// EncryptionConfig points at the key file used to encrypt CPF, CNPJ and
+// birth dates at rest; create one with `client-manager keys generate`. Only
+// the commands that encrypt or decrypt customers need it, so Config.Validate
+// leaves it alone and they call EncryptionConfig.Validate instead.
+type EncryptionConfig struct {
+	KeyFile string `yaml:"key_file"`
+}
+
+// Validate reports a missing or unreadable key file.
+func (e EncryptionConfig) Validate() error {
+	if strings.TrimSpace(e.KeyFile) == "" {
+		return errors.New("config: encryption.key_file (ENCRYPTION_KEY_FILE) is required")
+	}
+	if _, err := os.Stat(e.KeyFile); err != nil {
+		return fmt.Errorf("config: encryption.key_file (ENCRYPTION_KEY_FILE): %w", err)
+	}
+	return nil
+}
+
+// AdminConfig describes the admin seeded at startup. Seeding is skipped when
+// Email is empty.
+type AdminConfig struct {
+	Name     string `yaml:"name"`
+	Email    string `yaml:"email"`
+	Password string `yaml:"password"`
+}
+
+func Default() Config {
+	return Config{
+		Database: DatabaseConfig{
+			Host:    "localhost",
+			Port:    "5432",
+			SSLMode: "disable",
+		},
+		HTTP: HTTPConfig{
+			Host:              "0.0.0.0",
+			Port:              "3080",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			ShutdownTimeout:   20 * time.Second,
+			MaxBodyBytes:      1 << 20,
+		},
+		Session: SessionConfig{
+			Lifetime:       24 * time.Hour,
+			CookieName:     "session",
+			CookieSameSite: "lax",
+		},
+		Log: LogConfig{
+			Level:       "info",
+			Output:      "stdout",
+			ServiceName: "client-manager",
+			PIIPolicy:   "mask",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			SampleRatio: 1,
+		},
+	}
+}
+
+// Load builds the configuration. path may be empty, in which case the file
+// named by CONFIG_FILE (if any) is used.
+func Load(path string) (*Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("config: reading .env: %w", err)
+	}
+
+	cfg := Default()
+
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: reading %s: %w", path, err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) loadEnv() error {
+	e := envReader{}
+
+	e.string("DATABASE_HOST", &c.Database.Host)
+	e.string("DATABASE_PORT", &c.Database.Port)
+	e.string("DATABASE_NAME", &c.Database.Name)
+	e.string("DATABASE_USER", &c.Database.User)
+	e.string("DATABASE_PASSWORD", &c.Database.Password)
+	e.string("DATABASE_SSLMODE", &c.Database.SSLMode)
+	e.int32("DATABASE_MAX_CONNS", &c.Database.MaxConns)
+
+	e.string("HTTP_HOST", &c.HTTP.Host)
+	e.string("HTTP_PORT", &c.HTTP.Port)
+	e.duration("HTTP_READ_TIMEOUT", &c.HTTP.ReadTimeout)
+	e.duration("HTTP_READ_HEADER_TIMEOUT", &c.HTTP.ReadHeaderTimeout)
+	e.duration("HTTP_WRITE_TIMEOUT", &c.HTTP.WriteTimeout)
+	e.duration("HTTP_IDLE_TIMEOUT", &c.HTTP.IdleTimeout)
+	e.duration("HTTP_SHUTDOWN_TIMEOUT", &c.HTTP.ShutdownTimeout)
+	e.string("HTTP_TLS_CERT_FILE", &c.HTTP.TLSCertFile)
+	e.string("HTTP_TLS_KEY_FILE", &c.HTTP.TLSKeyFile)
+	e.int64("HTTP_MAX_BODY_BYTES", &c.HTTP.MaxBodyBytes)
+
+	e.duration("SESSION_LIFETIME", &c.Session.Lifetime)
+	e.duration("SESSION_IDLE_TIMEOUT", &c.Session.IdleTimeout)
+	e.string("SESSION_COOKIE_NAME", &c.Session.CookieName)
+	e.bool("SESSION_COOKIE_SECURE", &c.Session.CookieSecure)
+	e.string("SESSION_COOKIE_SAME_SITE", &c.Session.CookieSameSite)
+
+	e.string("LOG_LEVEL", &c.Log.Level)
+	e.string("LOG_OUTPUT", &c.Log.Output)
+	e.string("SERVICE_NAME", &c.Log.ServiceName)
+	e.string("LOG_PII_POLICY", &c.Log.PIIPolicy)
+
+	e.string("TRACING_EXPORTER", &c.Tracing.Exporter)
+	e.string("TRACING_ENDPOINT", &c.Tracing.Endpoint)
+	e.bool("TRACING_INSECURE", &c.Tracing.Insecure)
+	e.float64("TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio)
+
+	e.string("ENCRYPTION_KEY_FILE", &c.Encryption.KeyFile)
+
+	e.string("ADMIN_NAME", &c.Admin.Name)
+	e.string("ADMIN_EMAIL", &c.Admin.Email)
+	e.string("ADMIN_PASSWORD", &c.Admin.Password)
+
+	e.bool("AUTO_MIGRATE", &c.AutoMigrate)
+
+	return errors.Join(e.errs...)
+}
+
+// Validate reports every invalid or missing setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	required := func(name, value string) {
+		if strings.TrimSpace(value) == "" {
+			errs = append(errs, fmt.Errorf("config: %s is required", name))
+		}
+	}
+	port := func(name, value string) {
+		if n, err := strconv.Atoi(value); err != nil || n < 1 || n > 65535 {
+			errs = append(errs, fmt.Errorf("config: %s must be a port number, got %q", name, value))
+		}
+	}
+
+	required("database.host (DATABASE_HOST)", c.Database.Host)
+	required("database.name (DATABASE_NAME)", c.Database.Name)
+	required("database.user (DATABASE_USER)", c.Database.User)
+	port("database.port (DATABASE_PORT)", c.Database.Port)
+	if c.Database.MaxConns < 0 {
+		errs = append(errs, errors.New("config: database.max_conns (DATABASE_MAX_CONNS) cannot be negative"))
+	}
+
+	port("http.port (HTTP_PORT)", c.HTTP.Port)
+	for name, d := range map[string]time.Duration{
+		"http.read_timeout (HTTP_READ_TIMEOUT)":               c.HTTP.ReadTimeout,
+		"http.read_header_timeout (HTTP_READ_HEADER_TIMEOUT)": c.HTTP.ReadHeaderTimeout,
+		"http.write_timeout (HTTP_WRITE_TIMEOUT)":             c.HTTP.WriteTimeout,
+		"http.idle_timeout (HTTP_IDLE_TIMEOUT)":               c.HTTP.IdleTimeout,
+		"http.shutdown_timeout (HTTP_SHUTDOWN_TIMEOUT)":       c.HTTP.ShutdownTimeout,
+	} {
+		if d < 0 {
+			errs = append(errs, fmt.Errorf("config: %s cannot be negative", name))
+		}
+	}
+	if c.HTTP.MaxBodyBytes <= 0 {
+		errs = append(errs, errors.New("config: http.max_body_bytes (HTTP_MAX_BODY_BYTES) must be positive"))
+	}
+	if (c.HTTP.TLSCertFile == "") != (c.HTTP.TLSKeyFile == "") {
+		errs = append(errs, errors.New("config: http.tls_cert_file (HTTP_TLS_CERT_FILE) and http.tls_key_file (HTTP_TLS_KEY_FILE) must be set together"))
+	}
+	for name, path := range map[string]string{
+		"http.tls_cert_file (HTTP_TLS_CERT_FILE)": c.HTTP.TLSCertFile,
+		"http.tls_key_file (HTTP_TLS_KEY_FILE)":   c.HTTP.TLSKeyFile,
+	} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			errs = append(errs, fmt.Errorf("config: %s: %w", name, err))
+		}
+	}
+
+	if c.Session.Lifetime <= 0 {
+		errs = append(errs, errors.New("config: session.lifetime (SESSION_LIFETIME) must be positive"))
+	}
+	if c.Session.IdleTimeout < 0 {
+		errs = append(errs, errors.New("config: session.idle_timeout (SESSION_IDLE_TIMEOUT) cannot be negative"))
+	}
+	required("session.cookie_name (SESSION_COOKIE_NAME)", c.Session.CookieName)
+	if _, err := c.Session.SameSite(); err != nil {
+		errs = append(errs, err)
+	}
+	if strings.EqualFold(c.Session.CookieSameSite, "none") && !c.Session.CookieSecure {
+		errs = append(errs, errors.New("config: session.cookie_same_site=none requires session.cookie_secure"))
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("config: log.level (LOG_LEVEL) must be debug, info, warn or error, got %q", c.Log.Level))
+	}
+
+	switch strings.ToLower(c.Log.PIIPolicy) {
+	case "mask", "redact", "plain":
+	default:
+		errs = append(errs, fmt.Errorf("config: log.pii_policy (LOG_PII_POLICY) must be mask, redact or plain, got %q", c.Log.PIIPolicy))
+	}
+
+	switch strings.ToLower(c.Tracing.Exporter) {
+	case "none", "", "stdout":
+	case "otlp":
+		required("tracing.endpoint (TRACING_ENDPOINT)", c.Tracing.Endpoint)
+	default:
+		errs = append(errs, fmt.Errorf("config: tracing.exporter (TRACING_EXPORTER) must be none, stdout or otlp, got %q", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("config: tracing.sample_ratio (TRACING_SAMPLE_RATIO) must be between 0 and 1, got %v", c.Tracing.SampleRatio))
+	}
+
+	if c.Admin.Email != "" && len(c.Admin.Password) < 8 {
+		errs = append(errs, errors.New("config: admin.password (ADMIN_PASSWORD) must have at least 8 characters when admin.email is set"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d DatabaseConfig) DSN() string {
+	return fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s sslmode=%s",
+		d.User, d.Password, d.Host, d.Port, d.Name, d.SSLMode)
+}
+
+func (h HTTPConfig) Addr() string {
+	return net.JoinHostPort(h.Host, h.Port)
+}
+
+func (h HTTPConfig) TLSEnabled() bool {
+	return h.TLSCertFile != "" && h.TLSKeyFile != ""
+}
+
+func (s SessionConfig) SameSite() (http.SameSite, error) {
+	switch strings.ToLower(s.CookieSameSite) {
+	case "lax", "":
+		return http.SameSiteLaxMode, nil
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
+	}
+	return 0, fmt.Errorf("config: session.cookie_same_site (SESSION_COOKIE_SAME_SITE) must be lax, strict or none, got %q", s.CookieSameSite)
+}
+
+// envReader overrides fields with environment variables that are set,
+// collecting parse errors instead of stopping at the first one.
+type envReader struct {
+	errs []error
+}
+
+func (e *envReader) lookup(key string) (string, bool) {
+	v, ok := os.LookupEnv(key)
+	v = strings.TrimSpace(v)
+	return v, ok && v != ""
+}
+
+func (e *envReader) string(key string, dst *string) {
+	if v, ok := e.lookup(key); ok {
+		*dst = v
+	}
+}
+
+func (e *envReader) bool(key string, dst *bool) {
+	if v, ok := e.lookup(key); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("config: %s must be true or false, got %q", key, v))
+			return
+		}
+		*dst = b
+	}
+}
+
+func (e *envReader) int32(key string, dst *int32) {
+	if v, ok := e.lookup(key); ok {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("config: %s must be an integer, got %q", key, v))
+			return
+		}
+		*dst = int32(n)
+	}
+}
+
+func (e *envReader) int64(key string, dst *int64) {
+	if v, ok := e.lookup(key); ok {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("config: %s must be an integer, got %q", key, v))
+			return
+		}
+		*dst = n
+	}
+}
+
+func (e *envReader) duration(key string, dst *time.Duration) {
+	if v, ok := e.lookup(key); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("config: %s must be a duration like 24h, got %q", key, v))
+			return
+		}
+		*dst = d
+	}
+}
+
+func (e *envReader) float64(key string, dst *float64) {
+	if v, ok := e.lookup(key); ok {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("config: %s must be a number, got %q", key, v))
+			return
+		}
+		*dst = f
+	}
+}
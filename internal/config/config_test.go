@@ -0,0 +1,120 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/josevitorrodriguess/client-manager/internal/config"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFileThenEnv(t *testing.T) {
+	path := writeFile(t, `
+database:
+  name: from_file
+  user: file_user
+http:
+  port: "8080"
+session:
+  lifetime: 2h
+`)
+	t.Setenv("DATABASE_USER", "env_user")
+	t.Setenv("SESSION_COOKIE_SECURE", "true")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.Database.Name != "from_file" {
+		t.Errorf("database.name = %q, want value from file", cfg.Database.Name)
+	}
+	if cfg.Database.User != "env_user" {
+		t.Errorf("database.user = %q, env must override the file", cfg.Database.User)
+	}
+	if cfg.HTTP.Addr() != "0.0.0.0:8080" {
+		t.Errorf("http addr = %q", cfg.HTTP.Addr())
+	}
+	if cfg.Session.Lifetime != 2*time.Hour || !cfg.Session.CookieSecure {
+		t.Errorf("unexpected session config: %+v", cfg.Session)
+	}
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	path := writeFile(t, "database:\n  host: db\n")
+	t.Setenv("HTTP_PORT", "not-a-port")
+	t.Setenv("LOG_LEVEL", "loud")
+
+	_, err := config.Load(path)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"database.name", "database.user", "http.port", "log.level"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadRejectsUnknownFileKeys(t *testing.T) {
+	path := writeFile(t, "databse:\n  name: typo\n")
+	if _, err := config.Load(path); err == nil {
+		t.Fatal("expected unknown keys to be rejected")
+	}
+}
+
+func TestLoadRejectsMalformedEnv(t *testing.T) {
+	t.Setenv("DATABASE_NAME", "db")
+	t.Setenv("DATABASE_USER", "user")
+	t.Setenv("SESSION_LIFETIME", "one day")
+
+	_, err := config.Load("")
+	if err == nil || !strings.Contains(err.Error(), "SESSION_LIFETIME") {
+		t.Fatalf("expected a SESSION_LIFETIME error, got %v", err)
+	}
+}
+
+func TestLoadRequiresBothTLSFiles(t *testing.T) {
+	t.Setenv("DATABASE_NAME", "db")
+	t.Setenv("DATABASE_USER", "user")
+	t.Setenv("HTTP_TLS_CERT_FILE", writeFile(t, "cert"))
+
+	_, err := config.Load("")
+	if err == nil || !strings.Contains(err.Error(), "HTTP_TLS_KEY_FILE") {
+		t.Fatalf("expected a TLS pairing error, got %v", err)
+	}
+}
+
+func TestEncryptionKeyFileIsOnlyCheckedOnDemand(t *testing.T) {
+	t.Setenv("DATABASE_NAME", "db")
+	t.Setenv("DATABASE_USER", "user")
+	t.Setenv("ENCRYPTION_KEY_FILE", "")
+
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("Load without a key file: %v", err)
+	}
+	if err := cfg.Encryption.Validate(); err == nil || !strings.Contains(err.Error(), "ENCRYPTION_KEY_FILE") {
+		t.Errorf("expected a missing key file error, got %v", err)
+	}
+
+	cfg.Encryption.KeyFile = filepath.Join(t.TempDir(), "missing.json")
+	if err := cfg.Encryption.Validate(); err == nil {
+		t.Error("expected an error for a key file that does not exist")
+	}
+	cfg.Encryption.KeyFile = writeFile(t, "{}")
+	if err := cfg.Encryption.Validate(); err != nil {
+		t.Errorf("existing key file: %v", err)
+	}
+}
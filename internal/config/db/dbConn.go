@@ -3,44 +3,45 @@ package db
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/josevitorrodriguess/client-manager/internal/config"
 	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
 	"go.uber.org/zap"
 )
 
-func InitPool(ctx context.Context) *pgxpool.Pool {
+// InitPool opens the connection pool and pings the database. The caller owns
+// the pool and must Close it.
+func InitPool(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
 	logger.Debug("Initializing database connection pool")
 
-	host := os.Getenv("DATABASE_HOST")
-	port := os.Getenv("DATABASE_PORT")
-	dbname := os.Getenv("DATABASE_NAME")
-	user := os.Getenv("DATABASE_USER")
-
 	logger.Debug("Database connection parameters",
-		zap.String("host", host),
-		zap.String("port", port),
-		zap.String("database", dbname),
-		zap.String("user", user))
-
-	pool, err := pgxpool.New(ctx, fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s sslmode=disable",
-		user,
-		os.Getenv("DATABASE_PASSWORD"),
-		host,
-		port,
-		dbname))
+		zap.String("host", cfg.Host),
+		zap.String("port", cfg.Port),
+		zap.String("database", cfg.Name),
+		zap.String("user", cfg.User))
+
+	poolCfg, err := pgxpool.ParseConfig(cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+	poolCfg.ConnConfig.Tracer = tracing.QueryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
-		logger.Error("Failed to create database connection pool", err)
-		panic(err)
+		return nil, fmt.Errorf("failed to create database connection pool: %w", err)
 	}
 
 	// Test the connection
 	if err := pool.Ping(ctx); err != nil {
-		logger.Error("Failed to ping database", err)
-		panic(err)
+		pool.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	logger.Info("Database connection pool initialized successfully")
-	return pool
+	return pool, nil
 }
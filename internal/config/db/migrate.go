@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/db/migrations"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+	"go.uber.org/zap"
+)
+
+const (
+	MigrateUp     = "up"
+	MigrateDown   = "down"
+	MigrateStatus = "status"
+	MigrateRedo   = "redo"
+)
+
+// NewMigrator returns a goose provider for the embedded migrations. Every
+// operation holds a Postgres advisory lock, so replicas starting at the same
+// time apply migrations one after the other instead of racing.
+//
+// The provider talks to the database through a database/sql handle built
+// with stdlib.OpenDBFromPool, which borrows connections from pool. The caller
+// must Close the provider: that closes the handle and returns its
+// connections, but leaves pool open for the caller to close.
+func NewMigrator(pool *pgxpool.Pool) (*goose.Provider, error) {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration locker: %w", err)
+	}
+
+	provider, err := goose.NewProvider(goose.DialectPostgres, stdlib.OpenDBFromPool(pool), migrations.FS,
+		goose.WithSessionLocker(locker))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration provider: %w", err)
+	}
+	return provider, nil
+}
+
+// Migrate runs one of the up, down, status or redo commands against the
+// database behind pool.
+func Migrate(ctx context.Context, pool *pgxpool.Pool, command string) error {
+	provider, err := NewMigrator(pool)
+	if err != nil {
+		return err
+	}
+	defer provider.Close()
+
+	var results []*goose.MigrationResult
+	switch command {
+	case MigrateUp:
+		results, err = provider.Up(ctx)
+	case MigrateDown:
+		var res *goose.MigrationResult
+		res, err = provider.Down(ctx)
+		results = appendResult(results, res)
+	case MigrateRedo:
+		var res *goose.MigrationResult
+		res, err = provider.Down(ctx)
+		results = appendResult(results, res)
+		if err == nil {
+			res, err = provider.UpByOne(ctx)
+			results = appendResult(results, res)
+		}
+	case MigrateStatus:
+		return logStatus(ctx, provider)
+	default:
+		return fmt.Errorf("unknown migrate command %q (want up, down, status or redo)", command)
+	}
+
+	for _, r := range results {
+		logger.Info("Migration applied",
+			zap.Int64("version", r.Source.Version),
+			zap.String("direction", r.Direction),
+			zap.String("file", r.Source.Path),
+			zap.Duration("duration", r.Duration))
+	}
+	if err != nil {
+		logger.Error("Migration failed", err, zap.String("command", command))
+		return err
+	}
+	if len(results) == 0 {
+		logger.Info("No migrations to apply", zap.String("command", command))
+	}
+	return nil
+}
+
+func appendResult(results []*goose.MigrationResult, r *goose.MigrationResult) []*goose.MigrationResult {
+	if r == nil {
+		return results
+	}
+	return append(results, r)
+}
+
+func logStatus(ctx context.Context, provider *goose.Provider) error {
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		logger.Error("Failed to read migration status", err)
+		return err
+	}
+
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.State == goose.StateApplied {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		logger.Info("Migration status",
+			zap.Int64("version", s.Source.Version),
+			zap.String("file", s.Source.Path),
+			zap.String("state", string(s.State)),
+			zap.String("applied_at", appliedAt))
+	}
+	return nil
+}
+
+// PendingMigrations reports whether the database is behind the embedded
+// migrations. It does not take the advisory lock, so it never waits on a
+// replica that is migrating; that replica's migrations count as pending
+// until it commits them. It opens a provider on every call, so the server
+// checks once at startup and caches the answer instead of calling it from
+// probes.
+func PendingMigrations(ctx context.Context, pool *pgxpool.Pool) (bool, error) {
+	provider, err := NewMigrator(pool)
+	if err != nil {
+		return false, err
+	}
+	defer provider.Close()
+
+	return provider.HasPending(ctx)
+}
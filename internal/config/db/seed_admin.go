@@ -2,39 +2,80 @@ package db
 
 import (
 	"context"
-	"os"
+	"errors"
 
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/josevitorrodriguess/client-manager/internal/config"
 	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
 	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
-func CreateAdmin(ctx context.Context, pool *pgxpool.Pool) error {
-	adminName := os.Getenv("ADMIN_NAME")
-	adminEmail := os.Getenv("ADMIN_EMAIL")
-	adminPassword := os.Getenv("ADMIN_PASSWORD")
+// CreateAdmin makes sure the admin from cfg exists and is an admin. It is
+// safe to run on every startup: an existing user is promoted when needed but
+// its name and password are left alone, so a password changed with
+// `client-manager users reset-password` survives restarts.
+func CreateAdmin(ctx context.Context, queries sqlc.Querier, cfg config.AdminConfig) error {
+	if cfg.Email == "" {
+		logger.Debug("No admin configured, skipping admin seeding")
+		return nil
+	}
 
-	queries := sqlc.New(pool)
+	existing, err := queries.GetUserByEmail(ctx, cfg.Email)
+	switch {
+	case err == nil:
+		return ensureAdmin(ctx, queries, existing)
+	case !errors.Is(err, pgx.ErrNoRows):
+		logger.Error("Failed to look up admin user", err)
+		return err
+	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(adminPassword), bcrypt.DefaultCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(cfg.Password), bcrypt.DefaultCost)
 	if err != nil {
 		logger.Error("Failed to hash admin password", err)
 		return err
 	}
 
 	admin := sqlc.CreateUserParams{
-		Name:     adminName,
-		Email:    adminEmail,
+		Name:     cfg.Name,
+		Email:    cfg.Email,
 		Password: string(hashedPassword),
 		IsAdmin:  true,
 	}
 
 	_, err = queries.CreateUser(ctx, admin)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		// Another replica seeded it between the lookup and the insert.
+		logger.Debug("Admin user created concurrently, skipping", zap.String("email", cfg.Email))
+		return nil
+	}
 	if err != nil {
 		logger.Error("Failed to create admin user", err)
 		return err
 	}
 
+	logger.Info("Admin user created", zap.String("email", cfg.Email))
+	return nil
+}
+
+func ensureAdmin(ctx context.Context, queries sqlc.Querier, user sqlc.GetUserByEmailRow) error {
+	isAdmin, err := queries.CheckIfUserIsAdmin(ctx, user.ID)
+	if err != nil {
+		logger.Error("Failed to check admin user", err)
+		return err
+	}
+	if isAdmin {
+		logger.Debug("Admin user already exists, skipping admin seeding", zap.String("email", user.Email))
+		return nil
+	}
+
+	if _, err := queries.SetUserAdmin(ctx, sqlc.SetUserAdminParams{Email: user.Email, IsAdmin: true}); err != nil {
+		logger.Error("Failed to promote admin user", err)
+		return err
+	}
+	logger.Info("Existing user promoted to admin", zap.String("email", user.Email))
 	return nil
 }
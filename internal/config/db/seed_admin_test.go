@@ -0,0 +1,61 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/josevitorrodriguess/client-manager/internal/config"
+	"github.com/josevitorrodriguess/client-manager/internal/config/db"
+	"github.com/josevitorrodriguess/client-manager/internal/db/memstore"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/user"
+)
+
+func TestCreateAdminIsIdempotent(t *testing.T) {
+	store := memstore.New()
+	ctx := context.Background()
+	cfg := config.AdminConfig{Name: "Admin User", Email: "admin@example.com", Password: "first-pass"}
+
+	for i := 0; i < 2; i++ {
+		if err := db.CreateAdmin(ctx, store, cfg); err != nil {
+			t.Fatalf("CreateAdmin run %d: %v", i+1, err)
+		}
+	}
+
+	users, err := store.ListUsers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || !users[0].IsAdmin {
+		t.Fatalf("users after two runs = %+v, want one admin", users)
+	}
+
+	// A password reset must survive the next startup.
+	us := services.NewUserService(store)
+	if _, err := us.ResetPassword(ctx, cfg.Email, "second-pass"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.CreateAdmin(ctx, store, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := us.AuthenticateUser(ctx, cfg.Email, "second-pass"); err != nil {
+		t.Errorf("reset password lost after CreateAdmin: %v", err)
+	}
+}
+
+func TestCreateAdminPromotesExistingUser(t *testing.T) {
+	store := memstore.New()
+	ctx := context.Background()
+
+	id, err := services.NewUserService(store).Create(ctx, user.UserRequest{Name: "Regular User", Email: "ops@example.com", Password: "s3cret-pass"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.CreateAdmin(ctx, store, config.AdminConfig{Name: "Ops", Email: "ops@example.com", Password: "other-pass"}); err != nil {
+		t.Fatal(err)
+	}
+	if isAdmin, err := store.CheckIfUserIsAdmin(ctx, id); err != nil || !isAdmin {
+		t.Errorf("CheckIfUserIsAdmin = %v, %v; want true", isAdmin, err)
+	}
+}
@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ctxKey struct{}
+
+// requestFields is shared by everything handling one request, so fields
+// added deep in the stack (e.g. user_id by the auth middleware) also show up
+// in the access log written on the way out.
+type requestFields struct {
+	mu     sync.Mutex
+	fields []zap.Field
+}
+
+// NewContext returns a context whose logger carries fields in addition to
+// those already in ctx.
+func NewContext(ctx context.Context, fields ...zap.Field) context.Context {
+	rf := &requestFields{}
+	if parent, ok := ctx.Value(ctxKey{}).(*requestFields); ok {
+		parent.mu.Lock()
+		rf.fields = append(rf.fields, parent.fields...)
+		parent.mu.Unlock()
+	}
+	rf.fields = append(rf.fields, fields...)
+	return context.WithValue(ctx, ctxKey{}, rf)
+}
+
+// AddFields attaches fields to the logger already stored in ctx, making them
+// visible to every holder of that context. It does nothing when ctx has no
+// logger fields.
+func AddFields(ctx context.Context, fields ...zap.Field) {
+	rf, ok := ctx.Value(ctxKey{}).(*requestFields)
+	if !ok {
+		return
+	}
+	rf.mu.Lock()
+	rf.fields = append(rf.fields, fields...)
+	rf.mu.Unlock()
+}
+
+// FromContext returns a logger with the request fields stored in ctx, the
+// chi route pattern and the current trace and span ids.
+func FromContext(ctx context.Context) *Logger {
+	fields := append([]zap.Field{}, DEFAULT_FIELDS...)
+	if rf, ok := ctx.Value(ctxKey{}).(*requestFields); ok {
+		rf.mu.Lock()
+		fields = append(fields, rf.fields...)
+		rf.mu.Unlock()
+	}
+	if rctx := chi.RouteContext(ctx); rctx != nil {
+		if route := rctx.RoutePattern(); route != "" {
+			fields = append(fields, zap.String("route", route))
+		}
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", sc.TraceID().String()),
+			zap.String("span_id", sc.SpanID().String()))
+	}
+	return &Logger{z: log.With(fields...)}
+}
+
+// Logger is a request-scoped logger. Its methods mirror the package-level
+// functions.
+type Logger struct {
+	z *zap.Logger
+}
+
+func (l *Logger) With(fields ...zap.Field) *Logger {
+	return &Logger{z: l.z.With(fields...)}
+}
+
+func (l *Logger) Info(message string, tags ...zap.Field) {
+	l.z.Info(message, tags...)
+	l.z.Sync()
+}
+
+func (l *Logger) Error(message string, err error, tags ...zap.Field) {
+	tags = append(tags, zap.NamedError("error", err))
+	l.z.Error(message, tags...)
+	l.z.Sync()
+}
+
+func (l *Logger) Warn(message string, tags ...zap.Field) {
+	l.z.Warn(message, tags...)
+	l.z.Sync()
+}
+
+func (l *Logger) Debug(message string, tags ...zap.Field) {
+	l.z.Debug(message, tags...)
+	l.z.Sync()
+}
@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFromContextCarriesRequestFields(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	prev := log
+	log = zap.New(core)
+	t.Cleanup(func() { log = prev })
+
+	ctx := NewContext(context.Background(), zap.String("request_id", "req-1"))
+	AddFields(ctx, zap.String("user_id", "user-1"))
+	child := NewContext(ctx, zap.String("line", "3"))
+
+	FromContext(child).Error("boom", nil)
+	FromContext(ctx).Info("done")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries", len(entries))
+	}
+	first := entries[0].ContextMap()
+	if first["request_id"] != "req-1" || first["user_id"] != "user-1" || first["line"] != "3" {
+		t.Errorf("unexpected fields: %v", first)
+	}
+	if _, ok := entries[1].ContextMap()["line"]; ok {
+		t.Error("fields of a derived context leaked into its parent")
+	}
+}
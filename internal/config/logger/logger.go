@@ -1,9 +1,13 @@
 package logger
 
 import (
+	"errors"
+	stdlog "log"
 	"os"
 	"strings"
+	"syscall"
 
+	"github.com/josevitorrodriguess/client-manager/internal/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -14,13 +18,32 @@ var (
 	LOG_OUTPUT     = "LOG_OUTPUT"
 	LOG_LEVEL      = "LOG_LEVEL"
 	SERVICE_NAME   = "SERVICE_NAME"
+	LOG_PII_POLICY = "LOG_PII_POLICY"
 	DEFAULT_FIELDS = []zap.Field{
 		zap.String("service", getServiceName()),
 	}
 )
 
 func init() {
-	output := getOutputLogs()
+	log = build(getOutputLogs(), getLevelLogs(), getPIIPolicy())
+}
+
+// Configure rebuilds the logger from the loaded configuration. Until it is
+// called the logger uses the LOG_* environment variables directly, so early
+// startup messages are still emitted.
+func Configure(cfg config.LogConfig) {
+	output := strings.ToLower(strings.TrimSpace(cfg.Output))
+	if output == "" {
+		output = "stdout"
+	}
+	if name := strings.TrimSpace(cfg.ServiceName); name != "" {
+		DEFAULT_FIELDS = []zap.Field{zap.String("service", name)}
+	}
+	policy, _ := ParsePIIPolicy(cfg.PIIPolicy)
+	log = build(output, parseLevel(cfg.Level), policy)
+}
+
+func build(output string, level zapcore.Level, policy PIIPolicy) *zap.Logger {
 	isStdout := output == "stdout"
 
 	var encoder zapcore.Encoder
@@ -57,10 +80,10 @@ func init() {
 	core := zapcore.NewCore(
 		encoder,
 		zapcore.AddSync(os.Stdout),
-		zap.NewAtomicLevelAt(getLevelLogs()),
+		zap.NewAtomicLevelAt(level),
 	)
 
-	log = zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
+	return zap.New(newRedactCore(core, policy), zap.AddCaller(), zap.AddCallerSkip(1), zap.AddStacktrace(zapcore.ErrorLevel))
 }
 
 func Info(message string, tags ...zap.Field) {
@@ -103,7 +126,11 @@ func getOutputLogs() string {
 }
 
 func getLevelLogs() zapcore.Level {
-	switch strings.ToLower(strings.TrimSpace(os.Getenv(LOG_LEVEL))) {
+	return parseLevel(os.Getenv(LOG_LEVEL))
+}
+
+func parseLevel(level string) zapcore.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
 	case "info":
 		return zapcore.InfoLevel
 	case "error":
@@ -117,6 +144,11 @@ func getLevelLogs() zapcore.Level {
 	}
 }
 
+func getPIIPolicy() PIIPolicy {
+	policy, _ := ParsePIIPolicy(os.Getenv(LOG_PII_POLICY))
+	return policy
+}
+
 func getServiceName() string {
 	serviceName := strings.TrimSpace(os.Getenv(SERVICE_NAME))
 	if serviceName == "" {
@@ -124,3 +156,22 @@ func getServiceName() string {
 	}
 	return serviceName
 }
+
+// Sync flushes any buffered log entries. It is meant to be called once on
+// shutdown; errors from syncing a terminal are ignored.
+func Sync() error {
+	if err := log.Sync(); err != nil && !errors.Is(err, syscall.EINVAL) && !errors.Is(err, syscall.ENOTTY) {
+		return err
+	}
+	return nil
+}
+
+// StdLog adapts the logger for APIs that expect a *log.Logger, such as
+// http.Server.ErrorLog. Entries are written at error level.
+func StdLog() *stdlog.Logger {
+	l, err := zap.NewStdLogAt(log.With(DEFAULT_FIELDS...), zapcore.ErrorLevel)
+	if err != nil {
+		return zap.NewStdLog(log)
+	}
+	return l
+}
@@ -0,0 +1,200 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// PIIPolicy controls how personal data (LGPD) is written to the logs.
+type PIIPolicy string
+
+const (
+	// PIIMask keeps just enough of each value to tell records apart, e.g.
+	// ***.456.789-** for a CPF. It is the default.
+	PIIMask PIIPolicy = "mask"
+	// PIIRedact replaces every value with [REDACTED].
+	PIIRedact PIIPolicy = "redact"
+	// PIIPlain logs values untouched. Meant for local development only.
+	PIIPlain PIIPolicy = "plain"
+)
+
+const redacted = "[REDACTED]"
+
+// piiRules maps the keys that carry personal data to the mask applied under
+// PIIMask. Keys match exactly: call sites log personal data under one of
+// these names, and other keys and the message are written untouched.
+var piiRules = map[string]func(string) string{
+	"cpf":          MaskCPF,
+	"cnpj":         MaskCNPJ,
+	"email":        MaskEmail,
+	"phone":        MaskPhone,
+	"name":         MaskName,
+	"company_name": MaskName,
+}
+
+// ParsePIIPolicy returns the policy named by s, defaulting to PIIMask.
+func ParsePIIPolicy(s string) (PIIPolicy, bool) {
+	switch PIIPolicy(strings.ToLower(strings.TrimSpace(s))) {
+	case PIIMask, "":
+		return PIIMask, true
+	case PIIRedact:
+		return PIIRedact, true
+	case PIIPlain:
+		return PIIPlain, true
+	}
+	return PIIMask, false
+}
+
+// redactCore rewrites fields before they reach the encoder, including those
+// attached earlier with With. Fields whose key has a PII rule are masked
+// whatever their type.
+type redactCore struct {
+	zapcore.Core
+	policy PIIPolicy
+}
+
+func newRedactCore(core zapcore.Core, policy PIIPolicy) zapcore.Core {
+	if policy == PIIPlain {
+		return core
+	}
+	return &redactCore{Core: core, policy: policy}
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redact(fields)), policy: c.policy}
+}
+
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.redact(fields))
+}
+
+func (c *redactCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		redacted, ok := c.redactField(f)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = append([]zapcore.Field(nil), fields...)
+		}
+		out[i] = redacted
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// redactField returns f with its personal data masked; ok is false when there
+// was nothing to change.
+func (c *redactCore) redactField(f zapcore.Field) (zapcore.Field, bool) {
+	mask, ok := piiRules[f.Key]
+	if !ok {
+		return f, false
+	}
+	s, ok := fieldText(f)
+	if !ok {
+		return f, false
+	}
+	if c.policy == PIIRedact {
+		return zapcore.Field{Key: f.Key, Type: zapcore.StringType, String: redacted}, true
+	}
+	return zapcore.Field{Key: f.Key, Type: zapcore.StringType, String: mask(s)}, true
+}
+
+// fieldText returns the text a string-like field encodes to.
+func fieldText(f zapcore.Field) (string, bool) {
+	switch f.Type {
+	case zapcore.StringType:
+		return f.String, true
+	case zapcore.ByteStringType:
+		b, _ := f.Interface.([]byte)
+		return string(b), true
+	case zapcore.StringerType:
+		if s, ok := f.Interface.(fmt.Stringer); ok {
+			return s.String(), true
+		}
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			return err.Error(), true
+		}
+	case zapcore.ReflectType:
+		if f.Interface != nil {
+			return fmt.Sprint(f.Interface), true
+		}
+	}
+	return "", false
+}
+
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// MaskCPF keeps the middle six digits: ***.456.789-**.
+func MaskCPF(cpf string) string {
+	d := digits(cpf)
+	if len(d) != 11 {
+		return maskAll(cpf)
+	}
+	return "***." + d[3:6] + "." + d[6:9] + "-**"
+}
+
+// MaskCNPJ keeps only the branch number: **.***.***/0001-**.
+func MaskCNPJ(cnpj string) string {
+	d := digits(cnpj)
+	if len(d) != 14 {
+		return maskAll(cnpj)
+	}
+	return "**.***.***/" + d[8:12] + "-**"
+}
+
+// MaskEmail keeps the first letter of the local part and the domain:
+// j***@example.com.
+func MaskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return maskAll(email)
+	}
+	return string([]rune(local)[0]) + "***@" + domain
+}
+
+// MaskPhone keeps the last four digits.
+func MaskPhone(phone string) string {
+	d := digits(phone)
+	if len(d) <= 4 {
+		return maskAll(phone)
+	}
+	return strings.Repeat("*", len(d)-4) + d[len(d)-4:]
+}
+
+// MaskName keeps the initial of each word: M*** S***.
+func MaskName(name string) string {
+	words := strings.Fields(name)
+	for i, w := range words {
+		words[i] = string([]rune(w)[0]) + "***"
+	}
+	return strings.Join(words, " ")
+}
+
+func maskAll(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "***"
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMasks(t *testing.T) {
+	cases := []struct {
+		mask func(string) string
+		in   string
+		want string
+	}{
+		{MaskCPF, "123.456.789-09", "***.456.789-**"},
+		{MaskCPF, "12345678909", "***.456.789-**"},
+		{MaskCPF, "123", "***"},
+		{MaskCNPJ, "11.222.333/0001-81", "**.***.***/0001-**"},
+		{MaskEmail, "maria@example.com", "m***@example.com"},
+		{MaskEmail, "not-an-email", "***"},
+		{MaskPhone, "(11) 91234-5678", "*******5678"},
+		{MaskName, "Maria da Silva", "M*** d*** S***"},
+		{MaskName, "", ""},
+	}
+	for _, c := range cases {
+		if got := c.mask(c.in); got != c.want {
+			t.Errorf("mask(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestRedactCorePolicies(t *testing.T) {
+	for _, tc := range []struct {
+		policy PIIPolicy
+		email  string
+		cpf    string
+	}{
+		{PIIMask, "m***@example.com", "***.456.789-**"},
+		{PIIRedact, redacted, redacted},
+		{PIIPlain, "maria@example.com", "12345678909"},
+	} {
+		core, logs := observer.New(zapcore.InfoLevel)
+		l := zap.New(newRedactCore(core, tc.policy)).With(zap.String("email", "maria@example.com"))
+
+		l.Info("duplicate customer", zap.String("cpf", "12345678909"), zap.String("request_id", "req-1"))
+
+		fields := logs.All()[0].ContextMap()
+		if fields["email"] != tc.email || fields["cpf"] != tc.cpf {
+			t.Errorf("%s: got email=%v cpf=%v", tc.policy, fields["email"], fields["cpf"])
+		}
+		if fields["request_id"] != "req-1" {
+			t.Errorf("%s: non-PII field changed: %v", tc.policy, fields["request_id"])
+		}
+	}
+}
+
+func TestRedactCoreMatchesKeysExactly(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := zap.New(newRedactCore(core, PIIMask))
+
+	l.Info("customer 123.456.789-09 rejected",
+		zap.Stringer("email", stringer("joao@example.com")),
+		zap.Error(errors.New("lookup failed")),
+		zap.String("company_name", "Acme Comércio"),
+		zap.String("route_name", "customers.list"),
+		zap.String("service_name", "client-manager"),
+		zap.String("detail", "call +5511912345678"),
+	)
+
+	entry := logs.All()[0]
+	if entry.Message != "customer 123.456.789-09 rejected" {
+		t.Errorf("message = %q", entry.Message)
+	}
+	fields := entry.ContextMap()
+	want := map[string]any{
+		"email":        "j***@example.com",
+		"error":        "lookup failed",
+		"company_name": "A*** C***",
+		"route_name":   "customers.list",
+		"service_name": "client-manager",
+		"detail":       "call +5511912345678",
+	}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Errorf("%s = %v, want %v", k, fields[k], v)
+		}
+	}
+}
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
@@ -0,0 +1,93 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+// Fixtures holds the ids of the rows inserted by Seed.
+type Fixtures struct {
+	AdminID    uuid.UUID
+	PFCustomer uuid.UUID
+	PJCustomer uuid.UUID
+	ServiceID  int32
+}
+
+// Seed inserts an admin, one PF customer with a service and one PJ customer.
+func (db *DB) Seed(t testing.TB) Fixtures {
+	t.Helper()
+	ctx := context.Background()
+
+	var f Fixtures
+	var err error
+
+	f.AdminID, err = db.Queries.CreateUser(ctx, sqlc.CreateUserParams{
+		Name:     "Fixture Admin",
+		Email:    "admin@fixture.test",
+		Password: "not-a-real-hash",
+		IsAdmin:  true,
+	})
+	if err != nil {
+		t.Fatalf("dbtest: seeding admin: %v", err)
+	}
+
+	f.PFCustomer, err = db.Queries.CreateCustomerPF(ctx, sqlc.CreateCustomerPFParams{
+		ID:          uuid.New(),
+		Type:        sqlc.CustomerTypePF,
+		Email:       "maria@fixture.test",
+		Phone:       "11 91234-5678",
+		Cpf:         "12345678909",
+		CpfIndex:    pgtype.Text{String: "fixture-cpf-index", Valid: true},
+		Name:        "Maria Fixture",
+		BirthDate:   "1990-05-10",
+		AddressType: "home",
+		Street:      "Rua A",
+		Number:      "10",
+		State:       "SP",
+		City:        "Sao Paulo",
+		Cep:         "01001-000",
+	})
+	if err != nil {
+		t.Fatalf("dbtest: seeding PF customer: %v", err)
+	}
+
+	f.PJCustomer, err = db.Queries.CreateCustomerPJ(ctx, sqlc.CreateCustomerPJParams{
+		ID:          uuid.New(),
+		Type:        sqlc.CustomerTypePJ,
+		Email:       "acme@fixture.test",
+		Phone:       "11 93333-4444",
+		Cnpj:        "11222333000181",
+		CnpjIndex:   pgtype.Text{String: "fixture-cnpj-index", Valid: true},
+		CompanyName: "Acme Fixture",
+		AddressType: "work",
+		Street:      "Av B",
+		Number:      "1",
+		State:       "SP",
+		City:        "Sao Paulo",
+		Cep:         "01001-000",
+	})
+	if err != nil {
+		t.Fatalf("dbtest: seeding PJ customer: %v", err)
+	}
+
+	var total pgtype.Numeric
+	if err := total.Scan("1500.50"); err != nil {
+		t.Fatal(err)
+	}
+	f.ServiceID, err = db.Queries.CreateService(ctx, sqlc.CreateServiceParams{
+		CustomerID:  f.PFCustomer,
+		TypeProduct: "site",
+		Description: "fixture service",
+		TotalValue:  total,
+		DownPayment: total,
+	})
+	if err != nil {
+		t.Fatalf("dbtest: seeding service: %v", err)
+	}
+
+	return f
+}
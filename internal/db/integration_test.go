@@ -0,0 +1,217 @@
+//go:build integration
+
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/pgxstore"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/dbtest"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"github.com/pressly/goose/v3"
+)
+
+func TestMigrationsDownAndUp(t *testing.T) {
+	db := dbtest.New(t)
+	ctx := context.Background()
+
+	if _, err := db.Migrate(ctx, func(p *goose.Provider) ([]*goose.MigrationResult, error) { return p.DownTo(ctx, 0) }); err != nil {
+		t.Fatalf("migrating down: %v", err)
+	}
+	results, err := db.Migrate(ctx, func(p *goose.Provider) ([]*goose.MigrationResult, error) { return p.Up(ctx) })
+	if err != nil {
+		t.Fatalf("migrating up again: %v", err)
+	}
+	if len(results) == 0 {
+		t.Error("expected migrations to be re-applied")
+	}
+}
+
+func TestCreateCustomerPF(t *testing.T) {
+	db := dbtest.New(t)
+	f := db.Seed(t)
+	ctx := context.Background()
+
+	row, err := db.Queries.GetCustomerByID(ctx, f.PFCustomer)
+	if err != nil {
+		t.Fatalf("GetCustomerByID: %v", err)
+	}
+	if row.Type != sqlc.CustomerTypePF || row.Cpf != "12345678909" || row.PfName != "Maria Fixture" {
+		t.Errorf("unexpected row: %+v", row)
+	}
+	if row.Cnpj != nil || row.CompanyName != nil {
+		t.Errorf("PF customer should not have PJ columns: %+v", row)
+	}
+
+	addrs, err := db.Queries.GetCustomerAddresses(ctx, f.PFCustomer)
+	if err != nil || len(addrs) != 1 || addrs[0].Number != "10" {
+		t.Errorf("GetCustomerAddresses = %+v, %v", addrs, err)
+	}
+
+	_, err = db.Queries.CreateCustomerPF(ctx, sqlc.CreateCustomerPFParams{
+		ID:       uuid.New(),
+		Type:     sqlc.CustomerTypePF,
+		Email:    "other@fixture.test",
+		Phone:    "11 90000-0000",
+		Cpf:      "12345678909",
+		CpfIndex: pgtype.Text{String: "fixture-cpf-index", Valid: true},
+		Name:     "Duplicate CPF",
+	})
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		t.Errorf("expected a unique violation for a repeated cpf index, got %v", err)
+	}
+}
+
+func TestGetAllCustomersAggregatesAddresses(t *testing.T) {
+	db := dbtest.New(t)
+	f := db.Seed(t)
+	ctx := context.Background()
+
+	if _, err := db.Queries.AddAddressToCustomer(ctx, sqlc.AddAddressToCustomerParams{
+		CustomerID:  f.PJCustomer,
+		AddressType: "branch",
+		Street:      "Rua C",
+		Number:      "2",
+		State:       "RJ",
+		City:        "Rio de Janeiro",
+		Cep:         "20000-000",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := db.Queries.GetAllCustomers(ctx, sqlc.GetAllCustomersParams{})
+	if err != nil {
+		t.Fatalf("GetAllCustomers: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 customers, got %d", len(rows))
+	}
+
+	want := map[string]int{"maria@fixture.test": 1, "acme@fixture.test": 2}
+	for _, row := range rows {
+		resp, err := customer.MapToCustomerResponse(row)
+		if err != nil {
+			t.Fatalf("MapToCustomerResponse: %v", err)
+		}
+		if got := len(resp.Addresses); got != want[resp.Email] {
+			t.Errorf("%s: %d addresses, want %d", resp.Email, got, want[resp.Email])
+		}
+	}
+}
+
+func TestContacts(t *testing.T) {
+	db := dbtest.New(t)
+	f := db.Seed(t)
+	ctx := context.Background()
+
+	first, err := db.Queries.CreateContact(ctx, sqlc.CreateContactParams{
+		CustomerID: f.PJCustomer,
+		Name:       "Carlos",
+		Emails:     []string{"carlos@fixture.test", "financeiro@fixture.test"},
+		Phones:     []string{},
+		IsPrimary:  true,
+	})
+	if err != nil {
+		t.Fatalf("CreateContact: %v", err)
+	}
+	if len(first.Emails) != 2 || first.Phones == nil {
+		t.Errorf("unexpected contact: %+v", first)
+	}
+
+	_, err = db.Queries.CreateContact(ctx, sqlc.CreateContactParams{
+		CustomerID: f.PJCustomer,
+		Name:       "Joana",
+		Emails:     []string{},
+		Phones:     []string{"11 90000-0000"},
+		IsPrimary:  true,
+	})
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		t.Errorf("expected a unique violation for a second primary contact, got %v", err)
+	}
+
+	if err := db.Queries.ClearPrimaryContact(ctx, f.PJCustomer); err != nil {
+		t.Fatal(err)
+	}
+	contacts, err := db.Queries.ListContactsByCustomerIDs(ctx, []uuid.UUID{f.PFCustomer, f.PJCustomer})
+	if err != nil || len(contacts) != 1 || contacts[0].IsPrimary {
+		t.Errorf("ListContactsByCustomerIDs = %+v, %v", contacts, err)
+	}
+
+	n, err := db.Queries.DeleteCustomerContacts(ctx, f.PJCustomer)
+	if err != nil || n != 1 {
+		t.Errorf("DeleteCustomerContacts = %d, %v", n, err)
+	}
+}
+
+func TestTagsAndCustomFields(t *testing.T) {
+	db := dbtest.New(t)
+	f := db.Seed(t)
+	ctx := context.Background()
+
+	for id, tags := range map[uuid.UUID][]string{f.PFCustomer: {"vip", "recorrente"}, f.PJCustomer: {"vip"}} {
+		if err := db.Queries.AddCustomerTags(ctx, sqlc.AddCustomerTagsParams{CustomerID: id, Tags: tags}); err != nil {
+			t.Fatalf("AddCustomerTags: %v", err)
+		}
+	}
+	for _, tt := range []struct {
+		tags []string
+		want int
+	}{{[]string{}, 2}, {[]string{"vip"}, 2}, {[]string{"recorrente", "vip"}, 1}, {[]string{"outro"}, 0}} {
+		rows, err := db.Queries.GetAllCustomers(ctx, sqlc.GetAllCustomersParams{Tags: tt.tags})
+		if err != nil || len(rows) != tt.want {
+			t.Errorf("GetAllCustomers(%v) = %d rows, %v; want %d", tt.tags, len(rows), err, tt.want)
+		}
+	}
+
+	if _, err := db.Queries.CreateCustomField(ctx, sqlc.CreateCustomFieldParams{Key: "employees", Label: "Funcionários", Type: sqlc.CustomFieldTypeNumber}); err != nil {
+		t.Fatalf("CreateCustomField: %v", err)
+	}
+	n, err := db.Queries.SetCustomerCustomFields(ctx, sqlc.SetCustomerCustomFieldsParams{ID: f.PJCustomer, CustomFields: []byte(`{"employees": 40}`)})
+	if err != nil || n != 1 {
+		t.Fatalf("SetCustomerCustomFields = %d, %v", n, err)
+	}
+	if err := db.Queries.RemoveCustomFieldValues(ctx, "employees"); err != nil {
+		t.Fatal(err)
+	}
+	row, err := db.Queries.GetCustomerByID(ctx, f.PJCustomer)
+	if err != nil || string(row.CustomFields) != "{}" {
+		t.Errorf("custom fields after removal = %s, %v", row.CustomFields, err)
+	}
+}
+
+func TestSessionStorage(t *testing.T) {
+	db := dbtest.New(t)
+	store := pgxstore.NewWithCleanupInterval(db.Pool, 0)
+
+	if err := store.Commit("token-1", []byte("payload"), time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	b, found, err := store.Find("token-1")
+	if err != nil || !found || string(b) != "payload" {
+		t.Fatalf("Find = %q, %v, %v", b, found, err)
+	}
+
+	if err := store.Commit("expired", []byte("old"), time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, _ := store.Find("expired"); found {
+		t.Error("expired sessions must not be returned")
+	}
+
+	if err := store.Delete("token-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, _ := store.Find("token-1"); found {
+		t.Error("deleted session still found")
+	}
+}
@@ -0,0 +1,47 @@
+package memstore
+
+import (
+	"context"
+
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+func (s *Store) CreateAuditEvent(ctx context.Context, arg sqlc.CreateAuditEventParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if arg.ActorID.Valid {
+		if _, ok := s.data.users[arg.ActorID.Bytes]; !ok {
+			return 0, foreignKeyViolation("audit_events_actor_id_fkey")
+		}
+	}
+
+	details := arg.Details
+	if details == nil {
+		details = []byte("{}")
+	}
+	event := sqlc.AuditEvent{
+		ID:         int64(len(s.data.auditEvents) + 1),
+		ActorID:    arg.ActorID,
+		Action:     arg.Action,
+		EntityType: arg.EntityType,
+		EntityID:   arg.EntityID,
+		Details:    details,
+		CreatedAt:  now(),
+	}
+	s.data.auditEvents = append(s.data.auditEvents, event)
+	return event.ID, nil
+}
+
+func (s *Store) ListAuditEventsByEntity(ctx context.Context, arg sqlc.ListAuditEventsByEntityParams) ([]sqlc.AuditEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []sqlc.AuditEvent
+	for _, e := range s.data.auditEvents {
+		if e.EntityType == arg.EntityType && e.EntityID == arg.EntityID {
+			items = append(items, e)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,152 @@
+package memstore
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+// checkPrimary mimics the partial unique index contacts_primary_key: a
+// customer has at most one primary contact.
+func (s *Store) checkPrimary(customerID uuid.UUID, exceptID int32) error {
+	for _, c := range s.data.contacts {
+		if c.CustomerID == customerID && c.IsPrimary && c.ID != exceptID {
+			return uniqueViolation("contacts_primary_key")
+		}
+	}
+	return nil
+}
+
+func (s *Store) CreateContact(ctx context.Context, arg sqlc.CreateContactParams) (sqlc.Contact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.customers[arg.CustomerID]; !ok {
+		return sqlc.Contact{}, foreignKeyViolation("contacts_customer_id_fkey")
+	}
+	if arg.IsPrimary {
+		if err := s.checkPrimary(arg.CustomerID, 0); err != nil {
+			return sqlc.Contact{}, err
+		}
+	}
+
+	id := s.data.nextContactID
+	s.data.nextContactID++
+	c := sqlc.Contact{
+		ID:         id,
+		CustomerID: arg.CustomerID,
+		Name:       arg.Name,
+		Role:       arg.Role,
+		Emails:     slices.Clone(arg.Emails),
+		Phones:     slices.Clone(arg.Phones),
+		Whatsapp:   arg.Whatsapp,
+		IsPrimary:  arg.IsPrimary,
+		CreatedAt:  now(),
+		UpdatedAt:  now(),
+	}
+	s.data.contacts[id] = c
+	return c, nil
+}
+
+func (s *Store) sortedContacts(keep func(sqlc.Contact) bool) []sqlc.Contact {
+	var items []sqlc.Contact
+	for _, c := range s.data.contacts {
+		if keep(c) {
+			items = append(items, c)
+		}
+	}
+	slices.SortFunc(items, func(a, b sqlc.Contact) int {
+		if a.CustomerID != b.CustomerID {
+			return slices.Compare(a.CustomerID[:], b.CustomerID[:])
+		}
+		if a.IsPrimary != b.IsPrimary {
+			if a.IsPrimary {
+				return -1
+			}
+			return 1
+		}
+		return int(a.ID - b.ID)
+	})
+	return items
+}
+
+func (s *Store) ListCustomerContacts(ctx context.Context, customerID uuid.UUID) ([]sqlc.Contact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sortedContacts(func(c sqlc.Contact) bool { return c.CustomerID == customerID }), nil
+}
+
+func (s *Store) ListContactsByCustomerIDs(ctx context.Context, customerIds []uuid.UUID) ([]sqlc.Contact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sortedContacts(func(c sqlc.Contact) bool { return slices.Contains(customerIds, c.CustomerID) }), nil
+}
+
+func (s *Store) UpdateContact(ctx context.Context, arg sqlc.UpdateContactParams) (sqlc.Contact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.data.contacts[arg.ID]
+	if !ok || c.CustomerID != arg.CustomerID {
+		return sqlc.Contact{}, pgx.ErrNoRows
+	}
+	if arg.IsPrimary {
+		if err := s.checkPrimary(arg.CustomerID, arg.ID); err != nil {
+			return sqlc.Contact{}, err
+		}
+	}
+
+	c.Name = arg.Name
+	c.Role = arg.Role
+	c.Emails = slices.Clone(arg.Emails)
+	c.Phones = slices.Clone(arg.Phones)
+	c.Whatsapp = arg.Whatsapp
+	c.IsPrimary = arg.IsPrimary
+	c.UpdatedAt = now()
+	s.data.contacts[c.ID] = c
+	return c, nil
+}
+
+func (s *Store) ClearPrimaryContact(ctx context.Context, customerID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, c := range s.data.contacts {
+		if c.CustomerID == customerID && c.IsPrimary {
+			c.IsPrimary = false
+			c.UpdatedAt = now()
+			s.data.contacts[id] = c
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteContact(ctx context.Context, arg sqlc.DeleteContactParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.data.contacts[arg.ID]; !ok || c.CustomerID != arg.CustomerID {
+		return 0, nil
+	}
+	delete(s.data.contacts, arg.ID)
+	return 1, nil
+}
+
+func (s *Store) DeleteCustomerContacts(ctx context.Context, customerID uuid.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+	for id, c := range s.data.contacts {
+		if c.CustomerID == customerID {
+			delete(s.data.contacts, id)
+			n++
+		}
+	}
+	return n, nil
+}
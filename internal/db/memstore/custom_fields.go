@@ -0,0 +1,95 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+func (s *Store) CreateCustomField(ctx context.Context, arg sqlc.CreateCustomFieldParams) (sqlc.CustomField, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.customFields[arg.Key]; ok {
+		return sqlc.CustomField{}, uniqueViolation("custom_fields_key_key")
+	}
+
+	id := s.data.nextCustomFieldID
+	s.data.nextCustomFieldID++
+	f := sqlc.CustomField{
+		ID:        id,
+		Key:       arg.Key,
+		Label:     arg.Label,
+		Type:      arg.Type,
+		Options:   slices.Clone(arg.Options),
+		CreatedAt: now(),
+	}
+	s.data.customFields[f.Key] = f
+	return f, nil
+}
+
+func (s *Store) ListCustomFields(ctx context.Context) ([]sqlc.CustomField, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []sqlc.CustomField
+	for _, f := range s.data.customFields {
+		items = append(items, f)
+	}
+	slices.SortFunc(items, func(a, b sqlc.CustomField) int { return cmp.Compare(a.Key, b.Key) })
+	return items, nil
+}
+
+func (s *Store) DeleteCustomField(ctx context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.customFields[key]; !ok {
+		return 0, nil
+	}
+	delete(s.data.customFields, key)
+	return 1, nil
+}
+
+func (s *Store) SetCustomerCustomFields(ctx context.Context, arg sqlc.SetCustomerCustomFieldsParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.data.customers[arg.ID]
+	if !ok {
+		return 0, nil
+	}
+	c.CustomFields = slices.Clone(arg.CustomFields)
+	c.UpdatedAt = now()
+	s.data.customers[arg.ID] = c
+	return 1, nil
+}
+
+// RemoveCustomFieldValues mirrors the jsonb - operator on every customer
+// holding a value for key.
+func (s *Store) RemoveCustomFieldValues(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, c := range s.data.customers {
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(c.CustomFields, &values); err != nil {
+			return err
+		}
+		if _, ok := values[key]; !ok {
+			continue
+		}
+		delete(values, key)
+		raw, err := json.Marshal(values)
+		if err != nil {
+			return err
+		}
+		c.CustomFields = raw
+		c.UpdatedAt = now()
+		s.data.customers[id] = c
+	}
+	return nil
+}
@@ -0,0 +1,553 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+func (s *Store) insertCustomer(id uuid.UUID, typ sqlc.CustomerType, email, phone string) (sqlc.Customer, error) {
+	if _, ok := s.data.customers[id]; ok {
+		return sqlc.Customer{}, uniqueViolation("customers_pkey")
+	}
+	for _, c := range s.data.customers {
+		if c.Email == email {
+			return sqlc.Customer{}, uniqueViolation("customers_email_key")
+		}
+		if c.Phone == phone {
+			return sqlc.Customer{}, uniqueViolation("customers_phone_key")
+		}
+	}
+
+	c := sqlc.Customer{
+		ID:           id,
+		Type:         typ,
+		Email:        email,
+		Phone:        phone,
+		CreatedAt:    now(),
+		UpdatedAt:    now(),
+		IsActive:     true,
+		CustomFields: []byte("{}"),
+	}
+	return c, nil
+}
+
+func (s *Store) insertAddress(arg sqlc.AddAddressToCustomerParams) int32 {
+	id := s.data.nextAddressID
+	s.data.nextAddressID++
+	s.data.addresses[id] = sqlc.Address{
+		ID:          id,
+		CustomerID:  arg.CustomerID,
+		AddressType: arg.AddressType,
+		Street:      arg.Street,
+		Number:      arg.Number,
+		Complement:  arg.Complement,
+		State:       arg.State,
+		City:        arg.City,
+		Cep:         arg.Cep,
+	}
+	return id
+}
+
+func (s *Store) CreateCustomerPF(ctx context.Context, arg sqlc.CreateCustomerPFParams) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.insertCustomer(arg.ID, arg.Type, arg.Email, arg.Phone)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, pf := range s.data.pf {
+		if arg.CpfIndex.Valid && pf.CpfIndex == arg.CpfIndex {
+			return uuid.Nil, uniqueViolation("customerf_pf_cpf_index_key")
+		}
+	}
+
+	s.data.customers[c.ID] = c
+	s.data.pf[c.ID] = sqlc.CustomerfPf{
+		CustomerID: c.ID,
+		Cpf:        arg.Cpf,
+		CpfIndex:   arg.CpfIndex,
+		Name:       arg.Name,
+		BirthDate:  arg.BirthDate,
+		CreatedAt:  now(),
+		UpdatedAt:  now(),
+	}
+	s.insertAddress(sqlc.AddAddressToCustomerParams{
+		CustomerID:  c.ID,
+		AddressType: arg.AddressType,
+		Street:      arg.Street,
+		Number:      arg.Number,
+		Complement:  arg.Complement,
+		State:       arg.State,
+		City:        arg.City,
+		Cep:         arg.Cep,
+	})
+	return c.ID, nil
+}
+
+func (s *Store) CreateCustomerPJ(ctx context.Context, arg sqlc.CreateCustomerPJParams) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.insertCustomer(arg.ID, arg.Type, arg.Email, arg.Phone)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, pj := range s.data.pj {
+		if arg.CnpjIndex.Valid && pj.CnpjIndex == arg.CnpjIndex {
+			return uuid.Nil, uniqueViolation("customerf_pj_cnpj_index_key")
+		}
+	}
+
+	s.data.customers[c.ID] = c
+	s.data.pj[c.ID] = sqlc.CustomerfPj{
+		CustomerID:  c.ID,
+		Cnpj:        arg.Cnpj,
+		CnpjIndex:   arg.CnpjIndex,
+		CompanyName: arg.CompanyName,
+		CreatedAt:   now(),
+		UpdatedAt:   now(),
+	}
+	s.insertAddress(sqlc.AddAddressToCustomerParams{
+		CustomerID:  c.ID,
+		AddressType: arg.AddressType,
+		Street:      arg.Street,
+		Number:      arg.Number,
+		Complement:  arg.Complement,
+		State:       arg.State,
+		City:        arg.City,
+		Cep:         arg.Cep,
+	})
+	return c.ID, nil
+}
+
+func (s *Store) AddAddressToCustomer(ctx context.Context, arg sqlc.AddAddressToCustomerParams) (int32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.customers[arg.CustomerID]; !ok {
+		return 0, foreignKeyViolation("addresses_customer_id_fkey")
+	}
+	return s.insertAddress(arg), nil
+}
+
+func (s *Store) GetCustomerByID(ctx context.Context, id uuid.UUID) (sqlc.GetCustomerByIDRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.data.customers[id]
+	if !ok {
+		return sqlc.GetCustomerByIDRow{}, pgx.ErrNoRows
+	}
+
+	row := sqlc.GetCustomerByIDRow{
+		ID:           c.ID,
+		Type:         c.Type,
+		Email:        c.Email,
+		Phone:        c.Phone,
+		IsActive:     c.IsActive,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+		AnonymizedAt: c.AnonymizedAt,
+		CustomFields: c.CustomFields,
+	}
+	if pf, ok := s.data.pf[id]; ok && c.Type == sqlc.CustomerTypePF {
+		row.Cpf = pf.Cpf
+		row.PfName = pf.Name
+		row.BirthDate = pf.BirthDate
+	}
+	if pj, ok := s.data.pj[id]; ok && c.Type == sqlc.CustomerTypePJ {
+		row.Cnpj = pj.Cnpj
+		row.CompanyName = pj.CompanyName
+	}
+	return row, nil
+}
+
+// GetAllCustomers returns the aggregated addresses the way pgx scans a JSON
+// column into interface{}: a slice of generic maps. Only customers with every
+// one of arg.Tags and an id after arg.After are returned, at most arg.Limit
+// of them when it is set.
+func (s *Store) GetAllCustomers(ctx context.Context, arg sqlc.GetAllCustomersParams) ([]sqlc.GetAllCustomersRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]uuid.UUID, 0, len(s.data.customers))
+	for id := range s.data.customers {
+		if s.hasTags(id, arg.Tags) && bytes.Compare(id[:], arg.After[:]) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+	if arg.Limit.Valid {
+		ids = ids[:min(len(ids), int(arg.Limit.Int32))]
+	}
+
+	var items []sqlc.GetAllCustomersRow
+	for _, id := range ids {
+		c := s.data.customers[id]
+		row := sqlc.GetAllCustomersRow{
+			CustomerID:           c.ID,
+			CustomerEmail:        c.Email,
+			CustomerPhone:        c.Phone,
+			CustomerCreatedAt:    c.CreatedAt,
+			CustomerUpdatedAt:    c.UpdatedAt,
+			CustomerIsActive:     c.IsActive,
+			CustomerAnonymizedAt: c.AnonymizedAt,
+			CustomerCustomFields: c.CustomFields,
+		}
+		if pf, ok := s.data.pf[id]; ok {
+			row.PfCpf = text(pf.Cpf)
+			row.PfName = text(pf.Name)
+			row.PfBirthDate = text(pf.BirthDate)
+		}
+		if pj, ok := s.data.pj[id]; ok {
+			row.PjCnpj = text(pj.Cnpj)
+			row.PjCompanyName = text(pj.CompanyName)
+		}
+
+		addresses := []interface{}{}
+		for _, a := range s.customerAddresses(id) {
+			var complement interface{}
+			if a.Complement.Valid {
+				complement = a.Complement.String
+			}
+			addresses = append(addresses, map[string]interface{}{
+				"address_id":   float64(a.ID),
+				"address_type": a.AddressType,
+				"street":       a.Street,
+				"number":       a.Number,
+				"complement":   complement,
+				"state":        a.State,
+				"city":         a.City,
+				"cep":          a.Cep,
+			})
+		}
+		row.Addresses = addresses
+		items = append(items, row)
+	}
+	return items, nil
+}
+
+func (s *Store) customerAddresses(customerID uuid.UUID) []sqlc.Address {
+	var addrs []sqlc.Address
+	for _, a := range s.data.addresses {
+		if a.CustomerID == customerID {
+			addrs = append(addrs, a)
+		}
+	}
+	slices.SortFunc(addrs, func(a, b sqlc.Address) int { return int(a.ID - b.ID) })
+	return addrs
+}
+
+func (s *Store) GetCustomerAddresses(ctx context.Context, customerID uuid.UUID) ([]sqlc.GetCustomerAddressesRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []sqlc.GetCustomerAddressesRow
+	for _, a := range s.customerAddresses(customerID) {
+		items = append(items, sqlc.GetCustomerAddressesRow{
+			ID:          a.ID,
+			AddressType: a.AddressType,
+			Street:      a.Street,
+			Number:      a.Number,
+			Complement:  a.Complement,
+			State:       a.State,
+			City:        a.City,
+			Cep:         a.Cep,
+		})
+	}
+	return items, nil
+}
+
+func (s *Store) GetCustomerConflicts(ctx context.Context, arg sqlc.GetCustomerConflictsParams) (sqlc.GetCustomerConflictsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var row sqlc.GetCustomerConflictsRow
+	for _, c := range s.data.customers {
+		row.EmailExists = row.EmailExists || c.Email == arg.Email
+		row.PhoneExists = row.PhoneExists || c.Phone == arg.Phone
+	}
+	for _, pf := range s.data.pf {
+		row.CpfExists = row.CpfExists || (arg.CpfIndex.Valid && pf.CpfIndex == arg.CpfIndex)
+	}
+	for _, pj := range s.data.pj {
+		row.CnpjExists = row.CnpjExists || (arg.CnpjIndex.Valid && pj.CnpjIndex == arg.CnpjIndex)
+	}
+	return row, nil
+}
+
+func (s *Store) GetCustomerIDByCpfIndex(ctx context.Context, cpfIndex pgtype.Text) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, pf := range s.data.pf {
+		if cpfIndex.Valid && pf.CpfIndex == cpfIndex {
+			return id, nil
+		}
+	}
+	return uuid.Nil, pgx.ErrNoRows
+}
+
+func (s *Store) GetCustomerIDByCnpjIndex(ctx context.Context, cnpjIndex pgtype.Text) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, pj := range s.data.pj {
+		if cnpjIndex.Valid && pj.CnpjIndex == cnpjIndex {
+			return id, nil
+		}
+	}
+	return uuid.Nil, pgx.ErrNoRows
+}
+
+func (s *Store) ListCustomerPFSensitiveData(ctx context.Context, arg sqlc.ListCustomerPFSensitiveDataParams) ([]sqlc.ListCustomerPFSensitiveDataRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []sqlc.ListCustomerPFSensitiveDataRow
+	for id, pf := range s.data.pf {
+		if s.data.customers[id].AnonymizedAt.Valid || bytes.Compare(id[:], arg.After[:]) <= 0 {
+			continue
+		}
+		items = append(items, sqlc.ListCustomerPFSensitiveDataRow{CustomerID: id, Cpf: pf.Cpf, BirthDate: pf.BirthDate})
+	}
+	slices.SortFunc(items, func(a, b sqlc.ListCustomerPFSensitiveDataRow) int {
+		return bytes.Compare(a.CustomerID[:], b.CustomerID[:])
+	})
+	return items[:min(len(items), int(arg.BatchSize))], nil
+}
+
+func (s *Store) ListCustomerPJSensitiveData(ctx context.Context, arg sqlc.ListCustomerPJSensitiveDataParams) ([]sqlc.ListCustomerPJSensitiveDataRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []sqlc.ListCustomerPJSensitiveDataRow
+	for id, pj := range s.data.pj {
+		if s.data.customers[id].AnonymizedAt.Valid || bytes.Compare(id[:], arg.After[:]) <= 0 {
+			continue
+		}
+		items = append(items, sqlc.ListCustomerPJSensitiveDataRow{CustomerID: id, Cnpj: pj.Cnpj})
+	}
+	slices.SortFunc(items, func(a, b sqlc.ListCustomerPJSensitiveDataRow) int {
+		return bytes.Compare(a.CustomerID[:], b.CustomerID[:])
+	})
+	return items[:min(len(items), int(arg.BatchSize))], nil
+}
+
+func (s *Store) UpdateCustomerPFSensitiveData(ctx context.Context, arg sqlc.UpdateCustomerPFSensitiveDataParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pf, ok := s.data.pf[arg.CustomerID]
+	if !ok {
+		return nil
+	}
+	for id, other := range s.data.pf {
+		if id != arg.CustomerID && arg.CpfIndex.Valid && other.CpfIndex == arg.CpfIndex {
+			return uniqueViolation("customerf_pf_cpf_index_key")
+		}
+	}
+	pf.Cpf = arg.Cpf
+	pf.CpfIndex = arg.CpfIndex
+	pf.BirthDate = arg.BirthDate
+	s.data.pf[arg.CustomerID] = pf
+	return nil
+}
+
+func (s *Store) UpdateCustomerPJSensitiveData(ctx context.Context, arg sqlc.UpdateCustomerPJSensitiveDataParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pj, ok := s.data.pj[arg.CustomerID]
+	if !ok {
+		return nil
+	}
+	for id, other := range s.data.pj {
+		if id != arg.CustomerID && arg.CnpjIndex.Valid && other.CnpjIndex == arg.CnpjIndex {
+			return uniqueViolation("customerf_pj_cnpj_index_key")
+		}
+	}
+	pj.Cnpj = arg.Cnpj
+	pj.CnpjIndex = arg.CnpjIndex
+	s.data.pj[arg.CustomerID] = pj
+	return nil
+}
+
+func (s *Store) UpdateCustomerBasicInfo(ctx context.Context, arg sqlc.UpdateCustomerBasicInfoParams) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.data.customers[arg.ID]
+	if !ok {
+		return uuid.Nil, pgx.ErrNoRows
+	}
+	for id, other := range s.data.customers {
+		if id == arg.ID {
+			continue
+		}
+		if other.Email == arg.Email {
+			return uuid.Nil, uniqueViolation("customers_email_key")
+		}
+		if other.Phone == arg.Phone {
+			return uuid.Nil, uniqueViolation("customers_phone_key")
+		}
+	}
+
+	c.Email = arg.Email
+	c.Phone = arg.Phone
+	s.data.customers[arg.ID] = c
+	return c.ID, nil
+}
+
+func (s *Store) UpdateAddress(ctx context.Context, arg sqlc.UpdateAddressParams) (int32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.data.addresses[arg.ID]
+	if !ok {
+		return 0, pgx.ErrNoRows
+	}
+	a.AddressType = arg.AddressType
+	a.Street = arg.Street
+	a.Number = arg.Number
+	a.Complement = arg.Complement
+	a.State = arg.State
+	a.City = arg.City
+	a.Cep = arg.Cep
+	s.data.addresses[arg.ID] = a
+	return a.ID, nil
+}
+
+func (s *Store) AnonymizeCustomer(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.data.customers[id]
+	if !ok {
+		return nil
+	}
+	c.Email = "anonymized-" + id.String() + "@anonymized.invalid"
+	c.Phone = "anonymized-" + id.String()
+	c.CustomFields = []byte("{}")
+	c.IsActive = false
+	c.AnonymizedAt = now()
+	c.UpdatedAt = now()
+	s.data.customers[id] = c
+	return nil
+}
+
+func (s *Store) AnonymizeCustomerPF(ctx context.Context, arg sqlc.AnonymizeCustomerPFParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pf, ok := s.data.pf[arg.CustomerID]
+	if !ok {
+		return nil
+	}
+	pf.Cpf = arg.Cpf
+	pf.CpfIndex = pgtype.Text{}
+	pf.Name = "Anonymized"
+	pf.BirthDate = arg.BirthDate
+	pf.UpdatedAt = now()
+	s.data.pf[arg.CustomerID] = pf
+	return nil
+}
+
+func (s *Store) AnonymizeCustomerPJ(ctx context.Context, arg sqlc.AnonymizeCustomerPJParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pj, ok := s.data.pj[arg.CustomerID]
+	if !ok {
+		return nil
+	}
+	pj.Cnpj = arg.Cnpj
+	pj.CnpjIndex = pgtype.Text{}
+	pj.CompanyName = "Anonymized"
+	pj.UpdatedAt = now()
+	s.data.pj[arg.CustomerID] = pj
+	return nil
+}
+
+func (s *Store) DeleteAddress(ctx context.Context, id int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data.addresses, id)
+	return nil
+}
+
+func (s *Store) DeleteCustomerAddresses(ctx context.Context, customerID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, a := range s.data.addresses {
+		if a.CustomerID == customerID {
+			delete(s.data.addresses, id)
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteCustomerPF(ctx context.Context, customerID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data.pf, customerID)
+	return nil
+}
+
+func (s *Store) DeleteCustomerPJ(ctx context.Context, customerID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data.pj, customerID)
+	return nil
+}
+
+func (s *Store) DeleteCustomer(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.pf[id]; ok {
+		return foreignKeyViolation("customerf_pf_customer_id_fkey")
+	}
+	if _, ok := s.data.pj[id]; ok {
+		return foreignKeyViolation("customerf_pj_customer_id_fkey")
+	}
+	for _, a := range s.data.addresses {
+		if a.CustomerID == id {
+			return foreignKeyViolation("addresses_customer_id_fkey")
+		}
+	}
+	for _, c := range s.data.contacts {
+		if c.CustomerID == id {
+			return foreignKeyViolation("contacts_customer_id_fkey")
+		}
+	}
+	for _, n := range s.data.notes {
+		if n.CustomerID == id {
+			return foreignKeyViolation("customer_notes_customer_id_fkey")
+		}
+	}
+	for key := range s.data.tags {
+		if key.customerID == id {
+			return foreignKeyViolation("customer_tags_customer_id_fkey")
+		}
+	}
+	for _, sv := range s.data.services {
+		if sv.CustomerID == id {
+			return foreignKeyViolation("services_customer_id_fkey")
+		}
+	}
+
+	delete(s.data.customers, id)
+	return nil
+}
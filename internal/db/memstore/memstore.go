@@ -0,0 +1,128 @@
+// Package memstore is an in-memory implementation of sqlc.Querier used by
+// tests. It mimics the parts of Postgres the services rely on: generated
+// ids, unique and foreign key violations reported as *pgconn.PgError and
+// pgx.ErrNoRows for missing rows.
+package memstore
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+const (
+	codeUniqueViolation     = "23505"
+	codeForeignKeyViolation = "23503"
+)
+
+type state struct {
+	customers         map[uuid.UUID]sqlc.Customer
+	pf                map[uuid.UUID]sqlc.CustomerfPf
+	pj                map[uuid.UUID]sqlc.CustomerfPj
+	addresses         map[int32]sqlc.Address
+	contacts          map[int32]sqlc.Contact
+	notes             map[int32]sqlc.CustomerNote
+	tags              map[tagKey]sqlc.CustomerTag
+	customFields      map[string]sqlc.CustomField
+	services          map[int32]sqlc.Service
+	users             map[uuid.UUID]sqlc.User
+	auditEvents       []sqlc.AuditEvent
+	nextAddressID     int32
+	nextContactID     int32
+	nextNoteID        int32
+	nextCustomFieldID int32
+	nextServiceID     int32
+}
+
+func newState() *state {
+	return &state{
+		customers:         make(map[uuid.UUID]sqlc.Customer),
+		pf:                make(map[uuid.UUID]sqlc.CustomerfPf),
+		pj:                make(map[uuid.UUID]sqlc.CustomerfPj),
+		addresses:         make(map[int32]sqlc.Address),
+		contacts:          make(map[int32]sqlc.Contact),
+		notes:             make(map[int32]sqlc.CustomerNote),
+		tags:              make(map[tagKey]sqlc.CustomerTag),
+		customFields:      make(map[string]sqlc.CustomField),
+		services:          make(map[int32]sqlc.Service),
+		users:             make(map[uuid.UUID]sqlc.User),
+		nextAddressID:     1,
+		nextContactID:     1,
+		nextNoteID:        1,
+		nextCustomFieldID: 1,
+		nextServiceID:     1,
+	}
+}
+
+func (s *state) clone() *state {
+	return &state{
+		customers:         maps.Clone(s.customers),
+		pf:                maps.Clone(s.pf),
+		pj:                maps.Clone(s.pj),
+		addresses:         maps.Clone(s.addresses),
+		contacts:          maps.Clone(s.contacts),
+		notes:             maps.Clone(s.notes),
+		tags:              maps.Clone(s.tags),
+		customFields:      maps.Clone(s.customFields),
+		services:          maps.Clone(s.services),
+		users:             maps.Clone(s.users),
+		auditEvents:       slices.Clone(s.auditEvents),
+		nextAddressID:     s.nextAddressID,
+		nextContactID:     s.nextContactID,
+		nextNoteID:        s.nextNoteID,
+		nextCustomFieldID: s.nextCustomFieldID,
+		nextServiceID:     s.nextServiceID,
+	}
+}
+
+// Store satisfies both sqlc.Querier and services.Transactor.
+type Store struct {
+	mu   sync.Mutex
+	data *state
+}
+
+var _ sqlc.Querier = (*Store)(nil)
+
+func New() *Store {
+	return &Store{data: newState()}
+}
+
+// WithTx runs fn against a copy of the data and only publishes the copy when
+// fn succeeds, which gives callers the same all-or-nothing behaviour as a
+// Postgres transaction. The store stays locked while fn runs, so other
+// queries wait for the transaction instead of having their writes replaced
+// when it publishes. fn must only use q: calling the Store itself deadlocks.
+func (s *Store) WithTx(ctx context.Context, fn func(q sqlc.Querier) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := &Store{data: s.data.clone()}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	s.data = tx.data
+	return nil
+}
+
+func uniqueViolation(constraint string) error {
+	return &pgconn.PgError{Code: codeUniqueViolation, ConstraintName: constraint}
+}
+
+func foreignKeyViolation(constraint string) error {
+	return &pgconn.PgError{Code: codeForeignKeyViolation, ConstraintName: constraint}
+}
+
+func now() pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true}
+}
+
+func text(v string) pgtype.Text {
+	return pgtype.Text{String: v, Valid: true}
+}
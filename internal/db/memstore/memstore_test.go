@@ -0,0 +1,101 @@
+package memstore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/db/memstore"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+func pj(i int) sqlc.CreateCustomerPJParams {
+	return sqlc.CreateCustomerPJParams{
+		ID: uuid.New(), Type: sqlc.CustomerTypePJ, Email: fmt.Sprintf("pj%d@example.com", i), Phone: fmt.Sprintf("11 9000%d-0000", i), Cnpj: "x", CompanyName: "PJ",
+	}
+}
+
+func countCustomers(t *testing.T, q sqlc.Querier) int {
+	t.Helper()
+	rows, err := q.GetAllCustomers(context.Background(), sqlc.GetAllCustomersParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(rows)
+}
+
+func TestWithTxRollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	store := memstore.New()
+	errAbort := errors.New("abort")
+
+	err := store.WithTx(ctx, func(q sqlc.Querier) error {
+		if _, err := q.CreateCustomerPJ(ctx, pj(1)); err != nil {
+			return err
+		}
+		if n := countCustomers(t, q); n != 1 {
+			t.Errorf("inside the transaction: %d customers, want 1", n)
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithTx = %v, want the error returned by fn", err)
+	}
+	if n := countCustomers(t, store); n != 0 {
+		t.Errorf("after rollback: %d customers, want 0", n)
+	}
+}
+
+// Readers outside a transaction wait for it and never see half of it.
+func TestWithTxIsolatesUncommittedWrites(t *testing.T) {
+	ctx := context.Background()
+	store := memstore.New()
+
+	seen := make(chan int, 1)
+	err := store.WithTx(ctx, func(q sqlc.Querier) error {
+		if _, err := q.CreateCustomerPJ(ctx, pj(1)); err != nil {
+			return err
+		}
+		go func() { seen <- countCustomers(t, store) }()
+		time.Sleep(20 * time.Millisecond)
+		_, err := q.CreateCustomerPJ(ctx, pj(2))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := <-seen; n != 2 {
+		t.Errorf("concurrent reader saw %d customers, want both once committed", n)
+	}
+}
+
+// Writes made outside a transaction while it runs must survive its commit.
+func TestWithTxKeepsConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	store := memstore.New()
+
+	outside := make(chan error, 1)
+	err := store.WithTx(ctx, func(q sqlc.Querier) error {
+		if _, err := q.CreateCustomerPJ(ctx, pj(1)); err != nil {
+			return err
+		}
+		go func() {
+			_, err := store.CreateCustomerPJ(ctx, pj(2))
+			outside <- err
+		}()
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-outside; err != nil {
+		t.Fatal(err)
+	}
+	if n := countCustomers(t, store); n != 2 {
+		t.Errorf("%d customers, want both", n)
+	}
+}
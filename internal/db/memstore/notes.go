@@ -0,0 +1,138 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+// authorName mirrors the LEFT JOIN on users: NULL when the author is unknown
+// or was deleted.
+func (s *Store) authorName(authorID pgtype.UUID) pgtype.Text {
+	if !authorID.Valid {
+		return pgtype.Text{}
+	}
+	u, ok := s.data.users[authorID.Bytes]
+	if !ok {
+		return pgtype.Text{}
+	}
+	return text(u.Name)
+}
+
+func (s *Store) CreateCustomerNote(ctx context.Context, arg sqlc.CreateCustomerNoteParams) (sqlc.CreateCustomerNoteRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.customers[arg.CustomerID]; !ok {
+		return sqlc.CreateCustomerNoteRow{}, foreignKeyViolation("customer_notes_customer_id_fkey")
+	}
+	if _, ok := s.data.users[arg.AuthorID.Bytes]; arg.AuthorID.Valid && !ok {
+		return sqlc.CreateCustomerNoteRow{}, foreignKeyViolation("customer_notes_author_id_fkey")
+	}
+
+	id := s.data.nextNoteID
+	s.data.nextNoteID++
+	n := sqlc.CustomerNote{
+		ID:         id,
+		CustomerID: arg.CustomerID,
+		AuthorID:   arg.AuthorID,
+		Body:       arg.Body,
+		CreatedAt:  now(),
+	}
+	s.data.notes[id] = n
+	return sqlc.CreateCustomerNoteRow{
+		ID:         n.ID,
+		CustomerID: n.CustomerID,
+		AuthorID:   n.AuthorID,
+		AuthorName: s.authorName(n.AuthorID),
+		Body:       n.Body,
+		CreatedAt:  n.CreatedAt,
+	}, nil
+}
+
+func (s *Store) ListNotesByCustomerIDs(ctx context.Context, customerIds []uuid.UUID) ([]sqlc.ListNotesByCustomerIDsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.notesByCustomerIDs(customerIds), nil
+}
+
+func (s *Store) ListLatestNotesByCustomerIDs(ctx context.Context, customerIds []uuid.UUID) ([]sqlc.ListLatestNotesByCustomerIDsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []sqlc.ListLatestNotesByCustomerIDsRow
+	for _, n := range s.notesByCustomerIDs(customerIds) {
+		if len(items) > 0 && items[len(items)-1].CustomerID == n.CustomerID {
+			items[len(items)-1].NoteCount++
+			continue
+		}
+		items = append(items, sqlc.ListLatestNotesByCustomerIDsRow{
+			ID:         n.ID,
+			CustomerID: n.CustomerID,
+			AuthorID:   n.AuthorID,
+			AuthorName: n.AuthorName,
+			Body:       n.Body,
+			CreatedAt:  n.CreatedAt,
+			NoteCount:  1,
+		})
+	}
+	return items, nil
+}
+
+// notesByCustomerIDs returns the notes of customerIds grouped by customer,
+// newest first. The caller holds s.mu.
+func (s *Store) notesByCustomerIDs(customerIds []uuid.UUID) []sqlc.ListNotesByCustomerIDsRow {
+	var items []sqlc.ListNotesByCustomerIDsRow
+	for _, n := range s.data.notes {
+		if !slices.Contains(customerIds, n.CustomerID) {
+			continue
+		}
+		items = append(items, sqlc.ListNotesByCustomerIDsRow{
+			ID:         n.ID,
+			CustomerID: n.CustomerID,
+			AuthorID:   n.AuthorID,
+			AuthorName: s.authorName(n.AuthorID),
+			Body:       n.Body,
+			CreatedAt:  n.CreatedAt,
+		})
+	}
+	slices.SortFunc(items, func(a, b sqlc.ListNotesByCustomerIDsRow) int {
+		return cmp.Or(
+			cmp.Compare(a.CustomerID.String(), b.CustomerID.String()),
+			b.CreatedAt.Time.Compare(a.CreatedAt.Time),
+			cmp.Compare(b.ID, a.ID),
+		)
+	})
+	return items
+}
+
+func (s *Store) DeleteCustomerNote(ctx context.Context, arg sqlc.DeleteCustomerNoteParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.data.notes[arg.ID]
+	if !ok || n.CustomerID != arg.CustomerID {
+		return 0, nil
+	}
+	delete(s.data.notes, arg.ID)
+	return 1, nil
+}
+
+func (s *Store) DeleteCustomerNotes(ctx context.Context, customerID uuid.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+	for id, note := range s.data.notes {
+		if note.CustomerID == customerID {
+			delete(s.data.notes, id)
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,105 @@
+package memstore
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+func (s *Store) CreateService(ctx context.Context, arg sqlc.CreateServiceParams) (int32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.customers[arg.CustomerID]; !ok {
+		return 0, foreignKeyViolation("services_customer_id_fkey")
+	}
+
+	id := s.data.nextServiceID
+	s.data.nextServiceID++
+	s.data.services[id] = sqlc.Service{
+		ID:          id,
+		CustomerID:  arg.CustomerID,
+		TypeProduct: arg.TypeProduct,
+		Description: arg.Description,
+		TotalValue:  arg.TotalValue,
+		DownPayment: arg.DownPayment,
+		IsPaid:      arg.IsPaid,
+		IsFinished:  arg.IsFinished,
+	}
+	return id, nil
+}
+
+func (s *Store) sortedServices(keep func(sqlc.Service) bool) []sqlc.Service {
+	var items []sqlc.Service
+	for _, sv := range s.data.services {
+		if keep(sv) {
+			items = append(items, sv)
+		}
+	}
+	slices.SortFunc(items, func(a, b sqlc.Service) int { return int(a.ID - b.ID) })
+	return items
+}
+
+func (s *Store) GetServicesByCustomerID(ctx context.Context, customerID uuid.UUID) ([]sqlc.Service, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sortedServices(func(sv sqlc.Service) bool { return sv.CustomerID == customerID }), nil
+}
+
+func (s *Store) ListAllServices(ctx context.Context) ([]sqlc.Service, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sortedServices(func(sqlc.Service) bool { return true }), nil
+}
+
+func (s *Store) CountServicesByCustomerID(ctx context.Context, customerID uuid.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int64
+	for _, sv := range s.data.services {
+		if sv.CustomerID == customerID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *Store) DeleteService(ctx context.Context, id int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data.services, id)
+	return nil
+}
+
+func (s *Store) UpdateServiceFinishStatus(ctx context.Context, arg sqlc.UpdateServiceFinishStatusParams) (sqlc.Service, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sv, ok := s.data.services[arg.ID]
+	if !ok {
+		return sqlc.Service{}, pgx.ErrNoRows
+	}
+	sv.IsFinished = arg.IsFinished
+	s.data.services[arg.ID] = sv
+	return sv, nil
+}
+
+func (s *Store) UpdateServicePaymentStatus(ctx context.Context, arg sqlc.UpdateServicePaymentStatusParams) (sqlc.Service, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sv, ok := s.data.services[arg.ID]
+	if !ok {
+		return sqlc.Service{}, pgx.ErrNoRows
+	}
+	sv.IsPaid = arg.IsPaid
+	s.data.services[arg.ID] = sv
+	return sv, nil
+}
@@ -0,0 +1,75 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+// tagKey is the primary key of customer_tags.
+type tagKey struct {
+	customerID uuid.UUID
+	tag        string
+}
+
+// hasTags reports whether the customer has every one of tags.
+func (s *Store) hasTags(customerID uuid.UUID, tags []string) bool {
+	for _, tag := range tags {
+		if _, ok := s.data.tags[tagKey{customerID, tag}]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Store) AddCustomerTags(ctx context.Context, arg sqlc.AddCustomerTagsParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(arg.Tags) == 0 {
+		return nil
+	}
+	if _, ok := s.data.customers[arg.CustomerID]; !ok {
+		return foreignKeyViolation("customer_tags_customer_id_fkey")
+	}
+	for _, tag := range arg.Tags {
+		key := tagKey{arg.CustomerID, tag}
+		if _, ok := s.data.tags[key]; !ok {
+			s.data.tags[key] = sqlc.CustomerTag{CustomerID: arg.CustomerID, Tag: tag, CreatedAt: now()}
+		}
+	}
+	return nil
+}
+
+func (s *Store) ListTagsByCustomerIDs(ctx context.Context, customerIds []uuid.UUID) ([]sqlc.CustomerTag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []sqlc.CustomerTag
+	for key, t := range s.data.tags {
+		if slices.Contains(customerIds, key.customerID) {
+			items = append(items, t)
+		}
+	}
+	slices.SortFunc(items, func(a, b sqlc.CustomerTag) int {
+		return cmp.Or(cmp.Compare(a.CustomerID.String(), b.CustomerID.String()), cmp.Compare(a.Tag, b.Tag))
+	})
+	return items, nil
+}
+
+func (s *Store) DeleteCustomerTags(ctx context.Context, customerID uuid.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+	for key := range s.data.tags {
+		if key.customerID == customerID {
+			delete(s.data.tags, key)
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,134 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+func (s *Store) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range s.data.users {
+		if u.Email == arg.Email {
+			return uuid.Nil, uniqueViolation("users_email_key")
+		}
+	}
+
+	u := sqlc.User{
+		ID:        uuid.New(),
+		Name:      arg.Name,
+		Email:     arg.Email,
+		IsAdmin:   arg.IsAdmin,
+		Password:  arg.Password,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+	s.data.users[u.ID] = u
+	return u.ID, nil
+}
+
+func (s *Store) GetUserByEmail(ctx context.Context, email string) (sqlc.GetUserByEmailRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range s.data.users {
+		if u.Email == email {
+			return sqlc.GetUserByEmailRow{
+				ID:        u.ID,
+				Name:      u.Name,
+				Email:     u.Email,
+				Password:  u.Password,
+				CreatedAt: u.CreatedAt,
+				UpdatedAt: u.UpdatedAt,
+			}, nil
+		}
+	}
+	return sqlc.GetUserByEmailRow{}, pgx.ErrNoRows
+}
+
+func (s *Store) CheckIfUserIsAdmin(ctx context.Context, id uuid.UUID) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.data.users[id]
+	if !ok {
+		return false, pgx.ErrNoRows
+	}
+	return u.IsAdmin, nil
+}
+
+// UpdateUser mirrors the generated query, whose WHERE clause compares the id
+// with the is_admin argument and therefore never matches a row.
+func (s *Store) UpdateUser(ctx context.Context, arg sqlc.UpdateUserParams) (sqlc.UpdateUserRow, error) {
+	return sqlc.UpdateUserRow{}, pgx.ErrNoRows
+}
+
+func (s *Store) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data.users, id)
+	for noteID, n := range s.data.notes {
+		if n.AuthorID.Valid && n.AuthorID.Bytes == id {
+			n.AuthorID = pgtype.UUID{}
+			s.data.notes[noteID] = n
+		}
+	}
+	return nil
+}
+
+func (s *Store) ListUsers(ctx context.Context) ([]sqlc.ListUsersRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := make([]sqlc.ListUsersRow, 0, len(s.data.users))
+	for _, u := range s.data.users {
+		items = append(items, sqlc.ListUsersRow{
+			ID:        u.ID,
+			Name:      u.Name,
+			Email:     u.Email,
+			IsAdmin:   u.IsAdmin,
+			CreatedAt: u.CreatedAt,
+		})
+	}
+	slices.SortFunc(items, func(a, b sqlc.ListUsersRow) int {
+		return cmp.Or(a.CreatedAt.Time.Compare(b.CreatedAt.Time), cmp.Compare(a.Email, b.Email))
+	})
+	return items, nil
+}
+
+func (s *Store) UpdateUserPassword(ctx context.Context, arg sqlc.UpdateUserPasswordParams) (uuid.UUID, error) {
+	return s.updateUserByEmail(arg.Email, func(u *sqlc.User) { u.Password = arg.Password })
+}
+
+func (s *Store) SetUserAdmin(ctx context.Context, arg sqlc.SetUserAdminParams) (uuid.UUID, error) {
+	return s.updateUserByEmail(arg.Email, func(u *sqlc.User) { u.IsAdmin = arg.IsAdmin })
+}
+
+func (s *Store) updateUserByEmail(email string, update func(*sqlc.User)) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, u := range s.data.users {
+		if u.Email == email {
+			update(&u)
+			u.UpdatedAt = now()
+			s.data.users[id] = u
+			return id, nil
+		}
+	}
+	return uuid.Nil, pgx.ErrNoRows
+}
+
+// DeleteExpiredSessions is a no-op: sessions are kept by the session store,
+// not by Store.
+func (s *Store) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	return 0, nil
+}
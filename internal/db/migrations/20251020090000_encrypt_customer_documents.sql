@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+-- cpf, cnpj and birth_date now hold envelope-encrypted values (see
+-- internal/fieldcrypt). Ciphertexts are randomized, so uniqueness and lookups
+-- move to the *_index blind index columns. Existing rows keep their plaintext
+-- until the server encrypts them and fills the indexes at startup (or
+-- `client-manager keys rotate` does). The plaintext UNIQUE constraints stay:
+-- they still guard those rows meanwhile, and randomized ciphertexts never
+-- collide on them.
+ALTER TABLE customerf_pf
+    ALTER COLUMN cpf TYPE TEXT,
+    ALTER COLUMN birth_date TYPE TEXT USING to_char(birth_date, 'YYYY-MM-DD'),
+    ADD COLUMN cpf_index TEXT;
+ALTER TABLE customerf_pf ADD CONSTRAINT customerf_pf_cpf_index_key UNIQUE (cpf_index);
+
+ALTER TABLE customerf_pj
+    ALTER COLUMN cnpj TYPE TEXT,
+    ADD COLUMN cnpj_index TEXT;
+ALTER TABLE customerf_pj ADD CONSTRAINT customerf_pj_cnpj_index_key UNIQUE (cnpj_index);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM customerf_pf WHERE cpf LIKE 'enc:%' OR birth_date LIKE 'enc:%')
+        OR EXISTS (SELECT 1 FROM customerf_pj WHERE cnpj LIKE 'enc:%') THEN
+        RAISE EXCEPTION 'customer documents are encrypted and cannot be converted back';
+    END IF;
+END $$;
+
+-- The old columns were VARCHAR(14): documents that only fit formatted
+-- ("11.222.333/0001-81") lose their punctuation. If that makes two of them
+-- equal, the plaintext UNIQUE constraint stops the migration and the
+-- customers have to be merged first.
+ALTER TABLE customerf_pj DROP COLUMN IF EXISTS cnpj_index;
+ALTER TABLE customerf_pj ALTER COLUMN cnpj TYPE VARCHAR(14)
+    USING CASE WHEN length(cnpj) > 14 THEN regexp_replace(cnpj, '[^0-9A-Za-z]', '', 'g') ELSE cnpj END;
+
+ALTER TABLE customerf_pf DROP COLUMN IF EXISTS cpf_index;
+ALTER TABLE customerf_pf
+    ALTER COLUMN birth_date TYPE DATE USING birth_date::date,
+    ALTER COLUMN cpf TYPE VARCHAR(14)
+        USING CASE WHEN length(cpf) > 14 THEN regexp_replace(cpf, '[^0-9A-Za-z]', '', 'g') ELSE cpf END;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Contacts are the people reached at a customer: employees of a PJ customer
+-- or family members of a PF one. Unlike customers.email and customers.phone
+-- their emails and phones are not unique, so a shared phone is fine.
+CREATE TABLE contacts (
+    id SERIAL PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    name VARCHAR(100) NOT NULL,
+    role VARCHAR(100),
+    emails TEXT[] NOT NULL DEFAULT '{}',
+    phones TEXT[] NOT NULL DEFAULT '{}',
+    whatsapp BOOLEAN NOT NULL DEFAULT FALSE,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX contacts_customer_id_idx ON contacts (customer_id);
+CREATE UNIQUE INDEX contacts_primary_key ON contacts (customer_id) WHERE is_primary;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS contacts;
+-- +goose StatementEnd
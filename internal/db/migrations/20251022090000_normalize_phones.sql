@@ -0,0 +1,106 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Phones are now stored in E.164 (see internal/phone), so the UNIQUE
+-- constraint on customers.phone holds whatever format the number was typed
+-- in. normalize_phone_e164 mirrors phone.Parse and returns NULL for values it
+-- cannot parse.
+CREATE FUNCTION normalize_phone_e164(raw TEXT) RETURNS TEXT
+LANGUAGE plpgsql IMMUTABLE AS $fn$
+DECLARE
+    digits TEXT;
+    ddd TEXT;
+    subscriber TEXT;
+BEGIN
+    IF raw !~ '^\s*\+?[0-9 ().-]+\s*$' THEN
+        RETURN NULL;
+    END IF;
+    digits := regexp_replace(raw, '[^0-9]', '', 'g');
+
+    IF btrim(raw) LIKE '+%' OR (length(digits) >= 12 AND digits LIKE '55%') THEN
+        IF digits NOT LIKE '55%' THEN
+            RETURN NULL;
+        END IF;
+        digits := substr(digits, 3);
+    ELSIF length(digits) >= 11 AND digits LIKE '0%' THEN
+        digits := substr(digits, 2);
+    END IF;
+
+    IF length(digits) NOT IN (10, 11) THEN
+        RETURN NULL;
+    END IF;
+    ddd := substr(digits, 1, 2);
+    subscriber := substr(digits, 3);
+    IF ddd NOT IN (
+        '11', '12', '13', '14', '15', '16', '17', '18', '19',
+        '21', '22', '24', '27', '28',
+        '31', '32', '33', '34', '35', '37', '38',
+        '41', '42', '43', '44', '45', '46', '47', '48', '49',
+        '51', '53', '54', '55',
+        '61', '62', '63', '64', '65', '66', '67', '68', '69',
+        '71', '73', '74', '75', '77', '79',
+        '81', '82', '83', '84', '85', '86', '87', '88', '89',
+        '91', '92', '93', '94', '95', '96', '97', '98', '99'
+    ) THEN
+        RETURN NULL;
+    END IF;
+
+    IF length(subscriber) = 9 AND subscriber LIKE '9%' THEN
+        NULL;
+    ELSIF length(subscriber) = 8 AND left(subscriber, 1) BETWEEN '2' AND '5' THEN
+        NULL;
+    ELSIF length(subscriber) = 8 AND left(subscriber, 1) >= '6' THEN
+        subscriber := '9' || subscriber;
+    ELSE
+        RETURN NULL;
+    END IF;
+    RETURN '+55' || ddd || subscriber;
+END
+$fn$;
+
+-- Customers typed with the same number in different formats would collide
+-- on the UNIQUE constraint: only the oldest of them is normalized, and a
+-- number already taken by another row is left alone. Those rows are reported
+-- below and must be merged by hand.
+WITH normalized AS (
+    SELECT id, e164, row_number() OVER (PARTITION BY e164 ORDER BY created_at, id) AS rank
+    FROM (SELECT id, created_at, normalize_phone_e164(phone) AS e164 FROM customers) p
+    WHERE e164 IS NOT NULL
+)
+UPDATE customers c
+SET phone = n.e164, updated_at = NOW()
+FROM normalized n
+WHERE c.id = n.id
+    AND n.rank = 1
+    AND c.phone <> n.e164
+    AND NOT EXISTS (SELECT 1 FROM customers o WHERE o.phone = n.e164 AND o.id <> c.id);
+
+UPDATE contacts
+SET phones = ARRAY(
+        SELECT COALESCE(normalize_phone_e164(p), p)
+        FROM unnest(phones) WITH ORDINALITY AS u(p, ord)
+        ORDER BY ord
+    ),
+    updated_at = NOW()
+WHERE EXISTS (
+    SELECT 1 FROM unnest(phones) AS u(p)
+    WHERE normalize_phone_e164(p) IS DISTINCT FROM p
+        AND normalize_phone_e164(p) IS NOT NULL
+);
+
+DO $$
+DECLARE
+    pending INT;
+BEGIN
+    SELECT count(*) INTO pending
+    FROM customers
+    WHERE anonymized_at IS NULL AND phone !~ '^\+55[0-9]{10,11}$';
+    IF pending > 0 THEN
+        RAISE NOTICE '% customer phone(s) could not be normalized to E.164 and were kept as typed', pending;
+    END IF;
+END $$;
+
+DROP FUNCTION normalize_phone_e164(TEXT);
+-- +goose StatementEnd
+
+-- +goose Down
+-- The original formatting is not kept, so normalized phones stay in E.164.
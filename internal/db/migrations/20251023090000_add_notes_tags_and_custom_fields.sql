@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Notes are free-form and timestamped. The author is kept when possible, but
+-- deleting a user must not delete what they wrote.
+CREATE TABLE customer_notes (
+    id SERIAL PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX customer_notes_customer_id_idx ON customer_notes (customer_id);
+
+CREATE TABLE customer_tags (
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    tag VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (customer_id, tag)
+);
+
+CREATE INDEX customer_tags_tag_idx ON customer_tags (tag);
+
+-- Custom fields are defined by admins; the values of a customer live in
+-- customers.custom_fields keyed by custom_fields.key, as JSON strings for
+-- text, date (YYYY-MM-DD) and select fields and JSON numbers for number ones.
+CREATE TYPE custom_field_type AS ENUM ('text', 'number', 'date', 'select');
+
+CREATE TABLE custom_fields (
+    id SERIAL PRIMARY KEY,
+    key VARCHAR(50) NOT NULL UNIQUE,
+    label VARCHAR(100) NOT NULL,
+    type custom_field_type NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE customers ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE customers DROP COLUMN IF EXISTS custom_fields;
+DROP TABLE IF EXISTS custom_fields;
+DROP TYPE IF EXISTS custom_field_type;
+DROP TABLE IF EXISTS customer_tags;
+DROP TABLE IF EXISTS customer_notes;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+    actor_id,
+    action,
+    entity_type,
+    entity_id,
+    details
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING id;
+
+
+-- name: ListAuditEventsByEntity :many
+SELECT id, actor_id, action, entity_type, entity_id, details, created_at
+FROM audit_events
+WHERE entity_type = $1 AND entity_id = $2
+ORDER BY created_at, id;
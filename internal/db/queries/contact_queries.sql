@@ -0,0 +1,48 @@
+-- name: CreateContact :one
+INSERT INTO contacts (customer_id, name, role, emails, phones, whatsapp, is_primary)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, customer_id, name, role, emails, phones, whatsapp, is_primary, created_at, updated_at;
+
+
+-- name: ListCustomerContacts :many
+SELECT id, customer_id, name, role, emails, phones, whatsapp, is_primary, created_at, updated_at
+FROM contacts
+WHERE customer_id = $1
+ORDER BY is_primary DESC, id;
+
+
+-- name: ListContactsByCustomerIDs :many
+SELECT id, customer_id, name, role, emails, phones, whatsapp, is_primary, created_at, updated_at
+FROM contacts
+WHERE customer_id = ANY(@customer_ids::uuid[])
+ORDER BY customer_id, is_primary DESC, id;
+
+
+-- name: UpdateContact :one
+UPDATE contacts
+SET
+    name = $3,
+    role = $4,
+    emails = $5,
+    phones = $6,
+    whatsapp = $7,
+    is_primary = $8,
+    updated_at = NOW()
+WHERE id = $1 AND customer_id = $2
+RETURNING id, customer_id, name, role, emails, phones, whatsapp, is_primary, created_at, updated_at;
+
+
+-- name: ClearPrimaryContact :exec
+UPDATE contacts
+SET is_primary = FALSE, updated_at = NOW()
+WHERE customer_id = $1 AND is_primary;
+
+
+-- name: DeleteContact :execrows
+DELETE FROM contacts
+WHERE id = $1 AND customer_id = $2;
+
+
+-- name: DeleteCustomerContacts :execrows
+DELETE FROM contacts
+WHERE customer_id = $1;
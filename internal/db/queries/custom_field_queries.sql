@@ -0,0 +1,27 @@
+-- name: CreateCustomField :one
+INSERT INTO custom_fields (key, label, type, options)
+VALUES ($1, $2, $3, $4)
+RETURNING id, key, label, type, options, created_at;
+
+
+-- name: ListCustomFields :many
+SELECT id, key, label, type, options, created_at
+FROM custom_fields
+ORDER BY key;
+
+
+-- name: DeleteCustomField :execrows
+DELETE FROM custom_fields
+WHERE key = $1;
+
+
+-- name: SetCustomerCustomFields :execrows
+UPDATE customers
+SET custom_fields = $2, updated_at = NOW()
+WHERE id = $1;
+
+
+-- name: RemoveCustomFieldValues :exec
+UPDATE customers
+SET custom_fields = custom_fields - @key::text, updated_at = NOW()
+WHERE custom_fields ? @key::text;
@@ -1,12 +1,12 @@
 -- name: CreateCustomerPF :one
 WITH new_customer AS (
-    INSERT INTO customers (type, email, phone)
-    VALUES ($1, $2, $3)
+    INSERT INTO customers (id, type, email, phone)
+    VALUES ($1, $2, $3, $4)
     RETURNING id
 ),
 customer_pf AS (
-    INSERT INTO customerf_pf (customer_id, cpf, name, birth_date)
-    SELECT id, $4, $5, $6
+    INSERT INTO customerf_pf (customer_id, cpf, cpf_index, name, birth_date)
+    SELECT id, $5, $6, $7, $8
     FROM new_customer
     RETURNING customer_id
 )
@@ -22,20 +22,20 @@ INSERT INTO addresses (
 )
 SELECT 
     customer_id,
-    $7, $8, $9, $10, $11, $12, $13
+    $9, $10, $11, $12, $13, $14, $15
 FROM customer_pf
 RETURNING customer_id;
 
 
 -- name: CreateCustomerPJ :one
 WITH new_customer AS (
-    INSERT INTO customers (type, email, phone)
-    VALUES ($1, $2, $3)
+    INSERT INTO customers (id, type, email, phone)
+    VALUES ($1, $2, $3, $4)
     RETURNING id
 ),
 customer_pj AS (
-    INSERT INTO customerf_pj (customer_id, cnpj, company_name)
-    SELECT id, $4, $5
+    INSERT INTO customerf_pj (customer_id, cnpj, cnpj_index, company_name)
+    SELECT id, $5, $6, $7
     FROM new_customer
     RETURNING customer_id
 )
@@ -51,7 +51,7 @@ INSERT INTO addresses (
 )
 SELECT 
     customer_id,
-    $6, $7, $8, $9, $10, $11, $12
+    $8, $9, $10, $11, $12, $13, $14
 FROM customer_pj
 RETURNING customer_id;
 
@@ -79,6 +79,8 @@ SELECT
     c.is_active,
     c.created_at,
     c.updated_at,
+    c.anonymized_at,
+    c.custom_fields,
     CASE 
         WHEN c.type = 'PF' THEN pf.cpf
         ELSE NULL
@@ -115,6 +117,8 @@ SELECT
     c.created_at AS customer_created_at,
     c.updated_at AS customer_updated_at,
     c.is_active AS customer_is_active,
+    c.anonymized_at AS customer_anonymized_at,
+    c.custom_fields AS customer_custom_fields,
 
     pf.cpf AS pf_cpf,
     pf.name AS pf_name,
@@ -142,11 +146,21 @@ FROM customers c
 LEFT JOIN customerf_pf pf ON c.id = pf.customer_id
 LEFT JOIN customerf_pj pj ON c.id = pj.customer_id
 LEFT JOIN addresses a ON c.id = a.customer_id
+WHERE (COALESCE(CARDINALITY(@tags::text[]), 0) = 0
+    OR c.id IN (
+        SELECT t.customer_id
+        FROM customer_tags t
+        WHERE t.tag = ANY(@tags::text[])
+        GROUP BY t.customer_id
+        HAVING COUNT(*) = CARDINALITY(@tags::text[])
+    ))
+    AND c.id > @after::uuid
 GROUP BY 
-    c.id, c.email, c.phone, c.created_at, c.updated_at, c.is_active,
+    c.id, c.email, c.phone, c.created_at, c.updated_at, c.is_active, c.anonymized_at, c.custom_fields,
     pf.cpf, pf.name, pf.birth_date,
     pj.cnpj, pj.company_name
-ORDER BY c.id;
+ORDER BY c.id
+LIMIT sqlc.narg('limit');
 
 
 
@@ -165,6 +179,26 @@ FROM addresses
 WHERE customer_id = $1;
 
 
+-- name: GetCustomerConflicts :one
+SELECT
+    EXISTS(SELECT 1 FROM customers c WHERE c.email = @email) AS email_exists,
+    EXISTS(SELECT 1 FROM customers c WHERE c.phone = @phone) AS phone_exists,
+    EXISTS(SELECT 1 FROM customerf_pf pf WHERE pf.cpf_index = @cpf_index) AS cpf_exists,
+    EXISTS(SELECT 1 FROM customerf_pj pj WHERE pj.cnpj_index = @cnpj_index) AS cnpj_exists;
+
+
+-- name: GetCustomerIDByCpfIndex :one
+SELECT customer_id
+FROM customerf_pf
+WHERE cpf_index = $1;
+
+
+-- name: GetCustomerIDByCnpjIndex :one
+SELECT customer_id
+FROM customerf_pj
+WHERE cnpj_index = $1;
+
+
 -- name: UpdateCustomerBasicInfo :one
 UPDATE customers
 SET email = $2, phone = $3
@@ -186,6 +220,69 @@ WHERE id = $1
 RETURNING id;
 
 
+-- name: AnonymizeCustomer :exec
+UPDATE customers
+SET
+    email = 'anonymized-' || id || '@anonymized.invalid',
+    phone = 'anonymized-' || id,
+    custom_fields = '{}',
+    is_active = FALSE,
+    anonymized_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1;
+
+
+-- name: AnonymizeCustomerPF :exec
+UPDATE customerf_pf
+SET
+    cpf = @cpf,
+    cpf_index = NULL,
+    name = 'Anonymized',
+    birth_date = @birth_date,
+    updated_at = NOW()
+WHERE customer_id = @customer_id;
+
+
+-- name: AnonymizeCustomerPJ :exec
+UPDATE customerf_pj
+SET
+    cnpj = @cnpj,
+    cnpj_index = NULL,
+    company_name = 'Anonymized',
+    updated_at = NOW()
+WHERE customer_id = @customer_id;
+
+
+-- name: ListCustomerPFSensitiveData :many
+SELECT pf.customer_id, pf.cpf, pf.birth_date
+FROM customerf_pf pf
+JOIN customers c ON c.id = pf.customer_id
+WHERE c.anonymized_at IS NULL AND pf.customer_id > @after::uuid
+ORDER BY pf.customer_id
+LIMIT @batch_size;
+
+
+-- name: ListCustomerPJSensitiveData :many
+SELECT pj.customer_id, pj.cnpj
+FROM customerf_pj pj
+JOIN customers c ON c.id = pj.customer_id
+WHERE c.anonymized_at IS NULL AND pj.customer_id > @after::uuid
+ORDER BY pj.customer_id
+LIMIT @batch_size;
+
+
+-- name: UpdateCustomerPFSensitiveData :exec
+UPDATE customerf_pf
+SET cpf = $2, cpf_index = $3, birth_date = $4
+WHERE customer_id = $1;
+
+
+-- name: UpdateCustomerPJSensitiveData :exec
+UPDATE customerf_pj
+SET cnpj = $2, cnpj_index = $3
+WHERE customer_id = $1;
+
+
 -- name: DeleteAddress :exec
 DELETE FROM addresses
 WHERE id = $1;
@@ -194,6 +291,18 @@ WHERE id = $1;
 DELETE FROM customers
 WHERE id = $1;
 
+-- name: DeleteCustomerAddresses :exec
+DELETE FROM addresses
+WHERE customer_id = $1;
+
+-- name: DeleteCustomerPF :exec
+DELETE FROM customerf_pf
+WHERE customer_id = $1;
+
+-- name: DeleteCustomerPJ :exec
+DELETE FROM customerf_pj
+WHERE customer_id = $1;
+
 
 
 
@@ -0,0 +1,37 @@
+-- name: CreateCustomerNote :one
+WITH note AS (
+    INSERT INTO customer_notes (customer_id, author_id, body)
+    VALUES ($1, $2, $3)
+    RETURNING id, customer_id, author_id, body, created_at
+)
+SELECT note.id, note.customer_id, note.author_id, u.name AS author_name, note.body, note.created_at
+FROM note
+LEFT JOIN users u ON u.id = note.author_id;
+
+
+-- name: ListNotesByCustomerIDs :many
+SELECT n.id, n.customer_id, n.author_id, u.name AS author_name, n.body, n.created_at
+FROM customer_notes n
+LEFT JOIN users u ON u.id = n.author_id
+WHERE n.customer_id = ANY(@customer_ids::uuid[])
+ORDER BY n.customer_id, n.created_at DESC, n.id DESC;
+
+
+-- name: ListLatestNotesByCustomerIDs :many
+SELECT DISTINCT ON (n.customer_id)
+    n.id, n.customer_id, n.author_id, u.name AS author_name, n.body, n.created_at,
+    COUNT(*) OVER (PARTITION BY n.customer_id) AS note_count
+FROM customer_notes n
+LEFT JOIN users u ON u.id = n.author_id
+WHERE n.customer_id = ANY(@customer_ids::uuid[])
+ORDER BY n.customer_id, n.created_at DESC, n.id DESC;
+
+
+-- name: DeleteCustomerNote :execrows
+DELETE FROM customer_notes
+WHERE id = $1 AND customer_id = $2;
+
+
+-- name: DeleteCustomerNotes :execrows
+DELETE FROM customer_notes
+WHERE customer_id = $1;
@@ -0,0 +1,3 @@
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE expiry < NOW();
@@ -0,0 +1,16 @@
+-- name: AddCustomerTags :exec
+INSERT INTO customer_tags (customer_id, tag)
+SELECT @customer_id, UNNEST(@tags::text[])
+ON CONFLICT DO NOTHING;
+
+
+-- name: ListTagsByCustomerIDs :many
+SELECT customer_id, tag, created_at
+FROM customer_tags
+WHERE customer_id = ANY(@customer_ids::uuid[])
+ORDER BY customer_id, tag;
+
+
+-- name: DeleteCustomerTags :execrows
+DELETE FROM customer_tags
+WHERE customer_id = $1;
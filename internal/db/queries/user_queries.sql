@@ -34,3 +34,24 @@ WHERE id = $1;
 
 
 
+-- name: ListUsers :many
+SELECT
+    id,
+    name,
+    email,
+    is_admin,
+    created_at
+FROM users
+ORDER BY created_at, email;
+
+-- name: UpdateUserPassword :one
+UPDATE users
+SET password = $2, updated_at = NOW()
+WHERE email = $1
+RETURNING id;
+
+-- name: SetUserAdmin :one
+UPDATE users
+SET is_admin = $2, updated_at = NOW()
+WHERE email = $1
+RETURNING id;
@@ -54,15 +54,74 @@ func (q *Queries) AddAddressToCustomer(ctx context.Context, arg AddAddressToCust
 	return id, err
 }
 
+const anonymizeCustomer = `-- name: AnonymizeCustomer :exec
+UPDATE customers
+SET
+    email = 'anonymized-' || id || '@anonymized.invalid',
+    phone = 'anonymized-' || id,
+    custom_fields = '{}',
+    is_active = FALSE,
+    anonymized_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) AnonymizeCustomer(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, anonymizeCustomer, id)
+	return err
+}
+
+const anonymizeCustomerPF = `-- name: AnonymizeCustomerPF :exec
+UPDATE customerf_pf
+SET
+    cpf = $1,
+    cpf_index = NULL,
+    name = 'Anonymized',
+    birth_date = $2,
+    updated_at = NOW()
+WHERE customer_id = $3
+`
+
+type AnonymizeCustomerPFParams struct {
+	Cpf        string    `json:"cpf"`
+	BirthDate  string    `json:"birth_date"`
+	CustomerID uuid.UUID `json:"customer_id"`
+}
+
+func (q *Queries) AnonymizeCustomerPF(ctx context.Context, arg AnonymizeCustomerPFParams) error {
+	_, err := q.db.Exec(ctx, anonymizeCustomerPF, arg.Cpf, arg.BirthDate, arg.CustomerID)
+	return err
+}
+
+const anonymizeCustomerPJ = `-- name: AnonymizeCustomerPJ :exec
+UPDATE customerf_pj
+SET
+    cnpj = $1,
+    cnpj_index = NULL,
+    company_name = 'Anonymized',
+    updated_at = NOW()
+WHERE customer_id = $2
+`
+
+type AnonymizeCustomerPJParams struct {
+	Cnpj       string    `json:"cnpj"`
+	CustomerID uuid.UUID `json:"customer_id"`
+}
+
+func (q *Queries) AnonymizeCustomerPJ(ctx context.Context, arg AnonymizeCustomerPJParams) error {
+	_, err := q.db.Exec(ctx, anonymizeCustomerPJ, arg.Cnpj, arg.CustomerID)
+	return err
+}
+
 const createCustomerPF = `-- name: CreateCustomerPF :one
 WITH new_customer AS (
-    INSERT INTO customers (type, email, phone)
-    VALUES ($1, $2, $3)
+    INSERT INTO customers (id, type, email, phone)
+    VALUES ($1, $2, $3, $4)
     RETURNING id
 ),
 customer_pf AS (
-    INSERT INTO customerf_pf (customer_id, cpf, name, birth_date)
-    SELECT id, $4, $5, $6
+    INSERT INTO customerf_pf (customer_id, cpf, cpf_index, name, birth_date)
+    SELECT id, $5, $6, $7, $8
     FROM new_customer
     RETURNING customer_id
 )
@@ -78,18 +137,20 @@ INSERT INTO addresses (
 )
 SELECT 
     customer_id,
-    $7, $8, $9, $10, $11, $12, $13
+    $9, $10, $11, $12, $13, $14, $15
 FROM customer_pf
 RETURNING customer_id
 `
 
 type CreateCustomerPFParams struct {
+	ID          uuid.UUID    `json:"id"`
 	Type        CustomerType `json:"type"`
 	Email       string       `json:"email"`
 	Phone       string       `json:"phone"`
 	Cpf         string       `json:"cpf"`
+	CpfIndex    pgtype.Text  `json:"cpf_index"`
 	Name        string       `json:"name"`
-	BirthDate   pgtype.Date  `json:"birth_date"`
+	BirthDate   string       `json:"birth_date"`
 	AddressType string       `json:"address_type"`
 	Street      string       `json:"street"`
 	Number      string       `json:"number"`
@@ -101,10 +162,12 @@ type CreateCustomerPFParams struct {
 
 func (q *Queries) CreateCustomerPF(ctx context.Context, arg CreateCustomerPFParams) (uuid.UUID, error) {
 	row := q.db.QueryRow(ctx, createCustomerPF,
+		arg.ID,
 		arg.Type,
 		arg.Email,
 		arg.Phone,
 		arg.Cpf,
+		arg.CpfIndex,
 		arg.Name,
 		arg.BirthDate,
 		arg.AddressType,
@@ -122,13 +185,13 @@ func (q *Queries) CreateCustomerPF(ctx context.Context, arg CreateCustomerPFPara
 
 const createCustomerPJ = `-- name: CreateCustomerPJ :one
 WITH new_customer AS (
-    INSERT INTO customers (type, email, phone)
-    VALUES ($1, $2, $3)
+    INSERT INTO customers (id, type, email, phone)
+    VALUES ($1, $2, $3, $4)
     RETURNING id
 ),
 customer_pj AS (
-    INSERT INTO customerf_pj (customer_id, cnpj, company_name)
-    SELECT id, $4, $5
+    INSERT INTO customerf_pj (customer_id, cnpj, cnpj_index, company_name)
+    SELECT id, $5, $6, $7
     FROM new_customer
     RETURNING customer_id
 )
@@ -144,16 +207,18 @@ INSERT INTO addresses (
 )
 SELECT 
     customer_id,
-    $6, $7, $8, $9, $10, $11, $12
+    $8, $9, $10, $11, $12, $13, $14
 FROM customer_pj
 RETURNING customer_id
 `
 
 type CreateCustomerPJParams struct {
+	ID          uuid.UUID    `json:"id"`
 	Type        CustomerType `json:"type"`
 	Email       string       `json:"email"`
 	Phone       string       `json:"phone"`
 	Cnpj        string       `json:"cnpj"`
+	CnpjIndex   pgtype.Text  `json:"cnpj_index"`
 	CompanyName string       `json:"company_name"`
 	AddressType string       `json:"address_type"`
 	Street      string       `json:"street"`
@@ -166,10 +231,12 @@ type CreateCustomerPJParams struct {
 
 func (q *Queries) CreateCustomerPJ(ctx context.Context, arg CreateCustomerPJParams) (uuid.UUID, error) {
 	row := q.db.QueryRow(ctx, createCustomerPJ,
+		arg.ID,
 		arg.Type,
 		arg.Email,
 		arg.Phone,
 		arg.Cnpj,
+		arg.CnpjIndex,
 		arg.CompanyName,
 		arg.AddressType,
 		arg.Street,
@@ -204,6 +271,36 @@ func (q *Queries) DeleteCustomer(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteCustomerAddresses = `-- name: DeleteCustomerAddresses :exec
+DELETE FROM addresses
+WHERE customer_id = $1
+`
+
+func (q *Queries) DeleteCustomerAddresses(ctx context.Context, customerID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCustomerAddresses, customerID)
+	return err
+}
+
+const deleteCustomerPF = `-- name: DeleteCustomerPF :exec
+DELETE FROM customerf_pf
+WHERE customer_id = $1
+`
+
+func (q *Queries) DeleteCustomerPF(ctx context.Context, customerID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCustomerPF, customerID)
+	return err
+}
+
+const deleteCustomerPJ = `-- name: DeleteCustomerPJ :exec
+DELETE FROM customerf_pj
+WHERE customer_id = $1
+`
+
+func (q *Queries) DeleteCustomerPJ(ctx context.Context, customerID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCustomerPJ, customerID)
+	return err
+}
+
 const getAllCustomers = `-- name: GetAllCustomers :many
 SELECT
     c.id AS customer_id,
@@ -212,6 +309,8 @@ SELECT
     c.created_at AS customer_created_at,
     c.updated_at AS customer_updated_at,
     c.is_active AS customer_is_active,
+    c.anonymized_at AS customer_anonymized_at,
+    c.custom_fields AS customer_custom_fields,
 
     pf.cpf AS pf_cpf,
     pf.name AS pf_name,
@@ -239,30 +338,48 @@ FROM customers c
 LEFT JOIN customerf_pf pf ON c.id = pf.customer_id
 LEFT JOIN customerf_pj pj ON c.id = pj.customer_id
 LEFT JOIN addresses a ON c.id = a.customer_id
+WHERE (COALESCE(CARDINALITY($1::text[]), 0) = 0
+    OR c.id IN (
+        SELECT t.customer_id
+        FROM customer_tags t
+        WHERE t.tag = ANY($1::text[])
+        GROUP BY t.customer_id
+        HAVING COUNT(*) = CARDINALITY($1::text[])
+    ))
+    AND c.id > $2::uuid
 GROUP BY 
-    c.id, c.email, c.phone, c.created_at, c.updated_at, c.is_active,
+    c.id, c.email, c.phone, c.created_at, c.updated_at, c.is_active, c.anonymized_at, c.custom_fields,
     pf.cpf, pf.name, pf.birth_date,
     pj.cnpj, pj.company_name
 ORDER BY c.id
+LIMIT $3
 `
 
+type GetAllCustomersParams struct {
+	Tags  []string    `json:"tags"`
+	After uuid.UUID   `json:"after"`
+	Limit pgtype.Int4 `json:"limit"`
+}
+
 type GetAllCustomersRow struct {
-	CustomerID        uuid.UUID          `json:"customer_id"`
-	CustomerEmail     string             `json:"customer_email"`
-	CustomerPhone     string             `json:"customer_phone"`
-	CustomerCreatedAt pgtype.Timestamptz `json:"customer_created_at"`
-	CustomerUpdatedAt pgtype.Timestamptz `json:"customer_updated_at"`
-	CustomerIsActive  bool               `json:"customer_is_active"`
-	PfCpf             pgtype.Text        `json:"pf_cpf"`
-	PfName            pgtype.Text        `json:"pf_name"`
-	PfBirthDate       pgtype.Date        `json:"pf_birth_date"`
-	PjCnpj            pgtype.Text        `json:"pj_cnpj"`
-	PjCompanyName     pgtype.Text        `json:"pj_company_name"`
-	Addresses         interface{}        `json:"addresses"`
-}
-
-func (q *Queries) GetAllCustomers(ctx context.Context) ([]GetAllCustomersRow, error) {
-	rows, err := q.db.Query(ctx, getAllCustomers)
+	CustomerID           uuid.UUID          `json:"customer_id"`
+	CustomerEmail        string             `json:"customer_email"`
+	CustomerPhone        string             `json:"customer_phone"`
+	CustomerCreatedAt    pgtype.Timestamptz `json:"customer_created_at"`
+	CustomerUpdatedAt    pgtype.Timestamptz `json:"customer_updated_at"`
+	CustomerIsActive     bool               `json:"customer_is_active"`
+	CustomerAnonymizedAt pgtype.Timestamptz `json:"customer_anonymized_at"`
+	CustomerCustomFields []byte             `json:"customer_custom_fields"`
+	PfCpf                pgtype.Text        `json:"pf_cpf"`
+	PfName               pgtype.Text        `json:"pf_name"`
+	PfBirthDate          pgtype.Text        `json:"pf_birth_date"`
+	PjCnpj               pgtype.Text        `json:"pj_cnpj"`
+	PjCompanyName        pgtype.Text        `json:"pj_company_name"`
+	Addresses            interface{}        `json:"addresses"`
+}
+
+func (q *Queries) GetAllCustomers(ctx context.Context, arg GetAllCustomersParams) ([]GetAllCustomersRow, error) {
+	rows, err := q.db.Query(ctx, getAllCustomers, arg.Tags, arg.After, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -277,6 +394,8 @@ func (q *Queries) GetAllCustomers(ctx context.Context) ([]GetAllCustomersRow, er
 			&i.CustomerCreatedAt,
 			&i.CustomerUpdatedAt,
 			&i.CustomerIsActive,
+			&i.CustomerAnonymizedAt,
+			&i.CustomerCustomFields,
 			&i.PfCpf,
 			&i.PfName,
 			&i.PfBirthDate,
@@ -357,6 +476,8 @@ SELECT
     c.is_active,
     c.created_at,
     c.updated_at,
+    c.anonymized_at,
+    c.custom_fields,
     CASE 
         WHEN c.type = 'PF' THEN pf.cpf
         ELSE NULL
@@ -384,18 +505,20 @@ WHERE c.id = $1
 `
 
 type GetCustomerByIDRow struct {
-	ID          uuid.UUID          `json:"id"`
-	Type        CustomerType       `json:"type"`
-	Email       string             `json:"email"`
-	Phone       string             `json:"phone"`
-	IsActive    bool               `json:"is_active"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	Cpf         interface{}        `json:"cpf"`
-	PfName      interface{}        `json:"pf_name"`
-	BirthDate   interface{}        `json:"birth_date"`
-	Cnpj        interface{}        `json:"cnpj"`
-	CompanyName interface{}        `json:"company_name"`
+	ID           uuid.UUID          `json:"id"`
+	Type         CustomerType       `json:"type"`
+	Email        string             `json:"email"`
+	Phone        string             `json:"phone"`
+	IsActive     bool               `json:"is_active"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	AnonymizedAt pgtype.Timestamptz `json:"anonymized_at"`
+	CustomFields []byte             `json:"custom_fields"`
+	Cpf          interface{}        `json:"cpf"`
+	PfName       interface{}        `json:"pf_name"`
+	BirthDate    interface{}        `json:"birth_date"`
+	Cnpj         interface{}        `json:"cnpj"`
+	CompanyName  interface{}        `json:"company_name"`
 }
 
 func (q *Queries) GetCustomerByID(ctx context.Context, id uuid.UUID) (GetCustomerByIDRow, error) {
@@ -409,6 +532,8 @@ func (q *Queries) GetCustomerByID(ctx context.Context, id uuid.UUID) (GetCustome
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AnonymizedAt,
+		&i.CustomFields,
 		&i.Cpf,
 		&i.PfName,
 		&i.BirthDate,
@@ -418,6 +543,150 @@ func (q *Queries) GetCustomerByID(ctx context.Context, id uuid.UUID) (GetCustome
 	return i, err
 }
 
+const getCustomerConflicts = `-- name: GetCustomerConflicts :one
+SELECT
+    EXISTS(SELECT 1 FROM customers c WHERE c.email = $1) AS email_exists,
+    EXISTS(SELECT 1 FROM customers c WHERE c.phone = $2) AS phone_exists,
+    EXISTS(SELECT 1 FROM customerf_pf pf WHERE pf.cpf_index = $3) AS cpf_exists,
+    EXISTS(SELECT 1 FROM customerf_pj pj WHERE pj.cnpj_index = $4) AS cnpj_exists
+`
+
+type GetCustomerConflictsParams struct {
+	Email     string      `json:"email"`
+	Phone     string      `json:"phone"`
+	CpfIndex  pgtype.Text `json:"cpf_index"`
+	CnpjIndex pgtype.Text `json:"cnpj_index"`
+}
+
+type GetCustomerConflictsRow struct {
+	EmailExists bool `json:"email_exists"`
+	PhoneExists bool `json:"phone_exists"`
+	CpfExists   bool `json:"cpf_exists"`
+	CnpjExists  bool `json:"cnpj_exists"`
+}
+
+func (q *Queries) GetCustomerConflicts(ctx context.Context, arg GetCustomerConflictsParams) (GetCustomerConflictsRow, error) {
+	row := q.db.QueryRow(ctx, getCustomerConflicts,
+		arg.Email,
+		arg.Phone,
+		arg.CpfIndex,
+		arg.CnpjIndex,
+	)
+	var i GetCustomerConflictsRow
+	err := row.Scan(
+		&i.EmailExists,
+		&i.PhoneExists,
+		&i.CpfExists,
+		&i.CnpjExists,
+	)
+	return i, err
+}
+
+const getCustomerIDByCnpjIndex = `-- name: GetCustomerIDByCnpjIndex :one
+SELECT customer_id
+FROM customerf_pj
+WHERE cnpj_index = $1
+`
+
+func (q *Queries) GetCustomerIDByCnpjIndex(ctx context.Context, cnpjIndex pgtype.Text) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getCustomerIDByCnpjIndex, cnpjIndex)
+	var customer_id uuid.UUID
+	err := row.Scan(&customer_id)
+	return customer_id, err
+}
+
+const getCustomerIDByCpfIndex = `-- name: GetCustomerIDByCpfIndex :one
+SELECT customer_id
+FROM customerf_pf
+WHERE cpf_index = $1
+`
+
+func (q *Queries) GetCustomerIDByCpfIndex(ctx context.Context, cpfIndex pgtype.Text) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getCustomerIDByCpfIndex, cpfIndex)
+	var customer_id uuid.UUID
+	err := row.Scan(&customer_id)
+	return customer_id, err
+}
+
+const listCustomerPFSensitiveData = `-- name: ListCustomerPFSensitiveData :many
+SELECT pf.customer_id, pf.cpf, pf.birth_date
+FROM customerf_pf pf
+JOIN customers c ON c.id = pf.customer_id
+WHERE c.anonymized_at IS NULL AND pf.customer_id > $1::uuid
+ORDER BY pf.customer_id
+LIMIT $2
+`
+
+type ListCustomerPFSensitiveDataParams struct {
+	After     uuid.UUID `json:"after"`
+	BatchSize int32     `json:"batch_size"`
+}
+
+type ListCustomerPFSensitiveDataRow struct {
+	CustomerID uuid.UUID `json:"customer_id"`
+	Cpf        string    `json:"cpf"`
+	BirthDate  string    `json:"birth_date"`
+}
+
+func (q *Queries) ListCustomerPFSensitiveData(ctx context.Context, arg ListCustomerPFSensitiveDataParams) ([]ListCustomerPFSensitiveDataRow, error) {
+	rows, err := q.db.Query(ctx, listCustomerPFSensitiveData, arg.After, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCustomerPFSensitiveDataRow
+	for rows.Next() {
+		var i ListCustomerPFSensitiveDataRow
+		if err := rows.Scan(&i.CustomerID, &i.Cpf, &i.BirthDate); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomerPJSensitiveData = `-- name: ListCustomerPJSensitiveData :many
+SELECT pj.customer_id, pj.cnpj
+FROM customerf_pj pj
+JOIN customers c ON c.id = pj.customer_id
+WHERE c.anonymized_at IS NULL AND pj.customer_id > $1::uuid
+ORDER BY pj.customer_id
+LIMIT $2
+`
+
+type ListCustomerPJSensitiveDataParams struct {
+	After     uuid.UUID `json:"after"`
+	BatchSize int32     `json:"batch_size"`
+}
+
+type ListCustomerPJSensitiveDataRow struct {
+	CustomerID uuid.UUID `json:"customer_id"`
+	Cnpj       string    `json:"cnpj"`
+}
+
+func (q *Queries) ListCustomerPJSensitiveData(ctx context.Context, arg ListCustomerPJSensitiveDataParams) ([]ListCustomerPJSensitiveDataRow, error) {
+	rows, err := q.db.Query(ctx, listCustomerPJSensitiveData, arg.After, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCustomerPJSensitiveDataRow
+	for rows.Next() {
+		var i ListCustomerPJSensitiveDataRow
+		if err := rows.Scan(&i.CustomerID, &i.Cnpj); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateAddress = `-- name: UpdateAddress :one
 UPDATE addresses
 SET 
@@ -478,3 +747,43 @@ func (q *Queries) UpdateCustomerBasicInfo(ctx context.Context, arg UpdateCustome
 	err := row.Scan(&id)
 	return id, err
 }
+
+const updateCustomerPFSensitiveData = `-- name: UpdateCustomerPFSensitiveData :exec
+UPDATE customerf_pf
+SET cpf = $2, cpf_index = $3, birth_date = $4
+WHERE customer_id = $1
+`
+
+type UpdateCustomerPFSensitiveDataParams struct {
+	CustomerID uuid.UUID   `json:"customer_id"`
+	Cpf        string      `json:"cpf"`
+	CpfIndex   pgtype.Text `json:"cpf_index"`
+	BirthDate  string      `json:"birth_date"`
+}
+
+func (q *Queries) UpdateCustomerPFSensitiveData(ctx context.Context, arg UpdateCustomerPFSensitiveDataParams) error {
+	_, err := q.db.Exec(ctx, updateCustomerPFSensitiveData,
+		arg.CustomerID,
+		arg.Cpf,
+		arg.CpfIndex,
+		arg.BirthDate,
+	)
+	return err
+}
+
+const updateCustomerPJSensitiveData = `-- name: UpdateCustomerPJSensitiveData :exec
+UPDATE customerf_pj
+SET cnpj = $2, cnpj_index = $3
+WHERE customer_id = $1
+`
+
+type UpdateCustomerPJSensitiveDataParams struct {
+	CustomerID uuid.UUID   `json:"customer_id"`
+	Cnpj       string      `json:"cnpj"`
+	CnpjIndex  pgtype.Text `json:"cnpj_index"`
+}
+
+func (q *Queries) UpdateCustomerPJSensitiveData(ctx context.Context, arg UpdateCustomerPJSensitiveDataParams) error {
+	_, err := q.db.Exec(ctx, updateCustomerPJSensitiveData, arg.CustomerID, arg.Cnpj, arg.CnpjIndex)
+	return err
+}
@@ -12,6 +12,50 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText   CustomFieldType = "text"
+	CustomFieldTypeNumber CustomFieldType = "number"
+	CustomFieldTypeDate   CustomFieldType = "date"
+	CustomFieldTypeSelect CustomFieldType = "select"
+)
+
+func (e *CustomFieldType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CustomFieldType(s)
+	case string:
+		*e = CustomFieldType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CustomFieldType: %T", src)
+	}
+	return nil
+}
+
+type NullCustomFieldType struct {
+	CustomFieldType CustomFieldType `json:"custom_field_type"`
+	Valid           bool            `json:"valid"` // Valid is true if CustomFieldType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCustomFieldType) Scan(value interface{}) error {
+	if value == nil {
+		ns.CustomFieldType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CustomFieldType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCustomFieldType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CustomFieldType), nil
+}
+
 type CustomerType string
 
 const (
@@ -66,23 +110,72 @@ type Address struct {
 	Cep         string      `json:"cep"`
 }
 
-type Customer struct {
-	ID        uuid.UUID          `json:"id"`
-	Type      CustomerType       `json:"type"`
-	Email     string             `json:"email"`
-	Phone     string             `json:"phone"`
+type AuditEvent struct {
+	ID         int64              `json:"id"`
+	ActorID    pgtype.UUID        `json:"actor_id"`
+	Action     string             `json:"action"`
+	EntityType string             `json:"entity_type"`
+	EntityID   string             `json:"entity_id"`
+	Details    []byte             `json:"details"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type Contact struct {
+	ID         int32              `json:"id"`
+	CustomerID uuid.UUID          `json:"customer_id"`
+	Name       string             `json:"name"`
+	Role       pgtype.Text        `json:"role"`
+	Emails     []string           `json:"emails"`
+	Phones     []string           `json:"phones"`
+	Whatsapp   bool               `json:"whatsapp"`
+	IsPrimary  bool               `json:"is_primary"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CustomField struct {
+	ID        int32              `json:"id"`
+	Key       string             `json:"key"`
+	Label     string             `json:"label"`
+	Type      CustomFieldType    `json:"type"`
+	Options   []string           `json:"options"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	IsActive  bool               `json:"is_active"`
+}
+
+type Customer struct {
+	ID           uuid.UUID          `json:"id"`
+	Type         CustomerType       `json:"type"`
+	Email        string             `json:"email"`
+	Phone        string             `json:"phone"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	IsActive     bool               `json:"is_active"`
+	AnonymizedAt pgtype.Timestamptz `json:"anonymized_at"`
+	CustomFields []byte             `json:"custom_fields"`
+}
+
+type CustomerNote struct {
+	ID         int32              `json:"id"`
+	CustomerID uuid.UUID          `json:"customer_id"`
+	AuthorID   pgtype.UUID        `json:"author_id"`
+	Body       string             `json:"body"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type CustomerTag struct {
+	CustomerID uuid.UUID          `json:"customer_id"`
+	Tag        string             `json:"tag"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
 type CustomerfPf struct {
 	CustomerID uuid.UUID          `json:"customer_id"`
 	Cpf        string             `json:"cpf"`
 	Name       string             `json:"name"`
-	BirthDate  pgtype.Date        `json:"birth_date"`
+	BirthDate  string             `json:"birth_date"`
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	CpfIndex   pgtype.Text        `json:"cpf_index"`
 }
 
 type CustomerfPj struct {
@@ -91,6 +184,7 @@ type CustomerfPj struct {
 	CompanyName string             `json:"company_name"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	CnpjIndex   pgtype.Text        `json:"cnpj_index"`
 }
 
 type Service struct {
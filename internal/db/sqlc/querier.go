@@ -8,31 +8,71 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
 	AddAddressToCustomer(ctx context.Context, arg AddAddressToCustomerParams) (int32, error)
+	AddCustomerTags(ctx context.Context, arg AddCustomerTagsParams) error
+	AnonymizeCustomer(ctx context.Context, id uuid.UUID) error
+	AnonymizeCustomerPF(ctx context.Context, arg AnonymizeCustomerPFParams) error
+	AnonymizeCustomerPJ(ctx context.Context, arg AnonymizeCustomerPJParams) error
 	CheckIfUserIsAdmin(ctx context.Context, id uuid.UUID) (bool, error)
+	ClearPrimaryContact(ctx context.Context, customerID uuid.UUID) error
 	CountServicesByCustomerID(ctx context.Context, customerID uuid.UUID) (int64, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (int64, error)
+	CreateContact(ctx context.Context, arg CreateContactParams) (Contact, error)
+	CreateCustomField(ctx context.Context, arg CreateCustomFieldParams) (CustomField, error)
+	CreateCustomerNote(ctx context.Context, arg CreateCustomerNoteParams) (CreateCustomerNoteRow, error)
 	CreateCustomerPF(ctx context.Context, arg CreateCustomerPFParams) (uuid.UUID, error)
 	CreateCustomerPJ(ctx context.Context, arg CreateCustomerPJParams) (uuid.UUID, error)
 	CreateService(ctx context.Context, arg CreateServiceParams) (int32, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (uuid.UUID, error)
 	DeleteAddress(ctx context.Context, id int32) error
+	DeleteContact(ctx context.Context, arg DeleteContactParams) (int64, error)
+	DeleteCustomField(ctx context.Context, key string) (int64, error)
 	DeleteCustomer(ctx context.Context, id uuid.UUID) error
+	DeleteCustomerAddresses(ctx context.Context, customerID uuid.UUID) error
+	DeleteCustomerContacts(ctx context.Context, customerID uuid.UUID) (int64, error)
+	DeleteCustomerNote(ctx context.Context, arg DeleteCustomerNoteParams) (int64, error)
+	DeleteCustomerNotes(ctx context.Context, customerID uuid.UUID) (int64, error)
+	DeleteCustomerPF(ctx context.Context, customerID uuid.UUID) error
+	DeleteCustomerPJ(ctx context.Context, customerID uuid.UUID) error
+	DeleteCustomerTags(ctx context.Context, customerID uuid.UUID) (int64, error)
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
 	DeleteService(ctx context.Context, id int32) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
-	GetAllCustomers(ctx context.Context) ([]GetAllCustomersRow, error)
+	GetAllCustomers(ctx context.Context, arg GetAllCustomersParams) ([]GetAllCustomersRow, error)
 	GetCustomerAddresses(ctx context.Context, customerID uuid.UUID) ([]GetCustomerAddressesRow, error)
 	GetCustomerByID(ctx context.Context, id uuid.UUID) (GetCustomerByIDRow, error)
+	GetCustomerConflicts(ctx context.Context, arg GetCustomerConflictsParams) (GetCustomerConflictsRow, error)
+	GetCustomerIDByCnpjIndex(ctx context.Context, cnpjIndex pgtype.Text) (uuid.UUID, error)
+	GetCustomerIDByCpfIndex(ctx context.Context, cpfIndex pgtype.Text) (uuid.UUID, error)
 	GetServicesByCustomerID(ctx context.Context, customerID uuid.UUID) ([]Service, error)
 	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
 	ListAllServices(ctx context.Context) ([]Service, error)
+	ListAuditEventsByEntity(ctx context.Context, arg ListAuditEventsByEntityParams) ([]AuditEvent, error)
+	ListContactsByCustomerIDs(ctx context.Context, customerIds []uuid.UUID) ([]Contact, error)
+	ListCustomFields(ctx context.Context) ([]CustomField, error)
+	ListCustomerContacts(ctx context.Context, customerID uuid.UUID) ([]Contact, error)
+	ListCustomerPFSensitiveData(ctx context.Context, arg ListCustomerPFSensitiveDataParams) ([]ListCustomerPFSensitiveDataRow, error)
+	ListCustomerPJSensitiveData(ctx context.Context, arg ListCustomerPJSensitiveDataParams) ([]ListCustomerPJSensitiveDataRow, error)
+	ListLatestNotesByCustomerIDs(ctx context.Context, customerIds []uuid.UUID) ([]ListLatestNotesByCustomerIDsRow, error)
+	ListNotesByCustomerIDs(ctx context.Context, customerIds []uuid.UUID) ([]ListNotesByCustomerIDsRow, error)
+	ListTagsByCustomerIDs(ctx context.Context, customerIds []uuid.UUID) ([]CustomerTag, error)
+	ListUsers(ctx context.Context) ([]ListUsersRow, error)
+	RemoveCustomFieldValues(ctx context.Context, key string) error
+	SetCustomerCustomFields(ctx context.Context, arg SetCustomerCustomFieldsParams) (int64, error)
+	SetUserAdmin(ctx context.Context, arg SetUserAdminParams) (uuid.UUID, error)
 	UpdateAddress(ctx context.Context, arg UpdateAddressParams) (int32, error)
+	UpdateContact(ctx context.Context, arg UpdateContactParams) (Contact, error)
 	UpdateCustomerBasicInfo(ctx context.Context, arg UpdateCustomerBasicInfoParams) (uuid.UUID, error)
+	UpdateCustomerPFSensitiveData(ctx context.Context, arg UpdateCustomerPFSensitiveDataParams) error
+	UpdateCustomerPJSensitiveData(ctx context.Context, arg UpdateCustomerPJSensitiveDataParams) error
 	UpdateServiceFinishStatus(ctx context.Context, arg UpdateServiceFinishStatusParams) (Service, error)
 	UpdateServicePaymentStatus(ctx context.Context, arg UpdateServicePaymentStatusParams) (Service, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateUserRow, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (uuid.UUID, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,225 @@
+// Package fieldcrypt encrypts individual database columns with envelope
+// encryption. Every value is sealed with its own random data key (DEK) using
+// AES-256-GCM, and the DEK is stored next to the ciphertext wrapped by a
+// key-encryption key (KEK) from a KeyProvider. Rotating the KEK therefore only
+// re-wraps DEKs; the data itself is never re-encrypted.
+//
+// Every value is bound to where it is stored through GCM additional data
+// (for example the row id and column name), so a ciphertext copied to another
+// row or column fails to decrypt.
+//
+// Encrypted values cannot be compared in SQL, so exact-match lookups and
+// UNIQUE constraints use blind indexes: keyed HMACs of the plaintext stored in
+// a separate column.
+package fieldcrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The prefixes mark encrypted values, telling them apart from rows written
+// before encryption was enabled. The full format is
+// enc:v2:<key id>:<base64 wrapped DEK>:<base64 nonce+ciphertext>. v1 values
+// were sealed without additional data; they are still read, and Rewrap
+// upgrades them.
+const (
+	prefixV1 = "enc:v1:"
+	prefixV2 = "enc:v2:"
+)
+
+const keySize = 32
+
+var (
+	ErrMalformed  = errors.New("fieldcrypt: malformed encrypted value")
+	ErrUnknownKey = errors.New("fieldcrypt: unknown key")
+)
+
+// Cipher encrypts and decrypts column values and computes their blind
+// indexes. It is safe for concurrent use.
+type Cipher struct {
+	keys KeyProvider
+}
+
+func New(keys KeyProvider) *Cipher {
+	return &Cipher{keys: keys}
+}
+
+// IsEncrypted reports whether value was produced by Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefixV2) || strings.HasPrefix(value, prefixV1)
+}
+
+// Encrypt seals plaintext under a fresh DEK wrapped with the active KEK. ad is
+// authenticated but not stored: the same ad must be passed to Decrypt.
+func (c *Cipher) Encrypt(ctx context.Context, plaintext, ad string) (string, error) {
+	dek := make([]byte, keySize)
+	if _, err := rand.Read(dek); err != nil {
+		return "", err
+	}
+	data, err := seal(dek, []byte(plaintext), []byte(ad))
+	if err != nil {
+		return "", err
+	}
+
+	keyID := c.keys.ActiveKeyID()
+	wrapped, err := c.keys.WrapKey(ctx, keyID, dek)
+	if err != nil {
+		return "", err
+	}
+	return envelope{version: prefixV2, keyID: keyID, wrappedKey: wrapped, data: data}.String(), nil
+}
+
+// Decrypt returns the plaintext of value, which must have been encrypted with
+// the same ad. Values that are not encrypted are returned unchanged, so rows
+// written before encryption was enabled (or anonymized placeholders) can
+// still be read.
+func (c *Cipher) Decrypt(ctx context.Context, value, ad string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	env, err := parseEnvelope(value)
+	if err != nil {
+		return "", err
+	}
+
+	dek, err := c.keys.UnwrapKey(ctx, env.keyID, env.wrappedKey)
+	if err != nil {
+		return "", err
+	}
+	var additional []byte
+	if env.version == prefixV2 {
+		additional = []byte(ad)
+	}
+	plaintext, err := open(dek, env.data, additional)
+	if err != nil {
+		return "", fmt.Errorf("fieldcrypt: decrypting value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// Rewrap makes value use the active KEK: its DEK is unwrapped with the old
+// key and wrapped again. Plaintext and v1 values are encrypted again with ad.
+// changed is false when value was already current.
+func (c *Cipher) Rewrap(ctx context.Context, value, ad string) (rewrapped string, changed bool, err error) {
+	if !IsEncrypted(value) {
+		rewrapped, err = c.Encrypt(ctx, value, ad)
+		return rewrapped, err == nil, err
+	}
+	env, err := parseEnvelope(value)
+	if err != nil {
+		return "", false, err
+	}
+	if env.version == prefixV1 {
+		plaintext, err := c.Decrypt(ctx, value, ad)
+		if err != nil {
+			return "", false, err
+		}
+		rewrapped, err = c.Encrypt(ctx, plaintext, ad)
+		return rewrapped, err == nil, err
+	}
+	active := c.keys.ActiveKeyID()
+	if env.keyID == active {
+		return value, false, nil
+	}
+
+	dek, err := c.keys.UnwrapKey(ctx, env.keyID, env.wrappedKey)
+	if err != nil {
+		return "", false, err
+	}
+	if env.wrappedKey, err = c.keys.WrapKey(ctx, active, dek); err != nil {
+		return "", false, err
+	}
+	env.keyID = active
+	return env.String(), true, nil
+}
+
+// BlindIndex returns a deterministic HMAC of value for exact-match lookups.
+// field separates the indexes of different columns, so equal values in two
+// columns do not produce the same index.
+func (c *Cipher) BlindIndex(ctx context.Context, field, value string) (string, error) {
+	key, err := c.keys.IndexKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+type envelope struct {
+	version    string
+	keyID      string
+	wrappedKey []byte
+	data       []byte
+}
+
+func (e envelope) String() string {
+	return e.version + e.keyID +
+		":" + base64.RawStdEncoding.EncodeToString(e.wrappedKey) +
+		":" + base64.RawStdEncoding.EncodeToString(e.data)
+}
+
+func parseEnvelope(value string) (envelope, error) {
+	version := prefixV2
+	if strings.HasPrefix(value, prefixV1) {
+		version = prefixV1
+	}
+	parts := strings.Split(strings.TrimPrefix(value, version), ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return envelope{}, ErrMalformed
+	}
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return envelope{}, ErrMalformed
+	}
+	data, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return envelope{}, ErrMalformed
+	}
+	return envelope{version: version, keyID: parts[0], wrappedKey: wrapped, data: data}, nil
+}
+
+// seal encrypts plaintext with AES-GCM and prepends the random nonce.
+func seal(key, plaintext, ad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, ad), nil
+}
+
+func open(key, sealed, ad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, ad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
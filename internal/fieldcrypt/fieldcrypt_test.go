@@ -0,0 +1,187 @@
+package fieldcrypt_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/josevitorrodriguess/client-manager/internal/fieldcrypt"
+)
+
+func newCipher(t *testing.T, kf *fieldcrypt.KeyFile) *fieldcrypt.Cipher {
+	t.Helper()
+	p, err := fieldcrypt.NewLocalKeyProvider(kf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fieldcrypt.New(p)
+}
+
+func generate(t *testing.T, keyID string) *fieldcrypt.KeyFile {
+	t.Helper()
+	kf, err := fieldcrypt.GenerateKeyFile(keyID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kf
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	c := newCipher(t, generate(t, "k1"))
+	ctx := context.Background()
+
+	a, err := c.Encrypt(ctx, "123.456.789-09", "row-1")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	b, _ := c.Encrypt(ctx, "123.456.789-09", "row-1")
+	if !fieldcrypt.IsEncrypted(a) || strings.Contains(a, "123") {
+		t.Errorf("value does not look encrypted: %q", a)
+	}
+	if a == b {
+		t.Error("encrypting twice must not produce the same value")
+	}
+
+	got, err := c.Decrypt(ctx, a, "row-1")
+	if err != nil || got != "123.456.789-09" {
+		t.Fatalf("Decrypt = %q, %v", got, err)
+	}
+	if _, err := c.Decrypt(ctx, a, "row-2"); err == nil {
+		t.Error("a value moved to another row must not decrypt")
+	}
+
+	if got, _ := c.Decrypt(ctx, "12345678909", "row-1"); got != "12345678909" {
+		t.Errorf("plaintext values must pass through Decrypt, got %q", got)
+	}
+}
+
+func TestDecryptRejectsTamperedValues(t *testing.T) {
+	c := newCipher(t, generate(t, "k1"))
+	ctx := context.Background()
+
+	v, _ := c.Encrypt(ctx, "secret", "")
+	i := len(v) - 10
+	flipped := byte('A')
+	if v[i] == flipped {
+		flipped = 'B'
+	}
+	tampered := v[:i] + string(flipped) + v[i+1:]
+	if _, err := c.Decrypt(ctx, tampered, ""); err == nil {
+		t.Error("expected tampered ciphertext to fail")
+	}
+	if _, err := c.Decrypt(ctx, "enc:v1:k1:not-base64", ""); !errors.Is(err, fieldcrypt.ErrMalformed) {
+		t.Errorf("expected ErrMalformed, got %v", err)
+	}
+
+	other := newCipher(t, generate(t, "k2"))
+	if _, err := other.Decrypt(ctx, v, ""); !errors.Is(err, fieldcrypt.ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+}
+
+func TestRewrapAfterRotation(t *testing.T) {
+	ctx := context.Background()
+	kf := generate(t, "k1")
+	old, _ := newCipher(t, kf).Encrypt(ctx, "1990-05-10", "row-1")
+
+	if err := kf.AddKey("k2"); err != nil {
+		t.Fatal(err)
+	}
+	c := newCipher(t, kf)
+
+	rewrapped, changed, err := c.Rewrap(ctx, old, "row-1")
+	if err != nil || !changed || !strings.HasPrefix(rewrapped, "enc:v2:k2:") {
+		t.Fatalf("Rewrap = %q, %v, %v", rewrapped, changed, err)
+	}
+	if _, changed, _ := c.Rewrap(ctx, rewrapped, "row-1"); changed {
+		t.Error("values under the active key must not change")
+	}
+	if _, changed, _ := c.Rewrap(ctx, "legacy", "row-1"); !changed {
+		t.Error("plaintext values must be encrypted by Rewrap")
+	}
+
+	delete(kf.Keys, "k1")
+	if got, err := newCipher(t, kf).Decrypt(ctx, rewrapped, "row-1"); err != nil || got != "1990-05-10" {
+		t.Errorf("Decrypt without the old key = %q, %v", got, err)
+	}
+}
+
+// v1 values were sealed without additional data; an empty ad seals the same
+// way, so swapping the prefix stands in for one.
+func TestV1ValuesAreReadAndUpgraded(t *testing.T) {
+	ctx := context.Background()
+	c := newCipher(t, generate(t, "k1"))
+
+	v2, _ := c.Encrypt(ctx, "123.456.789-09", "")
+	v1 := "enc:v1:" + strings.TrimPrefix(v2, "enc:v2:")
+	if got, err := c.Decrypt(ctx, v1, "row-1"); err != nil || got != "123.456.789-09" {
+		t.Fatalf("Decrypt(v1) = %q, %v", got, err)
+	}
+
+	upgraded, changed, err := c.Rewrap(ctx, v1, "row-1")
+	if err != nil || !changed || !strings.HasPrefix(upgraded, "enc:v2:") {
+		t.Fatalf("Rewrap(v1) = %q, %v, %v", upgraded, changed, err)
+	}
+	if got, err := c.Decrypt(ctx, upgraded, "row-1"); err != nil || got != "123.456.789-09" {
+		t.Errorf("Decrypt after upgrade = %q, %v", got, err)
+	}
+	if _, err := c.Decrypt(ctx, upgraded, "row-2"); err == nil {
+		t.Error("an upgraded value must be bound to its row")
+	}
+}
+
+func TestBlindIndex(t *testing.T) {
+	ctx := context.Background()
+	kf := generate(t, "k1")
+	c := newCipher(t, kf)
+
+	a, _ := c.BlindIndex(ctx, "cpf", "12345678909")
+	b, _ := c.BlindIndex(ctx, "cpf", "12345678909")
+	if a == "" || a != b {
+		t.Errorf("blind index must be deterministic: %q %q", a, b)
+	}
+	if other, _ := c.BlindIndex(ctx, "cnpj", "12345678909"); other == a {
+		t.Error("blind indexes of different fields must differ")
+	}
+
+	if err := kf.AddKey("k2"); err != nil {
+		t.Fatal(err)
+	}
+	if rotated, _ := newCipher(t, kf).BlindIndex(ctx, "cpf", "12345678909"); rotated != a {
+		t.Error("rotating the KEK must not change blind indexes")
+	}
+}
+
+func TestKeyFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	kf := generate(t, "k1")
+	if err := kf.Write(path); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("key file permissions = %o, want 600", perm)
+	}
+
+	p, err := fieldcrypt.LoadKeyFile(path)
+	if err != nil {
+		t.Fatalf("LoadKeyFile: %v", err)
+	}
+	if p.ActiveKeyID() != "k1" {
+		t.Errorf("active key = %q", p.ActiveKeyID())
+	}
+
+	if err := os.WriteFile(path, []byte(`{"active_key":"k9","keys":{},"index_key":""}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fieldcrypt.LoadKeyFile(path); err == nil {
+		t.Error("expected an invalid key file to be rejected")
+	}
+}
@@ -0,0 +1,212 @@
+package fieldcrypt
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KeyProvider holds the key-encryption keys. A KMS-backed implementation
+// wraps and unwraps data keys remotely and never exposes the KEKs themselves.
+type KeyProvider interface {
+	// ActiveKeyID names the KEK that new data keys are wrapped with.
+	ActiveKeyID() string
+	WrapKey(ctx context.Context, keyID string, dek []byte) ([]byte, error)
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+	// IndexKey is the HMAC key for blind indexes. It is not rotated with the
+	// KEKs, since changing it invalidates every stored index.
+	IndexKey(ctx context.Context) ([]byte, error)
+}
+
+// KeyFile is the JSON document read by LocalKeyProvider. Keys are base64:
+//
+//	{"active_key": "k1", "keys": {"k1": "..."}, "index_key": "..."}
+//
+// Old keys must stay in the file until every value has been re-wrapped.
+type KeyFile struct {
+	ActiveKey string            `json:"active_key"`
+	Keys      map[string][]byte `json:"keys"`
+	IndexKey  []byte            `json:"index_key"`
+}
+
+// GenerateKeyFile returns a key file with a random KEK named keyID and a
+// random index key.
+func GenerateKeyFile(keyID string) (*KeyFile, error) {
+	index, err := randomKey()
+	if err != nil {
+		return nil, err
+	}
+	kf := &KeyFile{Keys: map[string][]byte{}, IndexKey: index}
+	if err := kf.AddKey(keyID); err != nil {
+		return nil, err
+	}
+	return kf, nil
+}
+
+func ReadKeyFile(path string) (*KeyFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fieldcrypt: reading key file: %w", err)
+	}
+	var kf KeyFile
+	if err := json.Unmarshal(b, &kf); err != nil {
+		return nil, fmt.Errorf("fieldcrypt: parsing key file %s: %w", path, err)
+	}
+	if err := kf.Validate(); err != nil {
+		return nil, err
+	}
+	return &kf, nil
+}
+
+// Write saves the key file with owner-only permissions, replacing path
+// atomically so a crash never leaves a truncated file behind.
+func (kf *KeyFile) Write(path string) error {
+	if err := kf.Validate(); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".keys-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(append(b, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// AddKey generates a new KEK and makes it the active one. Existing keys are
+// kept so values wrapped with them can still be read.
+func (kf *KeyFile) AddKey(keyID string) error {
+	if err := validKeyID(keyID); err != nil {
+		return err
+	}
+	if _, ok := kf.Keys[keyID]; ok {
+		return fmt.Errorf("fieldcrypt: key %q already exists", keyID)
+	}
+	key, err := randomKey()
+	if err != nil {
+		return err
+	}
+	if kf.Keys == nil {
+		kf.Keys = map[string][]byte{}
+	}
+	kf.Keys[keyID] = key
+	kf.ActiveKey = keyID
+	return nil
+}
+
+func (kf *KeyFile) Validate() error {
+	var errs []error
+	if _, ok := kf.Keys[kf.ActiveKey]; !ok {
+		errs = append(errs, fmt.Errorf("fieldcrypt: active key %q is not in the key file", kf.ActiveKey))
+	}
+	for id, key := range kf.Keys {
+		if err := validKeyID(id); err != nil {
+			errs = append(errs, err)
+		}
+		if len(key) != keySize {
+			errs = append(errs, fmt.Errorf("fieldcrypt: key %q must be %d bytes, got %d", id, keySize, len(key)))
+		}
+	}
+	if len(kf.IndexKey) != keySize {
+		errs = append(errs, fmt.Errorf("fieldcrypt: index key must be %d bytes, got %d", keySize, len(kf.IndexKey)))
+	}
+	return errors.Join(errs...)
+}
+
+// LocalKeyProvider keeps the KEKs in memory, loaded from a KeyFile. It is
+// meant for development, tests and single-host deploys.
+type LocalKeyProvider struct {
+	active string
+	keys   map[string][]byte
+	index  []byte
+}
+
+var _ KeyProvider = (*LocalKeyProvider)(nil)
+
+func NewLocalKeyProvider(kf *KeyFile) (*LocalKeyProvider, error) {
+	if err := kf.Validate(); err != nil {
+		return nil, err
+	}
+	return &LocalKeyProvider{
+		active: kf.ActiveKey,
+		keys:   maps.Clone(kf.Keys),
+		index:  kf.IndexKey,
+	}, nil
+}
+
+// LoadKeyFile reads path and returns a provider for its keys.
+func LoadKeyFile(path string) (*LocalKeyProvider, error) {
+	if path == "" {
+		return nil, errors.New("fieldcrypt: no key file configured")
+	}
+	kf, err := ReadKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalKeyProvider(kf)
+}
+
+func (p *LocalKeyProvider) ActiveKeyID() string {
+	return p.active
+}
+
+func (p *LocalKeyProvider) WrapKey(ctx context.Context, keyID string, dek []byte) ([]byte, error) {
+	kek, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+	return seal(kek, dek, nil)
+}
+
+func (p *LocalKeyProvider) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	kek, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+	dek, err := open(kek, wrapped, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fieldcrypt: unwrapping data key: %w", err)
+	}
+	return dek, nil
+}
+
+func (p *LocalKeyProvider) IndexKey(ctx context.Context) ([]byte, error) {
+	return p.index, nil
+}
+
+func validKeyID(id string) error {
+	if id == "" || strings.ContainsAny(id, ": \t\n") {
+		return fmt.Errorf("fieldcrypt: invalid key id %q", id)
+	}
+	return nil
+}
+
+func randomKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,172 @@
+package i18n
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// StatusText returns the title of an HTTP status in lang. English uses the
+// standard status text.
+func StatusText(lang string, status int) string {
+	if msg, ok := catalogFor(lang)["status."+strconv.Itoa(status)]; ok {
+		return msg
+	}
+	return http.StatusText(status)
+}
+
+// catalogs hold every message sent to clients, keyed by language. Keys are
+// grouped by prefix: status (problem titles), error (problem details), json
+// (request body decoding), validation (field rules, keyed by the
+// validators.Code* values), import (CSV import report) and message (success
+// responses).
+var catalogs = map[string]map[string]string{
+	En: {
+		"error.must_be_logged_in":      "must be logged in",
+		"error.forbidden":              "only admins can access this resource",
+		"error.invalid_session":        "invalid session data",
+		"error.invalid_credentials":    "invalid credentials",
+		"error.user_not_found":         "user not found",
+		"error.customer_not_found":     "customer not found",
+		"error.service_not_found":      "service not found",
+		"error.contact_not_found":      "contact not found",
+		"error.note_not_found":         "note not found",
+		"error.custom_field_not_found": "custom field not found",
+		"error.custom_field_exists":    "a custom field with this key already exists",
+		"error.duplicated_data":        "document, phone or email already registered",
+		"error.duplicated_user":        "username or email already exists",
+		"error.customer_anonymized":    "customer is already anonymized",
+		"error.customer_has_services":  "customer has services and cannot be deleted",
+		"error.validation_failed":      "request validation failed",
+		"error.internal":               "internal server error",
+		"error.route_not_found":        "route not found",
+		"error.method_not_allowed":     "method not allowed",
+		"error.invalid_customer_id":    "invalid customer id",
+		"error.invalid_service_id":     "invalid service id",
+		"error.invalid_contact_id":     "invalid contact id",
+		"error.invalid_note_id":        "invalid note id",
+		"error.service_id_required":    "id query parameter is required",
+		"error.document_required":      "cpf or cnpj query parameter is required",
+		"error.invalid_batch_size":     "batch_size must be a positive integer",
+		"error.invalid_limit":          "limit must be an integer between 1 and %d",
+		"error.invalid_cursor":         "after must be a customer id",
+		"error.import_file_required":   `multipart field "file" is required`,
+		"error.invalid_export_format":  "format must be json or zip",
+		"json.unsupported_media_type":  "content type must be application/json",
+		"json.body_too_large":          "request body is larger than %d bytes",
+		"json.empty_body":              "request body is empty",
+		"json.truncated_body":          "request body ends unexpectedly",
+		"json.syntax_error":            "malformed JSON at offset %d",
+		"json.multiple_values":         "request body must contain a single JSON value",
+		"json.invalid_value":           "invalid value in request body: %s",
+		"validation.required":          "is required",
+		"validation.length":            "must have between %d and %d characters",
+		"validation.format":            "has an invalid format",
+		"validation.email":             "must be a valid email address",
+		"validation.phone":             "must be a valid phone number",
+		"validation.cpf":               "must be a valid CPF",
+		"validation.cnpj":              "must be a valid CNPJ",
+		"validation.cep":               "must be a valid CEP",
+		"validation.one_of":            "must be one of %s",
+		"validation.money":             "must be a non-negative amount with at most 2 decimal places",
+		"validation.money_at_most":     "cannot be greater than %s",
+		"validation.date_range":        "must be between %s and %s",
+		"validation.date_format":       "must be a date in the format %s",
+		"validation.unknown_field":     "unknown field",
+		"validation.type":              "must be of type %s, got %s",
+		"validation.at_least_one":      "at least one of %s is required",
+		"validation.max_items":         "must have at most %d items",
+		"import.empty":                 "csv file has no customer rows",
+		"import.missing_type_column":   "csv header must contain a type column",
+		"import.invalid_csv":           "invalid csv on line %d",
+		"import.invalid_mode":          "invalid import mode %q",
+		"import.already_registered":    "%s already registered",
+		"import.duplicate_in_file":     "%s already used on line %d",
+		"import.insert_failed":         "failed to insert customer",
+		"message.logged_in":            "logged in successfully",
+		"message.logged_out":           "logged out successfully",
+		"message.customer_deleted":     "customer deleted successfully",
+		"message.service_deleted":      "service deleted successfully",
+		"message.contact_deleted":      "contact deleted successfully",
+		"message.note_deleted":         "note deleted successfully",
+		"message.custom_field_deleted": "custom field deleted successfully",
+	},
+	PtBR: {
+		"status.400":                   "Requisição inválida",
+		"status.401":                   "Não autenticado",
+		"status.403":                   "Acesso negado",
+		"status.404":                   "Não encontrado",
+		"status.405":                   "Método não permitido",
+		"status.409":                   "Conflito",
+		"status.413":                   "Corpo da requisição muito grande",
+		"status.415":                   "Tipo de conteúdo não suportado",
+		"status.500":                   "Erro interno do servidor",
+		"error.must_be_logged_in":      "é preciso estar logado",
+		"error.forbidden":              "apenas administradores podem acessar este recurso",
+		"error.invalid_session":        "dados de sessão inválidos",
+		"error.invalid_credentials":    "credenciais inválidas",
+		"error.user_not_found":         "usuário não encontrado",
+		"error.customer_not_found":     "cliente não encontrado",
+		"error.service_not_found":      "serviço não encontrado",
+		"error.contact_not_found":      "contato não encontrado",
+		"error.note_not_found":         "nota não encontrada",
+		"error.custom_field_not_found": "campo personalizado não encontrado",
+		"error.custom_field_exists":    "já existe um campo personalizado com esta chave",
+		"error.duplicated_data":        "documento, telefone ou email já cadastrado",
+		"error.duplicated_user":        "nome de usuário ou email já cadastrado",
+		"error.customer_anonymized":    "o cliente já foi anonimizado",
+		"error.customer_has_services":  "o cliente tem serviços e não pode ser excluído",
+		"error.validation_failed":      "a validação da requisição falhou",
+		"error.internal":               "erro interno do servidor",
+		"error.route_not_found":        "rota não encontrada",
+		"error.method_not_allowed":     "método não permitido",
+		"error.invalid_customer_id":    "id de cliente inválido",
+		"error.invalid_service_id":     "id de serviço inválido",
+		"error.invalid_contact_id":     "id de contato inválido",
+		"error.invalid_note_id":        "id de nota inválido",
+		"error.service_id_required":    "o parâmetro id é obrigatório",
+		"error.document_required":      "informe o parâmetro cpf ou cnpj",
+		"error.invalid_batch_size":     "batch_size deve ser um inteiro positivo",
+		"error.invalid_limit":          "limit deve ser um inteiro entre 1 e %d",
+		"error.invalid_cursor":         "after deve ser o id de um cliente",
+		"error.import_file_required":   `o campo multipart "file" é obrigatório`,
+		"error.invalid_export_format":  "format deve ser json ou zip",
+		"json.unsupported_media_type":  "o content type deve ser application/json",
+		"json.body_too_large":          "o corpo da requisição passa de %d bytes",
+		"json.empty_body":              "o corpo da requisição está vazio",
+		"json.truncated_body":          "o corpo da requisição termina de forma inesperada",
+		"json.syntax_error":            "JSON malformado na posição %d",
+		"json.multiple_values":         "o corpo da requisição deve conter um único valor JSON",
+		"json.invalid_value":           "valor inválido no corpo da requisição: %s",
+		"validation.required":          "é obrigatório",
+		"validation.length":            "deve ter entre %d e %d caracteres",
+		"validation.format":            "tem formato inválido",
+		"validation.email":             "deve ser um email válido",
+		"validation.phone":             "deve ser um telefone válido",
+		"validation.cpf":               "deve ser um CPF válido",
+		"validation.cnpj":              "deve ser um CNPJ válido",
+		"validation.cep":               "deve ser um CEP válido",
+		"validation.one_of":            "deve ser um destes: %s",
+		"validation.money":             "deve ser um valor não negativo com no máximo 2 casas decimais",
+		"validation.money_at_most":     "não pode ser maior que %s",
+		"validation.date_range":        "deve estar entre %s e %s",
+		"validation.date_format":       "deve ser uma data no formato %s",
+		"validation.unknown_field":     "campo desconhecido",
+		"validation.type":              "deve ser do tipo %s, recebido %s",
+		"validation.at_least_one":      "informe ao menos um destes: %s",
+		"validation.max_items":         "deve ter no máximo %d itens",
+		"import.empty":                 "o arquivo csv não tem clientes",
+		"import.missing_type_column":   "o cabeçalho do csv deve ter a coluna type",
+		"import.invalid_csv":           "csv inválido na linha %d",
+		"import.invalid_mode":          "modo de importação inválido: %q",
+		"import.already_registered":    "%s já cadastrado",
+		"import.duplicate_in_file":     "%s já usado na linha %d",
+		"import.insert_failed":         "falha ao inserir o cliente",
+		"message.logged_in":            "login realizado com sucesso",
+		"message.logged_out":           "logout realizado com sucesso",
+		"message.customer_deleted":     "cliente excluído com sucesso",
+		"message.service_deleted":      "serviço excluído com sucesso",
+		"message.contact_deleted":      "contato excluído com sucesso",
+		"message.note_deleted":         "nota excluída com sucesso",
+		"message.custom_field_deleted": "campo personalizado excluído com sucesso",
+	},
+}
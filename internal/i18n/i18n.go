@@ -0,0 +1,155 @@
+// Package i18n translates the messages the API sends to clients. Languages
+// are negotiated from the Accept-Language header by Middleware and carried in
+// the request context.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	PtBR = "pt-BR"
+	En   = "en"
+
+	// Default is used when the client sends no Accept-Language header or
+	// accepts none of the supported languages.
+	Default = PtBR
+)
+
+// Supported lists the languages with a catalog, in order of preference.
+var Supported = []string{PtBR, En}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying lang.
+func NewContext(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+// FromContext returns the language negotiated for the request, or Default.
+func FromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(contextKey{}).(string); ok {
+		return lang
+	}
+	return Default
+}
+
+// Middleware negotiates the response language from Accept-Language, stores
+// it in the request context and announces it in Content-Language.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := Negotiate(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", lang)
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), lang)))
+	})
+}
+
+// Negotiate picks the supported language the client prefers. Tags are
+// matched exactly first and then by their base language, so "pt" and "pt-PT"
+// get pt-BR and "en-GB" gets en. Weights of 0 exclude a language.
+func Negotiate(acceptLanguage string) string {
+	type option struct {
+		tag    string
+		weight float64
+	}
+
+	var options []option
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			w, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = w
+		}
+		if weight > 0 {
+			options = append(options, option{tag: tag, weight: weight})
+		}
+	}
+	sort.SliceStable(options, func(i, j int) bool { return options[i].weight > options[j].weight })
+
+	for _, o := range options {
+		if o.tag == "*" {
+			return Default
+		}
+		if lang, ok := match(o.tag); ok {
+			return lang
+		}
+	}
+	return Default
+}
+
+func match(tag string) (string, bool) {
+	for _, lang := range Supported {
+		if strings.EqualFold(lang, tag) {
+			return lang, true
+		}
+	}
+	for _, lang := range Supported {
+		if strings.EqualFold(base(lang), base(tag)) {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+func base(tag string) string {
+	b, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	return b
+}
+
+// T returns the message for key in lang, falling back to English and then to
+// the key itself. args fill the placeholders of the message.
+func T(lang, key string, args ...any) string {
+	format, ok := catalogFor(lang)[key]
+	if !ok {
+		format, ok = catalogs[En][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+func catalogFor(lang string) map[string]string {
+	if lang, ok := match(lang); ok {
+		return catalogs[lang]
+	}
+	return catalogs[Default]
+}
+
+// Error is an error whose client-facing message is translated. Error()
+// renders it in English, for logs; Err is only used to match the error with
+// errors.Is and errors.As.
+type Error struct {
+	Err  error
+	Key  string
+	Args []any
+}
+
+func (e *Error) Error() string {
+	return T(En, e.Key, e.Args...)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Localize renders the message in lang.
+func (e *Error) Localize(lang string) string {
+	return T(lang, e.Key, e.Args...)
+}
@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", PtBR},
+		{"en", En},
+		{"en-GB,en;q=0.8", En},
+		{"pt-PT", PtBR},
+		{"fr-CA, en;q=0.5, pt;q=0.7", PtBR},
+		{"pt;q=0, en;q=0.1", En},
+		{"de, *;q=0.5", PtBR},
+		{"en;q=bad", PtBR},
+	}
+	for _, tt := range tests {
+		if got := Negotiate(tt.header); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(En, "json.body_too_large", 1024); got != "request body is larger than 1024 bytes" {
+		t.Errorf("en = %q", got)
+	}
+	if got := T("pt", "json.body_too_large", 1024); got != "o corpo da requisição passa de 1024 bytes" {
+		t.Errorf("pt = %q", got)
+	}
+	if got := T(PtBR, "missing.key"); got != "missing.key" {
+		t.Errorf("missing key = %q", got)
+	}
+	if got := StatusText(En, 404); got != "Not Found" {
+		t.Errorf("en title = %q", got)
+	}
+}
+
+// Every key must exist in every language, so no client gets a mix of
+// languages in one response.
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	for key := range catalogs[En] {
+		if _, ok := catalogs[PtBR][key]; !ok {
+			t.Errorf("%s missing in %s", key, PtBR)
+		}
+	}
+	for key := range catalogs[PtBR] {
+		if _, ok := catalogs[En][key]; !ok && !strings.HasPrefix(key, "status.") {
+			t.Errorf("%s missing in %s", key, En)
+		}
+	}
+}
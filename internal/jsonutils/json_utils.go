@@ -1,13 +1,42 @@
 package jsonutils
 
 import (
+	"encoding"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
+)
+
+// DefaultMaxBodyBytes limits request bodies when Decoder.MaxBytes is zero.
+const DefaultMaxBodyBytes = 1 << 20
+
+var (
+	ErrUnsupportedMediaType = errors.New("content type must be application/json")
+	ErrBodyTooLarge         = errors.New("request body is too large")
+	ErrInvalidJSON          = errors.New("invalid json body")
 )
 
+// Decoder holds the rules DecodeJson applies to request bodies. The zero
+// value limits bodies to DefaultMaxBodyBytes and rejects unknown fields.
+type Decoder struct {
+	MaxBytes           int64
+	AllowUnknownFields bool
+}
+
 func EncodeJson[T any](w http.ResponseWriter, r *http.Request, statusCode int, data T) error {
 	w.Header().Set("Content-Type", "application/json")
+	return encode(w, statusCode, data)
+}
+
+func encode[T any](w http.ResponseWriter, statusCode int, data T) error {
 	w.WriteHeader(statusCode)
 	if err := json.NewEncoder(w).Encode(data); err != nil {
 		return fmt.Errorf("failed to encode json: %w", err)
@@ -15,10 +44,101 @@ func EncodeJson[T any](w http.ResponseWriter, r *http.Request, statusCode int, d
 	return nil
 }
 
-func DecodeJson[T any](r *http.Request) (T, error) {
+// DecodeJson reads a single JSON value from the request body into T.
+//
+// The request must be sent as application/json (or a +json type) and the body
+// must hold exactly one value no larger than d.MaxBytes. Unknown fields and
+// values of the wrong type are returned as validators.ValidationErrors keyed
+// by the field path, e.g. "birthdate" or "address.number"; every other
+// failure is an *i18n.Error wrapping ErrUnsupportedMediaType, ErrBodyTooLarge
+// or ErrInvalidJSON.
+func DecodeJson[T any](r *http.Request, d Decoder) (T, error) {
 	var data T
-	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
-		return data, fmt.Errorf("decode json failed: %w", err)
+	if err := checkContentType(r); err != nil {
+		return data, err
+	}
+
+	limit := d.MaxBytes
+	if limit <= 0 {
+		limit = DefaultMaxBodyBytes
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, limit))
+	if !d.AllowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+
+	if err := dec.Decode(&data); err != nil {
+		return data, decodeError(err)
+	}
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return data, decodeError(err)
+		}
+		return data, &i18n.Error{Err: ErrInvalidJSON, Key: "json.multiple_values"}
 	}
 	return data, nil
 }
+
+func checkContentType(r *http.Request) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+		return &i18n.Error{Err: ErrUnsupportedMediaType, Key: "json.unsupported_media_type"}
+	}
+	return nil
+}
+
+func decodeError(err error) error {
+	var (
+		tooLarge  *http.MaxBytesError
+		syntax    *json.SyntaxError
+		typeError *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &tooLarge):
+		return &i18n.Error{Err: ErrBodyTooLarge, Key: "json.body_too_large", Args: []any{tooLarge.Limit}}
+	case errors.Is(err, io.EOF):
+		return &i18n.Error{Err: ErrInvalidJSON, Key: "json.empty_body"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &i18n.Error{Err: ErrInvalidJSON, Key: "json.truncated_body"}
+	case errors.As(err, &syntax):
+		return &i18n.Error{Err: ErrInvalidJSON, Key: "json.syntax_error", Args: []any{syntax.Offset}}
+	case errors.As(err, &typeError):
+		field := typeError.Field
+		if field == "" {
+			field = "body"
+		}
+		return validators.NewFieldError(field, validators.CodeType, jsonKind(typeError.Type), typeError.Value)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return validators.NewFieldError(field, validators.CodeUnknownField)
+	default:
+		return &i18n.Error{Err: ErrInvalidJSON, Key: "json.invalid_value", Args: []any{strings.TrimPrefix(err.Error(), "json: ")}}
+	}
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// jsonKind names the JSON type expected for a Go type.
+func jsonKind(t reflect.Type) string {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return t.String()
+	}
+}
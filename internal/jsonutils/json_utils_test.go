@@ -0,0 +1,101 @@
+package jsonutils
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
+)
+
+type address struct {
+	Number int `json:"number"`
+}
+
+type payload struct {
+	Name       string    `json:"name"`
+	CustomerID uuid.UUID `json:"customer_id"`
+	Address    address   `json:"address"`
+}
+
+func request(contentType, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+func TestDecodeJson(t *testing.T) {
+	got, err := DecodeJson[payload](request("application/json; charset=utf-8", `{"name":"Maria","address":{"number":10}}`), Decoder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Maria" || got.Address.Number != 10 {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestDecodeJsonRejects(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		decoder     Decoder
+		want        error
+	}{
+		{"missing content type", "", `{}`, Decoder{}, ErrUnsupportedMediaType},
+		{"form content type", "application/x-www-form-urlencoded", `{}`, Decoder{}, ErrUnsupportedMediaType},
+		{"empty body", "application/json", ``, Decoder{}, ErrInvalidJSON},
+		{"syntax error", "application/json", `{"name":}`, Decoder{}, ErrInvalidJSON},
+		{"truncated", "application/json", `{"name":"Maria"`, Decoder{}, ErrInvalidJSON},
+		{"trailing data", "application/json", `{"name":"Maria"} {}`, Decoder{}, ErrInvalidJSON},
+		{"too large", "application/json", `{"name":"` + strings.Repeat("a", 64) + `"}`, Decoder{MaxBytes: 32}, ErrBodyTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeJson[payload](request(tt.contentType, tt.body), tt.decoder)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeJsonFieldErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		field   string
+		message string
+	}{
+		{"unknown field", `{"nmae":"Maria"}`, "nmae", "unknown field"},
+		{"wrong type", `{"name":10}`, "name", "must be of type string, got number"},
+		{"nested wrong type", `{"address":{"number":"10"}}`, "address.number", "must be of type integer, got string"},
+		{"text unmarshaler", `{"customer_id":10}`, "customer_id", "must be of type string, got number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeJson[payload](request("application/json", tt.body), Decoder{})
+			var verrs validators.ValidationErrors
+			if !errors.As(err, &verrs) {
+				t.Fatalf("err = %v, want validation errors", err)
+			}
+			if got := verrs.Errors[tt.field]; got != tt.message {
+				t.Errorf("errors = %v, want %s: %q", verrs.Errors, tt.field, tt.message)
+			}
+		})
+	}
+}
+
+func TestDecodeJsonAllowUnknownFields(t *testing.T) {
+	got, err := DecodeJson[payload](request("application/json", `{"name":"Maria","extra":true}`), Decoder{AllowUnknownFields: true})
+	if err != nil || got.Name != "Maria" {
+		t.Errorf("got %+v, %v", got, err)
+	}
+}
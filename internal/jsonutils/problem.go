@@ -0,0 +1,39 @@
+package jsonutils
+
+import (
+	"net/http"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Problem is the body of every error response, following RFC 9457
+// (problem+json). Errors holds field-level messages for validation failures.
+type Problem struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Errors   map[string]string `json:"errors,omitempty"`
+}
+
+// NewProblem returns a Problem for status, titled with the standard status
+// text.
+func NewProblem(status int, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// EncodeProblem writes p with the problem+json content type. Instance
+// defaults to the request path.
+func EncodeProblem(w http.ResponseWriter, r *http.Request, p Problem) error {
+	if p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	return encode(w, p.Status, p)
+}
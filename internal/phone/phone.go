@@ -0,0 +1,143 @@
+// Package phone parses Brazilian phone numbers and normalizes them to E.164
+// ("+5511912345678"), so the same number typed as "(11) 9 1234-5678" or
+// "11912345678" is stored once.
+//
+// A national number is a two digit area code (DDD) followed by an 8 digit
+// landline, starting with 2 to 5, or a 9 digit mobile, starting with 9.
+// Mobiles written without the ninth digit, as before 2016, get it back.
+// Spaces, dots, dashes and parentheses are ignored, as are the +55 country
+// code and the 0 trunk prefix.
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const countryCode = "55"
+
+var (
+	ErrInvalid     = errors.New("invalid phone number")
+	ErrUnknownDDD  = errors.New("unknown area code")
+	ErrMissingDDD  = errors.New("phone number has no area code")
+	ErrInvalidLine = errors.New("invalid subscriber number")
+)
+
+// ddds are the area codes assigned by Anatel.
+var ddds = map[string]bool{}
+
+func init() {
+	for _, d := range []string{
+		"11", "12", "13", "14", "15", "16", "17", "18", "19",
+		"21", "22", "24", "27", "28",
+		"31", "32", "33", "34", "35", "37", "38",
+		"41", "42", "43", "44", "45", "46", "47", "48", "49",
+		"51", "53", "54", "55",
+		"61", "62", "63", "64", "65", "66", "67", "68", "69",
+		"71", "73", "74", "75", "77", "79",
+		"81", "82", "83", "84", "85", "86", "87", "88", "89",
+		"91", "92", "93", "94", "95", "96", "97", "98", "99",
+	} {
+		ddds[d] = true
+	}
+}
+
+// Number is a parsed Brazilian phone number.
+type Number struct {
+	DDD        string
+	Subscriber string
+}
+
+// Mobile reports whether n is a mobile number.
+func (n Number) Mobile() bool {
+	return len(n.Subscriber) == 9
+}
+
+// E164 returns n in E.164, e.g. "+5511912345678".
+func (n Number) E164() string {
+	return "+" + countryCode + n.DDD + n.Subscriber
+}
+
+// String returns n in the national format, e.g. "(11) 91234-5678".
+func (n Number) String() string {
+	split := len(n.Subscriber) - 4
+	return fmt.Sprintf("(%s) %s-%s", n.DDD, n.Subscriber[:split], n.Subscriber[split:])
+}
+
+// Parse reads a Brazilian phone number in any of the usual formats.
+func Parse(s string) (Number, error) {
+	digits, ok := stripFormatting(s)
+	if !ok || digits == "" {
+		return Number{}, ErrInvalid
+	}
+
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(s), "+") || (len(digits) >= 12 && strings.HasPrefix(digits, countryCode)):
+		if !strings.HasPrefix(digits, countryCode) {
+			return Number{}, ErrInvalid
+		}
+		digits = digits[len(countryCode):]
+	case len(digits) >= 11 && digits[0] == '0':
+		digits = digits[1:]
+	}
+
+	switch len(digits) {
+	case 8, 9:
+		return Number{}, ErrMissingDDD
+	case 10, 11:
+	default:
+		return Number{}, ErrInvalid
+	}
+
+	n := Number{DDD: digits[:2], Subscriber: digits[2:]}
+	if !ddds[n.DDD] {
+		return Number{}, ErrUnknownDDD
+	}
+
+	switch first := n.Subscriber[0]; {
+	case len(n.Subscriber) == 9 && first == '9':
+	case len(n.Subscriber) == 8 && first >= '2' && first <= '5':
+	case len(n.Subscriber) == 8 && first >= '6':
+		n.Subscriber = "9" + n.Subscriber
+	default:
+		return Number{}, ErrInvalidLine
+	}
+	return n, nil
+}
+
+// Normalize returns s in E.164.
+func Normalize(s string) (string, error) {
+	n, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return n.E164(), nil
+}
+
+// Canonical returns s in E.164, or s unchanged when it does not parse. It is
+// meant for values that were already validated, so a bad number is stored or
+// compared as typed instead of failing twice.
+func Canonical(s string) string {
+	if n, err := Normalize(s); err == nil {
+		return n
+	}
+	return s
+}
+
+// stripFormatting keeps the digits of s, allowing a leading + and the
+// separators people type. Any other character makes the number invalid.
+func stripFormatting(s string) (string, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "+")
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
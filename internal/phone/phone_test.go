@@ -0,0 +1,52 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+		err      error
+	}{
+		{"(11) 9 1234-5678", "+5511912345678", nil},
+		{"11912345678", "+5511912345678", nil},
+		{"+55 11 91234-5678", "+5511912345678", nil},
+		{"5511912345678", "+5511912345678", nil},
+		{"011 91234-5678", "+5511912345678", nil},
+		{"(21) 3456-7890", "+552134567890", nil},
+		{"21 3456.7890", "+552134567890", nil},
+		{"+552134567890", "+552134567890", nil},
+		{"(55) 91234-5678", "+5555912345678", nil},
+		{"(31) 8765-4321", "+5531987654321", nil},
+		{"91234-5678", "", ErrMissingDDD},
+		{"(20) 91234-5678", "", ErrUnknownDDD},
+		{"(11) 1234-5678", "", ErrInvalidLine},
+		{"(11) 8 1234-5678", "", ErrInvalidLine},
+		{"+1 415 555 2671", "", ErrInvalid},
+		{"11 91234-567a", "", ErrInvalid},
+		{"", "", ErrInvalid},
+		{"123", "", ErrInvalid},
+	} {
+		got, err := Normalize(tc.in)
+		if got != tc.want || !errors.Is(err, tc.err) {
+			t.Errorf("Normalize(%q) = %q, %v; want %q, %v", tc.in, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+func TestNumberString(t *testing.T) {
+	for in, want := range map[string]string{
+		"11912345678": "(11) 91234-5678",
+		"2134567890":  "(21) 3456-7890",
+	} {
+		n, err := Parse(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n.String() != want {
+			t.Errorf("Parse(%q).String() = %q, want %q", in, n.String(), want)
+		}
+	}
+}
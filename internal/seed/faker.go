@@ -0,0 +1,236 @@
+package seed
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/service"
+)
+
+// Faker generates fake but valid customer and service requests: CPFs and
+// CNPJs have correct check digits, CEPs and phones match their state. The
+// same seed always yields the same sequence.
+type Faker struct {
+	r *rand.Rand
+}
+
+func NewFaker(seed uint64) *Faker {
+	return newFaker(seed, 0)
+}
+
+// newFaker returns one of many independent streams of seed, so customer n
+// gets the same data no matter what happened to the customers before it.
+func newFaker(seed, stream uint64) *Faker {
+	return &Faker{r: rand.New(rand.NewPCG(seed, stream))}
+}
+
+var (
+	firstNames = []string{
+		"Ana", "Beatriz", "Camila", "Fernanda", "Juliana", "Larissa", "Mariana", "Patrícia", "Letícia", "Gabriela",
+		"João", "Pedro", "Lucas", "Gustavo", "Rafael", "Thiago", "Bruno", "Felipe", "André", "Vinícius",
+	}
+	lastNames = []string{
+		"Silva", "Santos", "Oliveira", "Souza", "Rodrigues", "Ferreira", "Alves", "Pereira", "Lima", "Gomes",
+		"Costa", "Ribeiro", "Martins", "Carvalho", "Almeida", "Araújo", "Barbosa", "Rocha", "Cardoso", "Nascimento",
+	}
+	companyWords = []string{
+		"Alfa", "Horizonte", "Nova Era", "Atlântico", "Cerrado", "Ipê", "Aurora", "Vértice", "Boa Vista", "Serra Azul",
+	}
+	companyKinds = []string{
+		"Comércio", "Serviços", "Tecnologia", "Construções", "Alimentos", "Transportes", "Consultoria", "Engenharia",
+	}
+	companySuffixes = []string{"Ltda", "S.A.", "ME", "EIRELI"}
+	streets         = []string{
+		"Rua das Flores", "Avenida Brasil", "Rua XV de Novembro", "Avenida Paulista", "Rua Sete de Setembro",
+		"Rua Tiradentes", "Avenida Getúlio Vargas", "Rua São João", "Rua Santos Dumont", "Avenida Beira Mar",
+	}
+	complements = []string{"Apto 101", "Apto 32", "Casa 2", "Sala 804", "Bloco B", "Fundos"}
+)
+
+// cities pairs each city with its state, area code and the CEP range prefix.
+var cities = []struct {
+	name, state, ddd, cep string
+}{
+	{"São Paulo", "SP", "11", "01"},
+	{"Campinas", "SP", "19", "13"},
+	{"Rio de Janeiro", "RJ", "21", "20"},
+	{"Belo Horizonte", "MG", "31", "30"},
+	{"Curitiba", "PR", "41", "80"},
+	{"Porto Alegre", "RS", "51", "90"},
+	{"Florianópolis", "SC", "48", "88"},
+	{"Salvador", "BA", "71", "40"},
+	{"Recife", "PE", "81", "50"},
+	{"Fortaleza", "CE", "85", "60"},
+	{"Brasília", "DF", "61", "70"},
+	{"Goiânia", "GO", "62", "74"},
+	{"Manaus", "AM", "92", "69"},
+	{"Belém", "PA", "91", "66"},
+}
+
+var products = []struct {
+	kind         string
+	descriptions []string
+}{
+	{"Manutenção", []string{"Manutenção preventiva de equipamentos", "Troca de peças e revisão geral"}},
+	{"Instalação", []string{"Instalação de ar-condicionado split", "Instalação de rede elétrica"}},
+	{"Consultoria", []string{"Consultoria tributária mensal", "Diagnóstico de processos internos"}},
+	{"Desenvolvimento", []string{"Desenvolvimento de site institucional", "Integração com sistema de pagamentos"}},
+	{"Reforma", []string{"Reforma de cozinha completa", "Pintura interna e externa"}},
+}
+
+// CPF returns a formatted CPF with valid check digits.
+func (f *Faker) CPF() string {
+	ds := f.digits(9)
+	ds = append(ds, checkDigit(ds, 11))
+	ds = append(ds, checkDigit(ds, 11))
+	s := join(ds)
+	return fmt.Sprintf("%s.%s.%s-%s", s[0:3], s[3:6], s[6:9], s[9:11])
+}
+
+// CNPJ returns a formatted CNPJ of a head office (branch 0001) with valid
+// check digits.
+func (f *Faker) CNPJ() string {
+	ds := append(f.digits(8), 0, 0, 0, 1)
+	ds = append(ds, checkDigit(ds, 9))
+	ds = append(ds, checkDigit(ds, 9))
+	s := join(ds)
+	return fmt.Sprintf("%s.%s.%s/%s-%s", s[0:2], s[2:5], s[5:8], s[8:12], s[12:14])
+}
+
+// PF returns a person customer living in a random city.
+func (f *Faker) PF() customer.CustomerPFRequest {
+	first, last := pick(f.r, firstNames), pick(f.r, lastNames)
+	c := pick(f.r, cities)
+	birth := time.Date(1945+f.r.IntN(60), time.Month(1+f.r.IntN(12)), 1+f.r.IntN(28), 0, 0, 0, 0, time.UTC)
+	return customer.CustomerPFRequest{
+		Type:        string(sqlc.CustomerTypePF),
+		Name:        first + " " + pick(f.r, lastNames) + " " + last,
+		Email:       fmt.Sprintf("%s.%s.%06d@example.com", slug(first), slug(last), f.r.IntN(1000000)),
+		Phone:       f.phone(c.ddd),
+		Cpf:         f.CPF(),
+		BirthDate:   pgtype.Date{Time: birth, Valid: true},
+		AddressType: "residencial",
+		Street:      pick(f.r, streets),
+		Number:      fmt.Sprint(1 + f.r.IntN(3000)),
+		Complement:  f.complement(),
+		State:       c.state,
+		City:        c.name,
+		Cep:         f.cep(c.cep),
+	}
+}
+
+// PJ returns a company customer with its head office in a random city.
+func (f *Faker) PJ() customer.CustomerPJRequest {
+	word := pick(f.r, companyWords)
+	c := pick(f.r, cities)
+	return customer.CustomerPJRequest{
+		Type:        string(sqlc.CustomerTypePJ),
+		CompanyName: word + " " + pick(f.r, companyKinds) + " " + pick(f.r, companySuffixes),
+		Email:       fmt.Sprintf("contato.%06d@%s.com.br", f.r.IntN(1000000), slug(word)),
+		Phone:       f.phone(c.ddd),
+		Cnpj:        f.CNPJ(),
+		AddressType: "comercial",
+		Street:      pick(f.r, streets),
+		Number:      fmt.Sprint(1 + f.r.IntN(3000)),
+		Complement:  f.complement(),
+		State:       c.state,
+		City:        c.name,
+		Cep:         f.cep(c.cep),
+	}
+}
+
+// Service returns a service for customerID worth between R$ 100,00 and
+// R$ 20.000,00, with a down payment on about half of them.
+func (f *Faker) Service(customerID uuid.UUID) service.ServiceRequest {
+	p := pick(f.r, products)
+	total := 10000 + f.r.Int64N(1990001)
+	req := service.ServiceRequest{
+		CustomerID:  customerID,
+		TypeProduct: p.kind,
+		Description: pick(f.r, p.descriptions),
+		TotalValue:  cents(total),
+		IsPaid:      f.r.IntN(3) == 0,
+		IsFinished:  f.r.IntN(2) == 0,
+	}
+	if f.r.IntN(2) == 0 {
+		req.DownPayment = cents(f.r.Int64N(total/2 + 1))
+	}
+	return req
+}
+
+func (f *Faker) digits(n int) []int {
+	for {
+		ds := make([]int, n)
+		for i := range ds {
+			ds[i] = f.r.IntN(10)
+		}
+		// Sequences of one repeated digit are rejected by the validators.
+		if strings.Count(join(ds), join(ds[:1])) != n {
+			return ds
+		}
+	}
+}
+
+func (f *Faker) phone(ddd string) string {
+	return fmt.Sprintf("%s 9%04d-%04d", ddd, f.r.IntN(10000), f.r.IntN(10000))
+}
+
+func (f *Faker) cep(prefix string) string {
+	return fmt.Sprintf("%s%03d-%03d", prefix, f.r.IntN(1000), f.r.IntN(1000))
+}
+
+func (f *Faker) complement() pgtype.Text {
+	if f.r.IntN(3) != 0 {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: pick(f.r, complements), Valid: true}
+}
+
+// checkDigit is the mod 11 check digit of ds. Weights start at 2 on the
+// rightmost digit and grow up to maxWeight, then wrap back to 2 (CNPJ uses 9;
+// CPF never wraps).
+func checkDigit(ds []int, maxWeight int) int {
+	sum, weight := 0, 2
+	for i := len(ds) - 1; i >= 0; i-- {
+		sum += ds[i] * weight
+		if weight++; weight > maxWeight {
+			weight = 2
+		}
+	}
+	if rem := sum % 11; rem >= 2 {
+		return 11 - rem
+	}
+	return 0
+}
+
+func cents(v int64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(v), Exp: -2, Valid: true}
+}
+
+func join(ds []int) string {
+	var b strings.Builder
+	for _, d := range ds {
+		b.WriteByte(byte('0' + d))
+	}
+	return b.String()
+}
+
+func pick[T any](r *rand.Rand, items []T) T {
+	return items[r.IntN(len(items))]
+}
+
+var unaccent = strings.NewReplacer(
+	"á", "a", "â", "a", "ã", "a", "à", "a", "é", "e", "ê", "e", "í", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ú", "u", "ç", "c", "Á", "a", "É", "e", "Í", "i", "Ó", "o", "Ú", "u", " ", "",
+)
+
+func slug(s string) string {
+	return strings.ToLower(unaccent.Replace(s))
+}
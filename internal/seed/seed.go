@@ -0,0 +1,118 @@
+// Package seed fills a database with fake customers and services for demos
+// and load tests. Every customer goes through the services package, so data
+// is validated, encrypted and indexed exactly as if it came from the API.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/google/uuid"
+
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+	"go.uber.org/zap"
+)
+
+// Options controls how much data Run creates. The zero value creates nothing.
+type Options struct {
+	// Customers is how many customers to create.
+	Customers int
+	// PJShare is the fraction of customers that are companies, from 0 to 1.
+	PJShare float64
+	// MaxServices is the maximum number of services per customer; each
+	// customer gets between 0 and MaxServices.
+	MaxServices int
+	// Seed makes the data reproducible. Zero picks a random seed, reported
+	// back in Report.Seed.
+	Seed uint64
+}
+
+func (o Options) Validate() error {
+	var errs []error
+	if o.Customers < 0 {
+		errs = append(errs, errors.New("customers must not be negative"))
+	}
+	if o.PJShare < 0 || o.PJShare > 1 {
+		errs = append(errs, errors.New("pj share must be between 0 and 1"))
+	}
+	if o.MaxServices < 0 {
+		errs = append(errs, errors.New("max services must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Report counts what Run created. Skipped customers collided with existing
+// data (same CPF, CNPJ, email or phone) and were left out.
+type Report struct {
+	Seed     uint64 `json:"seed"`
+	PF       int    `json:"pf"`
+	PJ       int    `json:"pj"`
+	Services int    `json:"services"`
+	Skipped  int    `json:"skipped"`
+}
+
+type Seeder struct {
+	customers *services.CustomerService
+	services  *services.ServiceService
+}
+
+func New(customers *services.CustomerService, services *services.ServiceService) *Seeder {
+	return &Seeder{customers: customers, services: services}
+}
+
+// Run creates opts.Customers customers with their services. Customer n is
+// the same for a given seed, so running again with the same seed skips what
+// already exists. It stops at the first error other than a duplicate,
+// returning what was created so far.
+func (s *Seeder) Run(ctx context.Context, opts Options) (Report, error) {
+	if err := opts.Validate(); err != nil {
+		return Report{}, err
+	}
+	if opts.Seed == 0 {
+		opts.Seed = rand.Uint64()
+	}
+
+	report := Report{Seed: opts.Seed}
+	for i := 0; i < opts.Customers; i++ {
+		f := newFaker(opts.Seed, uint64(i))
+		pj := f.r.Float64() < opts.PJShare
+
+		var id uuid.UUID
+		var err error
+		if pj {
+			id, err = s.customers.CreatePJCustomer(ctx, f.PJ())
+		} else {
+			id, err = s.customers.CreatePFCustomer(ctx, f.PF())
+		}
+		if errors.Is(err, services.ErrDuplicatedData) {
+			report.Skipped++
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("creating customer %d: %w", i+1, err)
+		}
+		if pj {
+			report.PJ++
+		} else {
+			report.PF++
+		}
+
+		for range f.r.IntN(opts.MaxServices + 1) {
+			if _, err := s.services.CreateService(ctx, f.Service(id)); err != nil {
+				return report, fmt.Errorf("creating service for customer %s: %w", id, err)
+			}
+			report.Services++
+		}
+	}
+
+	logger.FromContext(ctx).Info("Seed data created",
+		zap.Uint64("seed", report.Seed),
+		zap.Int("pf", report.PF),
+		zap.Int("pj", report.PJ),
+		zap.Int("services", report.Services),
+		zap.Int("skipped", report.Skipped))
+	return report, nil
+}
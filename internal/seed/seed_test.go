@@ -0,0 +1,84 @@
+package seed_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/db/memstore"
+	"github.com/josevitorrodriguess/client-manager/internal/seed"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+	"github.com/josevitorrodriguess/client-manager/internal/testutil"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+)
+
+func TestFakerGeneratesValidRequests(t *testing.T) {
+	f := seed.NewFaker(42)
+	for i := 0; i < 500; i++ {
+		pf, pj, svc := f.PF(), f.PJ(), f.Service(uuid.New())
+		if err := pf.Validate(); err != nil {
+			t.Fatalf("PF %+v: %v", pf, err)
+		}
+		if err := pj.Validate(); err != nil {
+			t.Fatalf("PJ %+v: %v", pj, err)
+		}
+		if err := svc.Validate(); err != nil {
+			t.Fatalf("service %+v: %v", svc, err)
+		}
+	}
+}
+
+func TestFakerIsReproducible(t *testing.T) {
+	a, b := seed.NewFaker(7), seed.NewFaker(7)
+	for i := 0; i < 10; i++ {
+		if x, y := a.CPF(), b.CPF(); x != y {
+			t.Fatalf("CPF %d: %s != %s", i, x, y)
+		}
+	}
+	if known := seed.NewFaker(7).CNPJ(); known == seed.NewFaker(8).CNPJ() {
+		t.Errorf("seeds 7 and 8 produced the same CNPJ %s", known)
+	}
+}
+
+func TestRun(t *testing.T) {
+	store := memstore.New()
+	cs := services.NewCustomerService(store, store, testutil.Cipher(t))
+	ss := services.NewServiceService(store)
+	ctx := context.Background()
+
+	report, err := seed.New(cs, ss).Run(ctx, seed.Options{Customers: 30, PJShare: 0.5, MaxServices: 3, Seed: 1})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if report.Seed != 1 || report.PF+report.PJ != 30 || report.PF == 0 || report.PJ == 0 {
+		t.Errorf("report = %+v", report)
+	}
+
+	customers, err := cs.GetAllCustomersDetails(ctx, customer.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(customers) != 30 {
+		t.Errorf("%d customers stored, want 30", len(customers))
+	}
+	svcs, err := ss.ListAllServices(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(svcs) != report.Services {
+		t.Errorf("%d services stored, report says %d", len(svcs), report.Services)
+	}
+
+	// The same seed again collides with every customer already created.
+	again, err := seed.New(cs, ss).Run(ctx, seed.Options{Customers: 30, PJShare: 0.5, MaxServices: 3, Seed: 1})
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if again.Skipped != 30 {
+		t.Errorf("second run report = %+v, want 30 skipped", again)
+	}
+
+	if _, err := seed.New(cs, ss).Run(ctx, seed.Options{PJShare: 2}); err == nil {
+		t.Error("Run accepted a PJ share of 2")
+	}
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+const (
+	AuditEntityCustomer = "customer"
+
+	AuditCustomerExported   = "customer.exported"
+	AuditCustomerAnonymized = "customer.anonymized"
+)
+
+// recordAudit appends an audit event using q, so it commits or rolls back
+// together with the action it describes. A nil actorID records a system
+// action.
+func recordAudit(ctx context.Context, q sqlc.Querier, actorID uuid.UUID, action, entityType, entityID string, details map[string]any) error {
+	if details == nil {
+		details = map[string]any{}
+	}
+	b, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("encoding audit details: %w", err)
+	}
+
+	_, err = q.CreateAuditEvent(ctx, sqlc.CreateAuditEventParams{
+		ActorID:    pgtype.UUID{Bytes: actorID, Valid: actorID != uuid.Nil},
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Details:    b,
+	})
+	return err
+}
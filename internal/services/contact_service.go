@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/phone"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"go.uber.org/zap"
+)
+
+var ErrContactNotFound = errors.New("contact not found")
+
+// AddContact adds a contact to the customer. When the contact is primary the
+// previous primary contact, if any, is demoted in the same transaction.
+func (cs *CustomerService) AddContact(ctx context.Context, customerID uuid.UUID, req customer.ContactRequest) (customer.ContactResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.AddContact")
+	defer span.End()
+
+	var contact sqlc.Contact
+	err := cs.tx.WithTx(ctx, func(q sqlc.Querier) error {
+		if req.IsPrimary {
+			if err := q.ClearPrimaryContact(ctx, customerID); err != nil {
+				return err
+			}
+		}
+		var err error
+		contact, err = q.CreateContact(ctx, sqlc.CreateContactParams{
+			CustomerID: customerID,
+			Name:       req.Name,
+			Role:       req.Role,
+			Emails:     nonNil(req.Emails),
+			Phones:     canonicalPhones(req.Phones),
+			Whatsapp:   req.WhatsApp,
+			IsPrimary:  req.IsPrimary,
+		})
+		return err
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return customer.ContactResponse{}, ErrCustomerNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to add contact", err, zap.String("customer_id", customerID.String()))
+		return customer.ContactResponse{}, err
+	}
+
+	return customer.MapContact(contact), nil
+}
+
+func (cs *CustomerService) ListContacts(ctx context.Context, customerID uuid.UUID) ([]customer.ContactResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListContacts")
+	defer span.End()
+
+	if _, err := cs.queries.GetCustomerByID(ctx, customerID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCustomerNotFound
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	contacts, err := cs.queries.ListCustomerContacts(ctx, customerID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to list contacts", err, zap.String("customer_id", customerID.String()))
+		return nil, err
+	}
+	return customer.MapContacts(contacts), nil
+}
+
+// UpdateContact replaces every field of a contact of the customer.
+func (cs *CustomerService) UpdateContact(ctx context.Context, customerID uuid.UUID, contactID int32, req customer.ContactRequest) (customer.ContactResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.UpdateContact")
+	defer span.End()
+
+	var contact sqlc.Contact
+	err := cs.tx.WithTx(ctx, func(q sqlc.Querier) error {
+		if req.IsPrimary {
+			if err := q.ClearPrimaryContact(ctx, customerID); err != nil {
+				return err
+			}
+		}
+		var err error
+		contact, err = q.UpdateContact(ctx, sqlc.UpdateContactParams{
+			ID:         contactID,
+			CustomerID: customerID,
+			Name:       req.Name,
+			Role:       req.Role,
+			Emails:     nonNil(req.Emails),
+			Phones:     canonicalPhones(req.Phones),
+			Whatsapp:   req.WhatsApp,
+			IsPrimary:  req.IsPrimary,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrContactNotFound
+		}
+		return err
+	})
+	if err != nil && !errors.Is(err, ErrContactNotFound) {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to update contact", err,
+			zap.String("customer_id", customerID.String()),
+			zap.Int32("contact_id", contactID))
+	}
+	if err != nil {
+		return customer.ContactResponse{}, err
+	}
+
+	return customer.MapContact(contact), nil
+}
+
+func (cs *CustomerService) DeleteContact(ctx context.Context, customerID uuid.UUID, contactID int32) error {
+	ctx, span := tracing.Start(ctx, "CustomerService.DeleteContact")
+	defer span.End()
+
+	n, err := cs.queries.DeleteContact(ctx, sqlc.DeleteContactParams{ID: contactID, CustomerID: customerID})
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to delete contact", err,
+			zap.String("customer_id", customerID.String()),
+			zap.Int32("contact_id", contactID))
+		return err
+	}
+	if n == 0 {
+		return ErrContactNotFound
+	}
+	return nil
+}
+
+// nonNil turns a missing list into an empty one; the array columns are NOT
+// NULL.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// canonicalPhones stores contact phones in E.164, like customer phones.
+func canonicalPhones(values []string) []string {
+	phones := make([]string, 0, len(values))
+	for _, v := range values {
+		phones = append(phones, phone.Canonical(v))
+	}
+	return phones
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCustomFieldNotFound = errors.New("custom field not found")
+	ErrCustomFieldExists   = errors.New("custom field already exists")
+)
+
+func (cs *CustomerService) CreateCustomField(ctx context.Context, req customer.CustomFieldRequest) (customer.CustomFieldResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.CreateCustomField")
+	defer span.End()
+
+	options := []string{}
+	if req.Type == string(sqlc.CustomFieldTypeSelect) {
+		options = req.Options
+	}
+	field, err := cs.queries.CreateCustomField(ctx, sqlc.CreateCustomFieldParams{
+		Key:     req.Key,
+		Label:   req.Label,
+		Type:    sqlc.CustomFieldType(req.Type),
+		Options: options,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return customer.CustomFieldResponse{}, ErrCustomFieldExists
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to create custom field", err, zap.String("key", req.Key))
+		return customer.CustomFieldResponse{}, err
+	}
+	return customer.MapCustomField(field), nil
+}
+
+func (cs *CustomerService) ListCustomFields(ctx context.Context) ([]customer.CustomFieldResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListCustomFields")
+	defer span.End()
+
+	fields, err := cs.queries.ListCustomFields(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to list custom fields", err)
+		return nil, err
+	}
+	return customer.MapCustomFields(fields), nil
+}
+
+// DeleteCustomField removes the definition and the values every customer had
+// for it.
+func (cs *CustomerService) DeleteCustomField(ctx context.Context, key string) error {
+	ctx, span := tracing.Start(ctx, "CustomerService.DeleteCustomField")
+	defer span.End()
+
+	err := cs.tx.WithTx(ctx, func(q sqlc.Querier) error {
+		n, err := q.DeleteCustomField(ctx, key)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrCustomFieldNotFound
+		}
+		return q.RemoveCustomFieldValues(ctx, key)
+	})
+	if err != nil && !errors.Is(err, ErrCustomFieldNotFound) {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to delete custom field", err, zap.String("key", key))
+	}
+	return err
+}
+
+// SetCustomFields replaces the custom field values of the customer after
+// checking them against the current definitions, and returns them as stored.
+func (cs *CustomerService) SetCustomFields(ctx context.Context, customerID uuid.UUID, values customer.CustomFieldValues) (map[string]any, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.SetCustomFields")
+	defer span.End()
+
+	var typed map[string]any
+	err := cs.tx.WithTx(ctx, func(q sqlc.Querier) error {
+		fields, err := q.ListCustomFields(ctx)
+		if err != nil {
+			return err
+		}
+		typed, err = values.Check(fields)
+		if err != nil {
+			return err
+		}
+		raw, err := json.Marshal(typed)
+		if err != nil {
+			return err
+		}
+		n, err := q.SetCustomerCustomFields(ctx, sqlc.SetCustomerCustomFieldsParams{ID: customerID, CustomFields: raw})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrCustomerNotFound
+		}
+		return nil
+	})
+	var validation validators.ValidationErrors
+	if err != nil && !errors.Is(err, ErrCustomerNotFound) && !errors.As(err, &validation) {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to set custom fields", err, zap.String("customer_id", customerID.String()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return typed, nil
+}
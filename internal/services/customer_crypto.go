@@ -0,0 +1,424 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/fieldcrypt"
+	"github.com/josevitorrodriguess/client-manager/internal/phone"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"go.uber.org/zap"
+)
+
+// Blind index domains. Changing them invalidates every stored index.
+const (
+	indexFieldCpf  = "cpf"
+	indexFieldCnpj = "cnpj"
+)
+
+// Columns the encrypted values are bound to, see columnAD.
+const (
+	columnCpf       = "customerf_pf.cpf"
+	columnBirthDate = "customerf_pf.birth_date"
+	columnCnpj      = "customerf_pj.cnpj"
+)
+
+const birthDateLayout = "2006-01-02"
+
+// rewrapBatchSize is how many PF or PJ rows are rewritten per transaction by
+// RotateEncryptionKeys and EncryptLegacyCustomers.
+const rewrapBatchSize = 500
+
+// KeyRotationReport counts the PF and PJ rows checked by RotateEncryptionKeys
+// and those rewritten because they used an old key or were still plaintext.
+// Conflicts lists the customers left as they were because another customer
+// already holds the blind index of their CPF/CNPJ: plaintext such as
+// "123.456.789-09" and "12345678909" was unique before normalization. They
+// have to be merged by hand.
+type KeyRotationReport struct {
+	Scanned   int         `json:"scanned"`
+	Updated   int         `json:"updated"`
+	Conflicts []uuid.UUID `json:"conflicts"`
+}
+
+// rewrapOutcome is what happened to one row in a rewrap batch.
+type rewrapOutcome int
+
+const (
+	rewrapSkipped rewrapOutcome = iota
+	rewrapUnchanged
+	rewrapUpdated
+	rewrapConflict
+)
+
+func (r *KeyRotationReport) add(id uuid.UUID, outcome rewrapOutcome) {
+	if outcome == rewrapSkipped {
+		return
+	}
+	r.Scanned++
+	switch outcome {
+	case rewrapUpdated:
+		r.Updated++
+	case rewrapConflict:
+		r.Conflicts = append(r.Conflicts, id)
+	}
+}
+
+// columnAD is the additional data binding an encrypted value to its customer
+// and column, so it cannot be copied to another row unnoticed.
+func columnAD(customerID uuid.UUID, column string) string {
+	return column + ":" + customerID.String()
+}
+
+// pfParams encrypts the CPF and birth date of a new PF customer and computes
+// the CPF blind index. The id is chosen here since the ciphertexts are bound
+// to it. The phone is stored in E.164.
+func (cs *CustomerService) pfParams(ctx context.Context, c customer.CustomerPFRequest) (sqlc.CreateCustomerPFParams, error) {
+	id := uuid.New()
+	cpf, err := cs.cipher.Encrypt(ctx, c.Cpf, columnAD(id, columnCpf))
+	if err != nil {
+		return sqlc.CreateCustomerPFParams{}, err
+	}
+	var birthDate string
+	if c.BirthDate.Valid {
+		birthDate = c.BirthDate.Time.Format(birthDateLayout)
+	}
+	birthDate, err = cs.cipher.Encrypt(ctx, birthDate, columnAD(id, columnBirthDate))
+	if err != nil {
+		return sqlc.CreateCustomerPFParams{}, err
+	}
+	index, err := cs.documentIndex(ctx, indexFieldCpf, c.Cpf)
+	if err != nil {
+		return sqlc.CreateCustomerPFParams{}, err
+	}
+
+	return sqlc.CreateCustomerPFParams{
+		ID:          id,
+		Type:        sqlc.CustomerType(c.Type),
+		Email:       c.Email,
+		Phone:       phone.Canonical(c.Phone),
+		Cpf:         cpf,
+		CpfIndex:    index,
+		Name:        c.Name,
+		BirthDate:   birthDate,
+		AddressType: c.AddressType,
+		Street:      c.Street,
+		Number:      c.Number,
+		Complement:  c.Complement,
+		State:       c.State,
+		City:        c.City,
+		Cep:         c.Cep,
+	}, nil
+}
+
+// pjParams encrypts the CNPJ of a new PJ customer and computes its blind
+// index. Like pfParams it chooses the id. The phone is stored in E.164.
+func (cs *CustomerService) pjParams(ctx context.Context, c customer.CustomerPJRequest) (sqlc.CreateCustomerPJParams, error) {
+	id := uuid.New()
+	cnpj, err := cs.cipher.Encrypt(ctx, c.Cnpj, columnAD(id, columnCnpj))
+	if err != nil {
+		return sqlc.CreateCustomerPJParams{}, err
+	}
+	index, err := cs.documentIndex(ctx, indexFieldCnpj, c.Cnpj)
+	if err != nil {
+		return sqlc.CreateCustomerPJParams{}, err
+	}
+
+	return sqlc.CreateCustomerPJParams{
+		ID:          id,
+		Type:        sqlc.CustomerType(c.Type),
+		Email:       c.Email,
+		Phone:       phone.Canonical(c.Phone),
+		Cnpj:        cnpj,
+		CnpjIndex:   index,
+		CompanyName: c.CompanyName,
+		AddressType: c.AddressType,
+		Street:      c.Street,
+		Number:      c.Number,
+		Complement:  c.Complement,
+		State:       c.State,
+		City:        c.City,
+		Cep:         c.Cep,
+	}, nil
+}
+
+// documentIndex returns the blind index of a CPF or CNPJ. Punctuation is
+// stripped first so "123.456.789-09" and "12345678909" collide. An empty
+// document has no index (NULL).
+func (cs *CustomerService) documentIndex(ctx context.Context, field, document string) (pgtype.Text, error) {
+	normalized := customer.NormalizeDocument(document)
+	if normalized == "" {
+		return pgtype.Text{}, nil
+	}
+
+	index, err := cs.cipher.BlindIndex(ctx, field, normalized)
+	if err != nil {
+		return pgtype.Text{}, err
+	}
+	return pgtype.Text{String: index, Valid: true}, nil
+}
+
+// anonymizedPFParams blanks the CPF and birth date of an anonymized PF
+// customer. The empty values are encrypted like an absent birth date, so no
+// plaintext placeholder is stored, and the row leaves the blind index.
+func (cs *CustomerService) anonymizedPFParams(ctx context.Context, id uuid.UUID) (sqlc.AnonymizeCustomerPFParams, error) {
+	cpf, err := cs.cipher.Encrypt(ctx, "", columnAD(id, columnCpf))
+	if err != nil {
+		return sqlc.AnonymizeCustomerPFParams{}, err
+	}
+	birthDate, err := cs.cipher.Encrypt(ctx, "", columnAD(id, columnBirthDate))
+	if err != nil {
+		return sqlc.AnonymizeCustomerPFParams{}, err
+	}
+	return sqlc.AnonymizeCustomerPFParams{Cpf: cpf, BirthDate: birthDate, CustomerID: id}, nil
+}
+
+// anonymizedPJParams blanks the CNPJ of an anonymized PJ customer, like
+// anonymizedPFParams.
+func (cs *CustomerService) anonymizedPJParams(ctx context.Context, id uuid.UUID) (sqlc.AnonymizeCustomerPJParams, error) {
+	cnpj, err := cs.cipher.Encrypt(ctx, "", columnAD(id, columnCnpj))
+	if err != nil {
+		return sqlc.AnonymizeCustomerPJParams{}, err
+	}
+	return sqlc.AnonymizeCustomerPJParams{Cnpj: cnpj, CustomerID: id}, nil
+}
+
+// openCustomer decrypts the sensitive columns of row in place. Plaintext
+// left by rows written before encryption was enabled passes through Decrypt
+// unchanged.
+func (cs *CustomerService) openCustomer(ctx context.Context, row *sqlc.GetCustomerByIDRow) error {
+	columns := map[*interface{}]string{&row.Cpf: columnCpf, &row.BirthDate: columnBirthDate, &row.Cnpj: columnCnpj}
+	for v, column := range columns {
+		s, ok := (*v).(string)
+		if !ok {
+			continue
+		}
+		plain, err := cs.cipher.Decrypt(ctx, s, columnAD(row.ID, column))
+		if err != nil {
+			return err
+		}
+		*v = plain
+	}
+	return nil
+}
+
+// openCustomerListRow decrypts the sensitive columns of row in place.
+func (cs *CustomerService) openCustomerListRow(ctx context.Context, row *sqlc.GetAllCustomersRow) error {
+	columns := map[*pgtype.Text]string{&row.PfCpf: columnCpf, &row.PfBirthDate: columnBirthDate, &row.PjCnpj: columnCnpj}
+	for v, column := range columns {
+		if !v.Valid {
+			continue
+		}
+		plain, err := cs.cipher.Decrypt(ctx, v.String, columnAD(row.CustomerID, column))
+		if err != nil {
+			return err
+		}
+		v.String = plain
+	}
+	return nil
+}
+
+// RotateEncryptionKeys re-wraps the CPF, CNPJ and birth date of every customer
+// with the active key. Rows written before encryption was enabled are
+// encrypted and get their blind index; anonymized customers are skipped. Rows
+// are rewritten in batches of rewrapBatchSize, each in its own transaction,
+// and the work is safe to repeat.
+func (cs *CustomerService) RotateEncryptionKeys(ctx context.Context) (KeyRotationReport, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.RotateEncryptionKeys")
+	defer span.End()
+
+	report, err := cs.rewrapCustomers(ctx, false)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to rotate encryption keys", err)
+		return report, err
+	}
+
+	logger.FromContext(ctx).Info("Encryption keys rotated",
+		zap.Int("scanned", report.Scanned),
+		zap.Int("updated", report.Updated),
+		zap.Int("conflicts", len(report.Conflicts)))
+	return report, nil
+}
+
+// EncryptLegacyCustomers encrypts and indexes the rows still holding
+// plaintext from before encryption was enabled, leaving the others alone.
+// Duplicate checks and document lookups only see indexed rows, so the server
+// runs it in the background once it is up.
+func (cs *CustomerService) EncryptLegacyCustomers(ctx context.Context) (KeyRotationReport, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.EncryptLegacyCustomers")
+	defer span.End()
+
+	report, err := cs.rewrapCustomers(ctx, true)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to encrypt legacy customers", err)
+		return report, err
+	}
+
+	if report.Updated > 0 || len(report.Conflicts) > 0 {
+		logger.FromContext(ctx).Info("Legacy customers encrypted",
+			zap.Int("updated", report.Updated),
+			zap.Stringers("conflicts", report.Conflicts))
+	}
+	return report, nil
+}
+
+// rewrapCustomers re-wraps the sensitive columns of every customer, or with
+// legacyOnly only of those still holding plaintext. On error the report
+// covers the batches already committed.
+func (cs *CustomerService) rewrapCustomers(ctx context.Context, legacyOnly bool) (KeyRotationReport, error) {
+	report := KeyRotationReport{Conflicts: []uuid.UUID{}}
+
+	err := rewrapBatches(ctx, cs.tx, &report,
+		func(q sqlc.Querier, after uuid.UUID) ([]sqlc.ListCustomerPFSensitiveDataRow, error) {
+			return q.ListCustomerPFSensitiveData(ctx, sqlc.ListCustomerPFSensitiveDataParams{After: after, BatchSize: rewrapBatchSize})
+		},
+		func(q sqlc.Querier, row sqlc.ListCustomerPFSensitiveDataRow) (uuid.UUID, rewrapOutcome, error) {
+			if legacyOnly && fieldcrypt.IsEncrypted(row.Cpf) && fieldcrypt.IsEncrypted(row.BirthDate) {
+				return row.CustomerID, rewrapSkipped, nil
+			}
+			outcome, err := cs.rewrapPF(ctx, q, row)
+			return row.CustomerID, outcome, err
+		})
+	if err != nil {
+		return report, err
+	}
+
+	err = rewrapBatches(ctx, cs.tx, &report,
+		func(q sqlc.Querier, after uuid.UUID) ([]sqlc.ListCustomerPJSensitiveDataRow, error) {
+			return q.ListCustomerPJSensitiveData(ctx, sqlc.ListCustomerPJSensitiveDataParams{After: after, BatchSize: rewrapBatchSize})
+		},
+		func(q sqlc.Querier, row sqlc.ListCustomerPJSensitiveDataRow) (uuid.UUID, rewrapOutcome, error) {
+			if legacyOnly && fieldcrypt.IsEncrypted(row.Cnpj) {
+				return row.CustomerID, rewrapSkipped, nil
+			}
+			outcome, err := cs.rewrapPJ(ctx, q, row)
+			return row.CustomerID, outcome, err
+		})
+	return report, err
+}
+
+// rewrapBatches pages through the rows returned by list in customer id
+// order, calling rewrap on each inside one transaction per page. A page's
+// outcomes are added to report once it commits.
+func rewrapBatches[T any](ctx context.Context, tx Transactor, report *KeyRotationReport,
+	list func(q sqlc.Querier, after uuid.UUID) ([]T, error),
+	rewrap func(q sqlc.Querier, row T) (uuid.UUID, rewrapOutcome, error),
+) error {
+	for after := uuid.Nil; ; {
+		var batch KeyRotationReport
+		var last uuid.UUID
+		var n int
+		err := tx.WithTx(ctx, func(q sqlc.Querier) error {
+			batch = KeyRotationReport{}
+			rows, err := list(q, after)
+			if err != nil {
+				return err
+			}
+			n = len(rows)
+			for _, row := range rows {
+				id, outcome, err := rewrap(q, row)
+				if err != nil {
+					return fmt.Errorf("customer %s: %w", id, err)
+				}
+				batch.add(id, outcome)
+				last = id
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		report.Scanned += batch.Scanned
+		report.Updated += batch.Updated
+		report.Conflicts = append(report.Conflicts, batch.Conflicts...)
+		if n < rewrapBatchSize {
+			return nil
+		}
+		after = last
+	}
+}
+
+func (cs *CustomerService) rewrapPF(ctx context.Context, q sqlc.Querier, row sqlc.ListCustomerPFSensitiveDataRow) (rewrapOutcome, error) {
+	cpf, cpfChanged, err := cs.cipher.Rewrap(ctx, row.Cpf, columnAD(row.CustomerID, columnCpf))
+	if err != nil {
+		return 0, err
+	}
+	birthDate, birthDateChanged, err := cs.cipher.Rewrap(ctx, row.BirthDate, columnAD(row.CustomerID, columnBirthDate))
+	if err != nil {
+		return 0, err
+	}
+	if !cpfChanged && !birthDateChanged {
+		return rewrapUnchanged, nil
+	}
+
+	plain, err := cs.cipher.Decrypt(ctx, row.Cpf, columnAD(row.CustomerID, columnCpf))
+	if err != nil {
+		return 0, err
+	}
+	index, err := cs.documentIndex(ctx, indexFieldCpf, plain)
+	if err != nil {
+		return 0, err
+	}
+	if taken, err := indexTaken(ctx, q.GetCustomerIDByCpfIndex, row.CustomerID, index); taken || err != nil {
+		return rewrapConflict, err
+	}
+	return rewrapUpdated, q.UpdateCustomerPFSensitiveData(ctx, sqlc.UpdateCustomerPFSensitiveDataParams{
+		CustomerID: row.CustomerID,
+		Cpf:        cpf,
+		CpfIndex:   index,
+		BirthDate:  birthDate,
+	})
+}
+
+func (cs *CustomerService) rewrapPJ(ctx context.Context, q sqlc.Querier, row sqlc.ListCustomerPJSensitiveDataRow) (rewrapOutcome, error) {
+	cnpj, changed, err := cs.cipher.Rewrap(ctx, row.Cnpj, columnAD(row.CustomerID, columnCnpj))
+	if err != nil {
+		return 0, err
+	}
+	if !changed {
+		return rewrapUnchanged, nil
+	}
+
+	plain, err := cs.cipher.Decrypt(ctx, row.Cnpj, columnAD(row.CustomerID, columnCnpj))
+	if err != nil {
+		return 0, err
+	}
+	index, err := cs.documentIndex(ctx, indexFieldCnpj, plain)
+	if err != nil {
+		return 0, err
+	}
+	if taken, err := indexTaken(ctx, q.GetCustomerIDByCnpjIndex, row.CustomerID, index); taken || err != nil {
+		return rewrapConflict, err
+	}
+	return rewrapUpdated, q.UpdateCustomerPJSensitiveData(ctx, sqlc.UpdateCustomerPJSensitiveDataParams{
+		CustomerID: row.CustomerID,
+		Cnpj:       cnpj,
+		CnpjIndex:  index,
+	})
+}
+
+// indexTaken reports whether the blind index of customer id already belongs
+// to another customer, looking it up with lookup.
+func indexTaken(ctx context.Context, lookup func(context.Context, pgtype.Text) (uuid.UUID, error), id uuid.UUID, index pgtype.Text) (bool, error) {
+	if !index.Valid {
+		return false, nil
+	}
+	owner, err := lookup(ctx, index)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return owner != id, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCustomerNotFound   = errors.New("customer not found")
+	ErrCustomerAnonymized = errors.New("customer is already anonymized")
+)
+
+// ExportCustomer gathers everything held about a customer for a data access
+// request. The export is itself audited, and the audit trail returned already
+// includes that entry.
+func (cs *CustomerService) ExportCustomer(ctx context.Context, id, actorID uuid.UUID) (customer.CustomerExport, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ExportCustomer")
+	defer span.End()
+
+	var export customer.CustomerExport
+	err := cs.tx.WithTx(ctx, func(q sqlc.Querier) error {
+		data, err := q.GetCustomerByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrCustomerNotFound
+			}
+			return err
+		}
+		if err := cs.openCustomer(ctx, &data); err != nil {
+			return err
+		}
+		addrs, err := q.GetCustomerAddresses(ctx, id)
+		if err != nil {
+			return err
+		}
+		contacts, err := q.ListCustomerContacts(ctx, id)
+		if err != nil {
+			return err
+		}
+		services, err := q.GetServicesByCustomerID(ctx, id)
+		if err != nil {
+			return err
+		}
+		extras, err := loadCustomerExtras(ctx, q, []uuid.UUID{id})
+		if err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, q, actorID, AuditCustomerExported, AuditEntityCustomer, id.String(), nil); err != nil {
+			return err
+		}
+		events, err := q.ListAuditEventsByEntity(ctx, sqlc.ListAuditEventsByEntityParams{
+			EntityType: AuditEntityCustomer,
+			EntityID:   id.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		export = customer.CustomerExport{
+			ExportedAt: time.Now().UTC(),
+			Customer:   customer.MapCustomer(data, addrs, contacts),
+			Services:   services,
+			AuditTrail: customer.MapAuditEvents(events),
+		}
+		extras.apply(&export.Customer)
+		if export.Services == nil {
+			export.Services = []sqlc.Service{}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrCustomerNotFound) {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to export customer", err, zap.String("customer_id", id.String()))
+	}
+	return export, err
+}
+
+// AnonymizeCustomer erases the personal data of a customer: contact details,
+// CPF/CNPJ and birth date (left blank), name, addresses, contacts, notes and custom field
+// values. The customer row, its tags and its services stay, so financial
+// records are kept for legal retention and segments still add up, and the
+// action is audited in the same transaction.
+func (cs *CustomerService) AnonymizeCustomer(ctx context.Context, id, actorID uuid.UUID) (customer.CustomerResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.AnonymizeCustomer")
+	defer span.End()
+
+	var resp customer.CustomerResponse
+	err := cs.tx.WithTx(ctx, func(q sqlc.Querier) error {
+		data, err := q.GetCustomerByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrCustomerNotFound
+			}
+			return err
+		}
+		if data.AnonymizedAt.Valid {
+			return ErrCustomerAnonymized
+		}
+
+		addrs, err := q.GetCustomerAddresses(ctx, id)
+		if err != nil {
+			return err
+		}
+		services, err := q.CountServicesByCustomerID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := q.AnonymizeCustomer(ctx, id); err != nil {
+			return err
+		}
+		switch data.Type {
+		case sqlc.CustomerTypePF:
+			var params sqlc.AnonymizeCustomerPFParams
+			if params, err = cs.anonymizedPFParams(ctx, id); err == nil {
+				err = q.AnonymizeCustomerPF(ctx, params)
+			}
+		case sqlc.CustomerTypePJ:
+			var params sqlc.AnonymizeCustomerPJParams
+			if params, err = cs.anonymizedPJParams(ctx, id); err == nil {
+				err = q.AnonymizeCustomerPJ(ctx, params)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if err := q.DeleteCustomerAddresses(ctx, id); err != nil {
+			return err
+		}
+		contacts, err := q.DeleteCustomerContacts(ctx, id)
+		if err != nil {
+			return err
+		}
+		notes, err := q.DeleteCustomerNotes(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, q, actorID, AuditCustomerAnonymized, AuditEntityCustomer, id.String(), map[string]any{
+			"type":              data.Type,
+			"addresses_removed": len(addrs),
+			"contacts_removed":  contacts,
+			"notes_removed":     notes,
+			"services_retained": services,
+		}); err != nil {
+			return err
+		}
+
+		data, err = q.GetCustomerByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := cs.openCustomer(ctx, &data); err != nil {
+			return err
+		}
+		extras, err := loadCustomerExtras(ctx, q, []uuid.UUID{id})
+		if err != nil {
+			return err
+		}
+		resp = customer.MapCustomer(data, nil, nil)
+		extras.apply(&resp)
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrCustomerNotFound) && !errors.Is(err, ErrCustomerAnonymized) {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to anonymize customer", err, zap.String("customer_id", id.String()))
+		return customer.CustomerResponse{}, err
+	}
+	if err == nil {
+		logger.FromContext(ctx).Info("Customer anonymized", zap.String("customer_id", id.String()))
+	}
+	return resp, err
+}
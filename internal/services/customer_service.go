@@ -6,92 +6,96 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
 	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/fieldcrypt"
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
+	"github.com/josevitorrodriguess/client-manager/internal/metrics"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
 	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
 	"go.uber.org/zap"
 )
 
 var (
-	ErrDuplicatedData = errors.New("cpf, phone or email already exists")
+	ErrDuplicatedData      = errors.New("cpf, phone or email already exists")
+	ErrCustomerHasServices = errors.New("customer has services and cannot be deleted")
 )
 
+// CustomerService stores CPF, CNPJ and birth dates encrypted with cipher; see
+// customer_crypto.go.
 type CustomerService struct {
-	pool    *pgxpool.Pool
-	queries *sqlc.Queries
+	queries sqlc.Querier
+	tx      Transactor
+	cipher  *fieldcrypt.Cipher
 }
 
-func NewCustomerService(pool *pgxpool.Pool) *CustomerService {
+func NewCustomerService(queries sqlc.Querier, tx Transactor, cipher *fieldcrypt.Cipher) *CustomerService {
 	return &CustomerService{
-		pool:    pool,
-		queries: sqlc.New(pool),
+		queries: queries,
+		tx:      tx,
+		cipher:  cipher,
 	}
 }
 
 func (cs *CustomerService) CreatePFCustomer(ctx context.Context, customer customer.CustomerPFRequest) (uuid.UUID, error) {
-	args := sqlc.CreateCustomerPFParams{
-		Type:        sqlc.CustomerType(customer.Type),
-		Email:       customer.Email,
-		Phone:       customer.Phone,
-		Cpf:         customer.Cpf,
-		Name:        customer.Name,
-		BirthDate:   customer.BirthDate,
-		AddressType: customer.AddressType,
-		Street:      customer.Street,
-		Complement:  customer.Complement,
-		State:       customer.State,
-		City:        customer.City,
-		Cep:         customer.Cep,
-	}
-
-	id, err := cs.queries.CreateCustomerPF(ctx, args)
+	ctx, span := tracing.Start(ctx, "CustomerService.CreatePFCustomer")
+	defer span.End()
+
+	params, err := cs.pfParams(ctx, customer)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to encrypt PF customer data", err)
+		return uuid.UUID{}, err
+	}
+
+	id, err := cs.queries.CreateCustomerPF(ctx, params)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return uuid.UUID{}, ErrDuplicatedData
 		}
-		logger.Error("Failed to create PF customer", err,
-			zap.String("name", customer.Name),
-			zap.String("email", customer.Email))
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to create PF customer", err)
 		return uuid.UUID{}, err
 	}
 
+	metrics.CustomersCreated.WithLabelValues(string(sqlc.CustomerTypePF)).Inc()
 	return id, nil
 }
 
 func (cs *CustomerService) CreatePJCustomer(ctx context.Context, customer customer.CustomerPJRequest) (uuid.UUID, error) {
-	args := sqlc.CreateCustomerPJParams{
-		Type:        sqlc.CustomerType(customer.Type),
-		Email:       customer.Email,
-		Phone:       customer.Phone,
-		Cnpj:        customer.Cnpj,
-		CompanyName: customer.CompanyName,
-		AddressType: customer.AddressType,
-		Street:      customer.Street,
-		Complement:  customer.Complement,
-		State:       customer.State,
-		City:        customer.City,
-		Cep:         customer.Cep,
-	}
-
-	id, err := cs.queries.CreateCustomerPJ(ctx, args)
+	ctx, span := tracing.Start(ctx, "CustomerService.CreatePJCustomer")
+	defer span.End()
+
+	params, err := cs.pjParams(ctx, customer)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to encrypt PJ customer data", err)
+		return uuid.UUID{}, err
+	}
+
+	id, err := cs.queries.CreateCustomerPJ(ctx, params)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return uuid.UUID{}, ErrDuplicatedData
 		}
-		logger.Error("Failed to create PJ customer", err,
-			zap.String("company_name", customer.CompanyName),
-			zap.String("email", customer.Email))
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to create PJ customer", err)
 		return uuid.UUID{}, err
 	}
 
+	metrics.CustomersCreated.WithLabelValues(string(sqlc.CustomerTypePJ)).Inc()
 	return id, nil
 }
 
 func (cs *CustomerService) AddAddressToCustomer(ctx context.Context, address customer.AddAddressRequest) (int32, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.AddAddressToCustomer")
+	defer span.End()
+
 	args := sqlc.AddAddressToCustomerParams{
 		CustomerID:  address.CustomerID,
 		AddressType: address.AddressType,
@@ -104,8 +108,13 @@ func (cs *CustomerService) AddAddressToCustomer(ctx context.Context, address cus
 	}
 
 	id, err := cs.queries.AddAddressToCustomer(ctx, args)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return 0, ErrCustomerNotFound
+	}
 	if err != nil {
-		logger.Error("Failed to add address to customer", err,
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to add address to customer", err,
 			zap.String("customer_id", address.CustomerID.String()))
 		return 0, err
 	}
@@ -114,47 +123,460 @@ func (cs *CustomerService) AddAddressToCustomer(ctx context.Context, address cus
 }
 
 func (cs *CustomerService) GetCustomerDetails(ctx context.Context, id uuid.UUID) (customer.CustomerResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.GetCustomerDetails")
+	defer span.End()
 
 	data, err := cs.queries.GetCustomerByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return customer.CustomerResponse{}, ErrCustomerNotFound
+	}
 	if err != nil {
-		logger.Error("Failed to get customer with id:", err)
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to get customer with id:", err)
+		return customer.CustomerResponse{}, err
+	}
+	if err := cs.openCustomer(ctx, &data); err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to decrypt customer data", err, zap.String("customer_id", id.String()))
 		return customer.CustomerResponse{}, err
 	}
 
 	adrs, err := cs.queries.GetCustomerAddresses(ctx, id)
 	if err != nil {
-		logger.Error("failed so search address for this customer", err)
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("failed so search address for this customer", err)
+		return customer.CustomerResponse{}, err
+	}
+
+	contacts, err := cs.queries.ListCustomerContacts(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to list customer contacts", err, zap.String("customer_id", id.String()))
+		return customer.CustomerResponse{}, err
+	}
+
+	extras, err := loadCustomerExtras(ctx, cs.queries, []uuid.UUID{id})
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to load customer notes and tags", err, zap.String("customer_id", id.String()))
 		return customer.CustomerResponse{}, err
 	}
 
-	customerResponse := customer.MapCustomer(data, adrs)
+	customerResponse := customer.MapCustomer(data, adrs, contacts)
+	extras.apply(&customerResponse)
 
 	return customerResponse, nil
 }
 
-func (cs *CustomerService) GetAllCustomersDetails(ctx context.Context) ([]customer.CustomerResponse, error) {
+// GetAllCustomersDetails lists the customers selected by opts, in id order.
+// The zero ListOptions lists every customer.
+func (cs *CustomerService) GetAllCustomersDetails(ctx context.Context, opts customer.ListOptions) ([]customer.CustomerResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.GetAllCustomersDetails")
+	defer span.End()
+
+	params := sqlc.GetAllCustomersParams{Tags: customer.NormalizeTags(opts.Tags), After: opts.After}
+	if opts.Limit > 0 {
+		params.Limit = pgtype.Int4{Int32: int32(opts.Limit), Valid: true}
+	}
+	rows, err := cs.queries.GetAllCustomers(ctx, params)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := cs.queries.GetAllCustomers(ctx)
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.CustomerID)
+	}
+	contacts, err := cs.queries.ListContactsByCustomerIDs(ctx, ids)
 	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to list customer contacts", err)
+		return nil, err
+	}
+	contactsByCustomer := make(map[uuid.UUID][]sqlc.Contact)
+	for _, c := range contacts {
+		contactsByCustomer[c.CustomerID] = append(contactsByCustomer[c.CustomerID], c)
+	}
+	extras, err := loadCustomerListExtras(ctx, cs.queries, ids)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to load customer notes and tags", err)
 		return nil, err
 	}
 
-	var customers []customer.CustomerResponse
+	customers := make([]customer.CustomerResponse, 0, len(rows))
 	for _, row := range rows {
+		if err := cs.openCustomerListRow(ctx, &row); err != nil {
+			tracing.RecordError(span, err)
+			logger.FromContext(ctx).Error("Failed to decrypt customer data", err, zap.String("customer_id", row.CustomerID.String()))
+			return nil, err
+		}
 		customerResponse, err := customer.MapToCustomerResponse(row)
 		if err != nil {
 			return nil, fmt.Errorf("failed to map customer data: %w", err)
 		}
+		customerResponse.Contacts = customer.MapContacts(contactsByCustomer[row.CustomerID])
+		extras.apply(customerResponse)
 		customers = append(customers, *customerResponse)
 	}
 
 	return customers, nil
 }
 
+// FindCustomerByCpf looks a PF customer up by CPF through its blind index,
+// with or without punctuation.
+func (cs *CustomerService) FindCustomerByCpf(ctx context.Context, cpf string) (customer.CustomerResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.FindCustomerByCpf")
+	defer span.End()
+
+	index, err := cs.documentIndex(ctx, indexFieldCpf, cpf)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return customer.CustomerResponse{}, err
+	}
+	return cs.findByIndex(ctx, index, cs.queries.GetCustomerIDByCpfIndex)
+}
+
+// FindCustomerByCnpj looks a PJ customer up by CNPJ through its blind index,
+// with or without punctuation.
+func (cs *CustomerService) FindCustomerByCnpj(ctx context.Context, cnpj string) (customer.CustomerResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.FindCustomerByCnpj")
+	defer span.End()
+
+	index, err := cs.documentIndex(ctx, indexFieldCnpj, cnpj)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return customer.CustomerResponse{}, err
+	}
+	return cs.findByIndex(ctx, index, cs.queries.GetCustomerIDByCnpjIndex)
+}
+
+func (cs *CustomerService) findByIndex(ctx context.Context, index pgtype.Text, lookup func(context.Context, pgtype.Text) (uuid.UUID, error)) (customer.CustomerResponse, error) {
+	if !index.Valid {
+		return customer.CustomerResponse{}, ErrCustomerNotFound
+	}
+	id, err := lookup(ctx, index)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return customer.CustomerResponse{}, ErrCustomerNotFound
+		}
+		logger.FromContext(ctx).Error("Failed to look customer up by document", err)
+		return customer.CustomerResponse{}, err
+	}
+	return cs.GetCustomerDetails(ctx, id)
+}
+
+// DeleteCustomer removes the customer together with its addresses, contacts
+// and PF/PJ details in a single transaction. Customers with services are kept, since
+// those records must not disappear with the customer.
 func (cs *CustomerService) DeleteCustomer(ctx context.Context, id uuid.UUID) error {
-	err := cs.queries.DeleteCustomer(ctx, id)
+	ctx, span := tracing.Start(ctx, "CustomerService.DeleteCustomer")
+	defer span.End()
+
+	err := cs.tx.WithTx(ctx, func(q sqlc.Querier) error {
+		count, err := q.CountServicesByCustomerID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrCustomerHasServices
+		}
+
+		if err := q.DeleteCustomerAddresses(ctx, id); err != nil {
+			return err
+		}
+		if _, err := q.DeleteCustomerContacts(ctx, id); err != nil {
+			return err
+		}
+		if _, err := q.DeleteCustomerNotes(ctx, id); err != nil {
+			return err
+		}
+		if _, err := q.DeleteCustomerTags(ctx, id); err != nil {
+			return err
+		}
+		if err := q.DeleteCustomerPF(ctx, id); err != nil {
+			return err
+		}
+		if err := q.DeleteCustomerPJ(ctx, id); err != nil {
+			return err
+		}
+		return q.DeleteCustomer(ctx, id)
+	})
+	if err != nil && !errors.Is(err, ErrCustomerHasServices) {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to delete customer", err, zap.String("customer_id", id.String()))
+	}
+	return err
+}
+
+// ImportCustomers validates every row, rejects duplicates inside the file and
+// against the database, and then inserts the valid rows either in a single
+// transaction (all or nothing) or in independently committed batches.
+func (cs *CustomerService) ImportCustomers(ctx context.Context, rows []customer.ImportRow, opts customer.ImportOptions) (customer.ImportReport, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ImportCustomers")
+	defer span.End()
+
+	opts, err := opts.Normalize()
+	if err != nil {
+		return customer.ImportReport{}, err
+	}
+
+	lang := i18n.FromContext(ctx)
+	report := customer.ImportReport{
+		DryRun: opts.DryRun,
+		Mode:   opts.Mode,
+		Total:  len(rows),
+		Rows:   make([]customer.ImportRowResult, len(rows)),
+	}
+
+	seen := make(map[string]int)
+	var pending []int
+	for i, row := range rows {
+		result := customer.ImportRowResult{Line: row.Line, Type: row.Type}
+
+		if errs := row.Validate(lang); len(errs) > 0 {
+			result.Status = customer.ImportStatusInvalid
+			result.Errors = errs
+			report.Rows[i] = result
+			continue
+		}
+
+		dupErrs := make(map[string]string)
+		for field, value := range row.UniqueKeys() {
+			key := field + ":" + value
+			if line, ok := seen[key]; ok {
+				dupErrs[field] = i18n.T(lang, "import.duplicate_in_file", field, line)
+				continue
+			}
+			seen[key] = row.Line
+		}
+
+		if len(dupErrs) == 0 {
+			conflicts, err := cs.findConflicts(ctx, row)
+			if err != nil {
+				return customer.ImportReport{}, err
+			}
+			dupErrs = conflicts
+		}
+
+		if len(dupErrs) > 0 {
+			result.Status = customer.ImportStatusDuplicate
+			result.Errors = dupErrs
+			report.Rows[i] = result
+			continue
+		}
+
+		result.Status = customer.ImportStatusValid
+		report.Rows[i] = result
+		pending = append(pending, i)
+	}
+
+	report.Valid = len(pending)
+	report.Failed = report.Total - report.Valid
+
+	if opts.DryRun || len(pending) == 0 {
+		return report, nil
+	}
+
+	if opts.Mode == customer.ImportModeTransaction && report.Failed > 0 {
+		for _, i := range pending {
+			report.Rows[i].Status = customer.ImportStatusSkipped
+		}
+		return report, nil
+	}
+
+	batchSize := opts.BatchSize
+	if opts.Mode == customer.ImportModeTransaction {
+		batchSize = len(pending)
+	}
+
+	for start := 0; start < len(pending); start += batchSize {
+		end := min(start+batchSize, len(pending))
+		if err := cs.importBatch(ctx, rows, pending[start:end], &report); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+func (cs *CustomerService) findConflicts(ctx context.Context, row customer.ImportRow) (map[string]string, error) {
+	keys := row.UniqueKeys()
+	cpfIndex, err := cs.documentIndex(ctx, indexFieldCpf, keys["cpf"])
+	if err != nil {
+		return nil, err
+	}
+	cnpjIndex, err := cs.documentIndex(ctx, indexFieldCnpj, keys["cnpj"])
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := cs.queries.GetCustomerConflicts(ctx, sqlc.GetCustomerConflictsParams{
+		Email:     keys["email"],
+		Phone:     keys["phone"],
+		CpfIndex:  cpfIndex,
+		CnpjIndex: cnpjIndex,
+	})
 	if err != nil {
+		logger.FromContext(ctx).Error("Failed to check customer conflicts", err, zap.Int("line", row.Line))
+		return nil, err
+	}
+
+	lang := i18n.FromContext(ctx)
+	errs := make(map[string]string)
+	if conflicts.EmailExists {
+		errs["email"] = i18n.T(lang, "import.already_registered", "email")
+	}
+	if conflicts.PhoneExists {
+		errs["phone"] = i18n.T(lang, "import.already_registered", "phone")
+	}
+	if conflicts.CpfExists && keys["cpf"] != "" {
+		errs["cpf"] = i18n.T(lang, "import.already_registered", "cpf")
+	}
+	if conflicts.CnpjExists && keys["cnpj"] != "" {
+		errs["cnpj"] = i18n.T(lang, "import.already_registered", "cnpj")
+	}
+	return errs, nil
+}
+
+// importBatch inserts the given rows inside one transaction. A database error
+// on any row rolls the whole batch back and is reported on that row; only
+// failures to open or commit the transaction are returned as errors.
+func (cs *CustomerService) importBatch(ctx context.Context, rows []customer.ImportRow, batch []int, report *customer.ImportReport) error {
+	var ids []uuid.UUID
+	failed := -1
+
+	err := cs.tx.WithTx(ctx, func(q sqlc.Querier) error {
+		ids = make([]uuid.UUID, len(batch))
+		failed = -1
+		for n, i := range batch {
+			row := rows[i]
+
+			id, err := cs.createImportRow(ctx, q, row)
+			if err != nil {
+				failed = i
+				return err
+			}
+			ids[n] = id
+		}
+		return nil
+	})
+
+	if failed >= 0 {
+		logger.FromContext(ctx).Error("Failed to import customer row", err, zap.Int("line", rows[failed].Line))
+		report.Rows[failed].Status = customer.ImportStatusFailed
+		report.Rows[failed].Errors = map[string]string{"row": importErrorMessage(i18n.FromContext(ctx), err)}
+		report.Failed++
+		report.Valid--
+		for _, j := range batch {
+			if j != failed {
+				report.Rows[j].Status = customer.ImportStatusRolledBack
+			}
+		}
+		return nil
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to commit import transaction", err)
 		return err
 	}
+
+	for n, i := range batch {
+		report.Rows[i].Status = customer.ImportStatusCreated
+		report.Rows[i].CustomerID = ids[n]
+		report.Created++
+		metrics.CustomersCreated.WithLabelValues(rows[i].Type).Inc()
+	}
 	return nil
 }
+
+func (cs *CustomerService) createImportRow(ctx context.Context, q sqlc.Querier, row customer.ImportRow) (uuid.UUID, error) {
+	if row.PF != nil {
+		params, err := cs.pfParams(ctx, *row.PF)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+		return q.CreateCustomerPF(ctx, params)
+	}
+	params, err := cs.pjParams(ctx, *row.PJ)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	return q.CreateCustomerPJ(ctx, params)
+}
+
+func importErrorMessage(lang string, err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return i18n.T(lang, "error.duplicated_data")
+	}
+	return i18n.T(lang, "import.insert_failed")
+}
+
+// customerExtras are the notes and tags of a set of customers, loaded in one
+// query each. A single customer comes with every note; a list only with the
+// latest note and the count of each customer.
+type customerExtras struct {
+	notes  map[uuid.UUID][]sqlc.ListNotesByCustomerIDsRow
+	latest map[uuid.UUID]sqlc.ListLatestNotesByCustomerIDsRow
+	tags   map[uuid.UUID][]sqlc.CustomerTag
+}
+
+// loadCustomerExtras loads every note and tag of ids.
+func loadCustomerExtras(ctx context.Context, q sqlc.Querier, ids []uuid.UUID) (customerExtras, error) {
+	extras := customerExtras{notes: make(map[uuid.UUID][]sqlc.ListNotesByCustomerIDsRow)}
+	notes, err := q.ListNotesByCustomerIDs(ctx, ids)
+	if err != nil {
+		return customerExtras{}, err
+	}
+	for _, n := range notes {
+		extras.notes[n.CustomerID] = append(extras.notes[n.CustomerID], n)
+	}
+	if extras.tags, err = loadTags(ctx, q, ids); err != nil {
+		return customerExtras{}, err
+	}
+	return extras, nil
+}
+
+// loadCustomerListExtras loads the latest note, the note count and the tags
+// of ids, so a page of customers does not carry every note.
+func loadCustomerListExtras(ctx context.Context, q sqlc.Querier, ids []uuid.UUID) (customerExtras, error) {
+	extras := customerExtras{latest: make(map[uuid.UUID]sqlc.ListLatestNotesByCustomerIDsRow)}
+	latest, err := q.ListLatestNotesByCustomerIDs(ctx, ids)
+	if err != nil {
+		return customerExtras{}, err
+	}
+	for _, n := range latest {
+		extras.latest[n.CustomerID] = n
+	}
+	if extras.tags, err = loadTags(ctx, q, ids); err != nil {
+		return customerExtras{}, err
+	}
+	return extras, nil
+}
+
+func loadTags(ctx context.Context, q sqlc.Querier, ids []uuid.UUID) (map[uuid.UUID][]sqlc.CustomerTag, error) {
+	tags, err := q.ListTagsByCustomerIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byCustomer := make(map[uuid.UUID][]sqlc.CustomerTag)
+	for _, t := range tags {
+		byCustomer[t.CustomerID] = append(byCustomer[t.CustomerID], t)
+	}
+	return byCustomer, nil
+}
+
+// apply fills the notes and tags of resp.
+func (e customerExtras) apply(resp *customer.CustomerResponse) {
+	if e.notes != nil {
+		resp.Notes = customer.MapNotes(e.notes[resp.ID])
+		resp.NoteCount = int64(len(resp.Notes))
+		if len(resp.Notes) > 0 {
+			resp.LatestNote = &resp.Notes[0]
+		}
+	} else if n, ok := e.latest[resp.ID]; ok {
+		resp.NoteCount = n.NoteCount
+		resp.LatestNote = customer.MapLatestNote(n)
+	}
+	resp.Tags = customer.MapTags(e.tags[resp.ID])
+}
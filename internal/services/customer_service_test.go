@@ -0,0 +1,528 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/memstore"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/fieldcrypt"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+	"github.com/josevitorrodriguess/client-manager/internal/testutil"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/service"
+)
+
+func newCustomerService(t *testing.T) (*services.CustomerService, *memstore.Store) {
+	t.Helper()
+	store := memstore.New()
+	return services.NewCustomerService(store, store, testutil.Cipher(t)), store
+}
+
+func pfRequest() customer.CustomerPFRequest {
+	return customer.CustomerPFRequest{
+		Type:        "PF",
+		Email:       "maria@example.com",
+		Phone:       "11 91234-5678",
+		Cpf:         "123.456.789-09",
+		Name:        "Maria Silva",
+		BirthDate:   pgtype.Date{Time: time.Date(1990, 5, 10, 0, 0, 0, 0, time.UTC), Valid: true},
+		AddressType: "home",
+		Street:      "Rua A",
+		Number:      "10",
+		State:       "SP",
+		City:        "Sao Paulo",
+		Cep:         "01001-000",
+	}
+}
+
+func pjRequest() customer.CustomerPJRequest {
+	return customer.CustomerPJRequest{
+		Type:        "PJ",
+		Email:       "contato@acme.com",
+		Phone:       "11 93333-4444",
+		Cnpj:        "12.345.678/0001-95",
+		CompanyName: "Acme Ltda",
+		AddressType: "work",
+		Street:      "Av B",
+		Number:      "1",
+		State:       "SP",
+		City:        "Sao Paulo",
+		Cep:         "01001000",
+	}
+}
+
+func TestCreatePFCustomer(t *testing.T) {
+	cs, _ := newCustomerService(t)
+	ctx := context.Background()
+
+	id, err := cs.CreatePFCustomer(ctx, pfRequest())
+	if err != nil {
+		t.Fatalf("CreatePFCustomer: %v", err)
+	}
+
+	got, err := cs.GetCustomerDetails(ctx, id)
+	if err != nil {
+		t.Fatalf("GetCustomerDetails: %v", err)
+	}
+	if got.PfName != "Maria Silva" || got.Cpf != "123.456.789-09" {
+		t.Errorf("unexpected customer details: %+v", got)
+	}
+	if len(got.Addresses) != 1 || got.Addresses[0].Number != "10" {
+		t.Errorf("expected the address number to be stored, got %+v", got.Addresses)
+	}
+
+	if _, err := cs.CreatePFCustomer(ctx, pfRequest()); !errors.Is(err, services.ErrDuplicatedData) {
+		t.Errorf("expected ErrDuplicatedData, got %v", err)
+	}
+}
+
+func TestCustomerPhoneIsStoredInE164(t *testing.T) {
+	cs, _ := newCustomerService(t)
+	ctx := context.Background()
+
+	id, err := cs.CreatePFCustomer(ctx, pfRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := cs.GetCustomerDetails(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Phone != "+5511912345678" {
+		t.Errorf("phone = %q, want +5511912345678", got.Phone)
+	}
+
+	pj := pjRequest()
+	pj.Phone = "(11) 9 1234-5678"
+	if _, err := cs.CreatePJCustomer(ctx, pj); !errors.Is(err, services.ErrDuplicatedData) {
+		t.Errorf("same phone in another format: expected ErrDuplicatedData, got %v", err)
+	}
+}
+
+func TestGetAllCustomersDetails(t *testing.T) {
+	cs, _ := newCustomerService(t)
+	ctx := context.Background()
+
+	if _, err := cs.CreatePFCustomer(ctx, pfRequest()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cs.CreatePJCustomer(ctx, pjRequest()); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := cs.GetAllCustomersDetails(ctx, customer.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetAllCustomersDetails: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 customers, got %d", len(all))
+	}
+	for _, c := range all {
+		if len(c.Addresses) != 1 {
+			t.Errorf("customer %s: expected 1 address, got %d", c.ID, len(c.Addresses))
+		}
+	}
+}
+
+func TestDeleteCustomer(t *testing.T) {
+	cs, store := newCustomerService(t)
+	ss := services.NewServiceService(store)
+	ctx := context.Background()
+
+	keep, err := cs.CreatePFCustomer(ctx, pfRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ss.CreateService(ctx, service.ServiceRequest{CustomerID: keep, TypeProduct: "site", Description: "landing page"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.DeleteCustomer(ctx, keep); !errors.Is(err, services.ErrCustomerHasServices) {
+		t.Errorf("expected ErrCustomerHasServices, got %v", err)
+	}
+
+	drop, err := cs.CreatePJCustomer(ctx, pjRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.DeleteCustomer(ctx, drop); err != nil {
+		t.Fatalf("DeleteCustomer: %v", err)
+	}
+	if _, err := cs.GetCustomerDetails(ctx, drop); err == nil {
+		t.Error("expected deleted customer to be gone")
+	}
+}
+
+const importHeader = "type,email,phone,cpf,name,birth_date,cnpj,company_name,address_type,street,number,complement,state,city,cep\n"
+
+func parseImport(t *testing.T, body string) []customer.ImportRow {
+	t.Helper()
+	rows, err := customer.ParseImportCSV(strings.NewReader(importHeader + body))
+	if err != nil {
+		t.Fatalf("ParseImportCSV: %v", err)
+	}
+	return rows
+}
+
+func TestImportCustomers(t *testing.T) {
+	validRows := "" +
+		"PF,ana@example.com,11 91111-1111,111.444.777-35,Ana Souza,1985-01-02,,,home,Rua 1,1,,SP,Sao Paulo,01001-000\n" +
+		"PJ,loja@example.com,11 92222-2222,,,,11.222.333/0001-81,Loja Azul,work,Rua 2,2,,SP,Sao Paulo,01001-000\n"
+	mixedRows := validRows +
+		"PF,ana2@example.com,11 91111-1111,529.982.247-25,Ana Lima,1986-03-04,,,home,Rua 3,3,,SP,Sao Paulo,01001-000\n" +
+		"PF,bad,1,1,Bo,,,,home,Rua 4,4,,SP,Sao Paulo,1\n"
+
+	tests := []struct {
+		name        string
+		body        string
+		opts        customer.ImportOptions
+		wantCreated int
+		wantFailed  int
+		wantStatus  []string
+	}{
+		{
+			name:       "dry run only validates",
+			body:       validRows,
+			opts:       customer.ImportOptions{DryRun: true},
+			wantStatus: []string{customer.ImportStatusValid, customer.ImportStatusValid},
+		},
+		{
+			name:        "transaction commits every valid row",
+			body:        validRows,
+			wantCreated: 2,
+			wantStatus:  []string{customer.ImportStatusCreated, customer.ImportStatusCreated},
+		},
+		{
+			name:       "transaction skips everything on any invalid row",
+			body:       mixedRows,
+			wantFailed: 2,
+			wantStatus: []string{
+				customer.ImportStatusSkipped, customer.ImportStatusSkipped,
+				customer.ImportStatusDuplicate, customer.ImportStatusInvalid,
+			},
+		},
+		{
+			name:        "batch commits the valid rows",
+			body:        mixedRows,
+			opts:        customer.ImportOptions{Mode: customer.ImportModeBatch, BatchSize: 1},
+			wantCreated: 2,
+			wantFailed:  2,
+			wantStatus: []string{
+				customer.ImportStatusCreated, customer.ImportStatusCreated,
+				customer.ImportStatusDuplicate, customer.ImportStatusInvalid,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs, _ := newCustomerService(t)
+			ctx := context.Background()
+
+			report, err := cs.ImportCustomers(ctx, parseImport(t, tt.body), tt.opts)
+			if err != nil {
+				t.Fatalf("ImportCustomers: %v", err)
+			}
+			if report.Created != tt.wantCreated || report.Failed != tt.wantFailed {
+				t.Errorf("created/failed = %d/%d, want %d/%d", report.Created, report.Failed, tt.wantCreated, tt.wantFailed)
+			}
+			for i, want := range tt.wantStatus {
+				if got := report.Rows[i].Status; got != want {
+					t.Errorf("row %d status = %q, want %q (errors %v)", i, got, want, report.Rows[i].Errors)
+				}
+			}
+
+			all, err := cs.GetAllCustomersDetails(ctx, customer.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(all) != tt.wantCreated {
+				t.Errorf("stored %d customers, want %d", len(all), tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestImportCustomersDetectsExistingData(t *testing.T) {
+	cs, _ := newCustomerService(t)
+	ctx := context.Background()
+
+	if _, err := cs.CreatePFCustomer(ctx, pfRequest()); err != nil {
+		t.Fatal(err)
+	}
+
+	rows := parseImport(t, "PF,maria@example.com,11 95555-5555,123.456.789-09,Maria Silva,1990-05-10,,,home,Rua A,10,,SP,Sao Paulo,01001-000\n")
+	report, err := cs.ImportCustomers(ctx, rows, customer.ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row := report.Rows[0]
+	if row.Status != customer.ImportStatusDuplicate {
+		t.Fatalf("status = %q, want duplicate", row.Status)
+	}
+	for _, field := range []string{"email", "cpf"} {
+		if _, ok := row.Errors[field]; !ok {
+			t.Errorf("expected a %s conflict, got %v", field, row.Errors)
+		}
+	}
+}
+
+func TestImportCustomersDetectsDuplicatesInFile(t *testing.T) {
+	cs, _ := newCustomerService(t)
+
+	rows := parseImport(t, ""+
+		"PF,ana@example.com,11 91111-1111,111.444.777-35,Ana Souza,1985-01-02,,,home,Rua 1,1,,SP,Sao Paulo,01001-000\n"+
+		"PF,ana2@example.com,11 93333-3333,11144477735,Ana Lima,1986-03-04,,,home,Rua 2,2,,SP,Sao Paulo,01001-000\n"+
+		"PJ,loja@example.com,11 92222-2222,,,,11.222.333/0001-81,Loja Azul,work,Rua 3,3,,SP,Sao Paulo,01001-000\n"+
+		"PJ,loja2@example.com,11 94444-4444,,,,11222333000181,Loja Verde,work,Rua 4,4,,SP,Sao Paulo,01001-000\n")
+	report, err := cs.ImportCustomers(context.Background(), rows, customer.ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, field := range map[int]string{1: "cpf", 3: "cnpj"} {
+		row := report.Rows[i]
+		if row.Status != customer.ImportStatusDuplicate {
+			t.Errorf("row %d status = %q, want duplicate", i, row.Status)
+		}
+		if _, ok := row.Errors[field]; !ok {
+			t.Errorf("row %d: expected a %s duplicate, got %v", i, field, row.Errors)
+		}
+	}
+}
+
+func TestCustomerDocumentsAreEncryptedAtRest(t *testing.T) {
+	cs, store := newCustomerService(t)
+	ctx := context.Background()
+
+	id, err := cs.CreatePFCustomer(ctx, pfRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := store.GetCustomerByID(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, v := range map[string]interface{}{"cpf": row.Cpf, "birth_date": row.BirthDate} {
+		if s, _ := v.(string); !fieldcrypt.IsEncrypted(s) {
+			t.Errorf("%s stored in plaintext: %v", name, v)
+		}
+	}
+
+	found, err := cs.FindCustomerByCpf(ctx, "12345678909")
+	if err != nil || found.ID != id || found.BirthDate != "1990-05-10" {
+		t.Errorf("FindCustomerByCpf = %+v, %v", found, err)
+	}
+
+	dup := pfRequest()
+	dup.Email, dup.Phone, dup.Cpf = "other@example.com", "11 90000-0000", "12345678909"
+	if _, err := cs.CreatePFCustomer(ctx, dup); !errors.Is(err, services.ErrDuplicatedData) {
+		t.Errorf("expected the blind index to reject a reformatted cpf, got %v", err)
+	}
+
+	// A ciphertext copied to another row must not decrypt there.
+	other := pfRequest()
+	other.Email, other.Phone, other.Cpf = "other@example.com", "11 90000-0000", "529.982.247-25"
+	otherID, err := cs.CreatePFCustomer(ctx, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.UpdateCustomerPFSensitiveData(ctx, sqlc.UpdateCustomerPFSensitiveDataParams{
+		CustomerID: otherID, Cpf: row.Cpf.(string), BirthDate: row.BirthDate.(string),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := cs.GetCustomerDetails(ctx, otherID); err == nil || !strings.Contains(err.Error(), "decrypting") {
+		t.Errorf("swapped cpf = %q, %v; want a decryption error", got.Cpf, err)
+	}
+}
+
+func TestRotateEncryptionKeys(t *testing.T) {
+	ctx := context.Background()
+	store := memstore.New()
+	kf, err := fieldcrypt.GenerateKeyFile("old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := func() *services.CustomerService {
+		keys, err := fieldcrypt.NewLocalKeyProvider(kf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return services.NewCustomerService(store, store, fieldcrypt.New(keys))
+	}
+
+	pf, err := service().CreatePFCustomer(ctx, pfRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A row written before encryption was enabled.
+	legacy, err := store.CreateCustomerPJ(ctx, sqlc.CreateCustomerPJParams{
+		ID: uuid.New(), Type: sqlc.CustomerTypePJ, Email: "legacy@example.com", Phone: "11 95555-0000", Cnpj: "11.222.333/0001-81", CompanyName: "Legacy Ltda",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kf.AddKey("new"); err != nil {
+		t.Fatal(err)
+	}
+	report, err := service().RotateEncryptionKeys(ctx)
+	if err != nil {
+		t.Fatalf("RotateEncryptionKeys: %v", err)
+	}
+	if report.Scanned != 2 || report.Updated != 2 {
+		t.Errorf("report = %+v, want 2 scanned and 2 updated", report)
+	}
+	if report, _ := service().RotateEncryptionKeys(ctx); report.Updated != 0 {
+		t.Errorf("second rotation updated %d rows, want 0", report.Updated)
+	}
+
+	delete(kf.Keys, "old")
+	cs := service()
+	if got, err := cs.GetCustomerDetails(ctx, pf); err != nil || got.Cpf != "123.456.789-09" {
+		t.Errorf("PF customer after rotation = %+v, %v", got, err)
+	}
+	if got, err := cs.FindCustomerByCnpj(ctx, "11222333000181"); err != nil || got.ID != legacy {
+		t.Errorf("legacy customer must be indexed by the rotation: %+v, %v", got, err)
+	}
+}
+
+// Rows written before encryption was enabled hold plaintext until they are
+// backfilled; Decrypt passes them through so they can still be read.
+func TestLegacyPlaintextCustomersAreReadable(t *testing.T) {
+	cs, store := newCustomerService(t)
+	ctx := context.Background()
+
+	pf, err := store.CreateCustomerPF(ctx, sqlc.CreateCustomerPFParams{
+		ID: uuid.New(), Type: sqlc.CustomerTypePF, Email: "legacy@example.com", Phone: "11 95555-0000", Cpf: "123.456.789-09", Name: "Legacy", BirthDate: "1990-05-10",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pj, err := store.CreateCustomerPJ(ctx, sqlc.CreateCustomerPJParams{
+		ID: uuid.New(), Type: sqlc.CustomerTypePJ, Email: "legacy-pj@example.com", Phone: "11 95555-0001", Cnpj: "11.222.333/0001-81", CompanyName: "Legacy Ltda",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := cs.GetCustomerDetails(ctx, pf); err != nil || got.Cpf != "123.456.789-09" || got.BirthDate != "1990-05-10" {
+		t.Errorf("legacy PF customer = %+v, %v", got, err)
+	}
+	if got, err := cs.GetCustomerDetails(ctx, pj); err != nil || got.Cnpj != "11.222.333/0001-81" {
+		t.Errorf("legacy PJ customer = %+v, %v", got, err)
+	}
+	all, err := cs.GetAllCustomersDetails(ctx, customer.ListOptions{})
+	if err != nil || len(all) != 2 {
+		t.Fatalf("GetAllCustomersDetails = %d customers, %v", len(all), err)
+	}
+}
+
+func TestEncryptLegacyCustomers(t *testing.T) {
+	cs, store := newCustomerService(t)
+	ctx := context.Background()
+
+	if _, err := cs.CreatePFCustomer(ctx, pfRequest()); err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := store.CreateCustomerPJ(ctx, sqlc.CreateCustomerPJParams{
+		ID: uuid.New(), Type: sqlc.CustomerTypePJ, Email: "legacy@example.com", Phone: "11 95555-0000", Cnpj: "11.222.333/0001-81", CompanyName: "Legacy Ltda",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cs.FindCustomerByCnpj(ctx, "11222333000181"); !errors.Is(err, services.ErrCustomerNotFound) {
+		t.Fatalf("legacy customer found before being indexed: %v", err)
+	}
+
+	report, err := cs.EncryptLegacyCustomers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Scanned != 1 || report.Updated != 1 {
+		t.Errorf("report = %+v, want only the legacy row", report)
+	}
+	if got, err := cs.FindCustomerByCnpj(ctx, "11222333000181"); err != nil || got.ID != legacy {
+		t.Errorf("legacy customer after backfill = %+v, %v", got, err)
+	}
+	if report, _ := cs.EncryptLegacyCustomers(ctx); report.Scanned != 0 {
+		t.Errorf("second backfill scanned %d rows, want 0", report.Scanned)
+	}
+}
+
+// Legacy plaintext was only unique as written, so two rows can normalize to
+// the same blind index. The second one is reported and left alone instead of
+// failing the whole backfill.
+func TestEncryptLegacyCustomersSkipsCollidingDocuments(t *testing.T) {
+	cs, store := newCustomerService(t)
+	ctx := context.Background()
+
+	var ids []uuid.UUID
+	for i, cpf := range []string{"111.444.777-35", "11144477735"} {
+		id, err := store.CreateCustomerPF(ctx, sqlc.CreateCustomerPFParams{
+			ID: uuid.New(), Type: sqlc.CustomerTypePF, Email: fmt.Sprintf("legacy%d@example.com", i), Phone: fmt.Sprintf("11 9555%d-0000", i), Cpf: cpf, Name: "Legacy", BirthDate: "1990-05-10",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	report, err := cs.EncryptLegacyCustomers(ctx)
+	if err != nil {
+		t.Fatalf("EncryptLegacyCustomers: %v", err)
+	}
+	if report.Scanned != 2 || report.Updated != 1 || len(report.Conflicts) != 1 {
+		t.Fatalf("report = %+v, want one row updated and one conflict", report)
+	}
+
+	indexed, err := cs.FindCustomerByCpf(ctx, "11144477735")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped := report.Conflicts[0]; skipped == indexed.ID || (skipped != ids[0] && skipped != ids[1]) {
+		t.Errorf("conflict %s, indexed %s, customers %v", skipped, indexed.ID, ids)
+	}
+	row, err := store.GetCustomerByID(ctx, report.Conflicts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cpf, _ := row.Cpf.(string); fieldcrypt.IsEncrypted(cpf) {
+		t.Errorf("conflicting customer was rewritten: %q", cpf)
+	}
+}
+
+// The rewrap pages through customers in several transactions; every row
+// must be visited once.
+func TestRotateEncryptionKeysCoversEveryBatch(t *testing.T) {
+	cs, store := newCustomerService(t)
+	ctx := context.Background()
+
+	const total = 1200
+	for i := range total {
+		_, err := store.CreateCustomerPJ(ctx, sqlc.CreateCustomerPJParams{
+			ID: uuid.New(), Type: sqlc.CustomerTypePJ, Email: fmt.Sprintf("pj%d@example.com", i), Phone: fmt.Sprintf("phone-%d", i), Cnpj: fmt.Sprintf("%014d", i), CompanyName: "PJ",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := cs.RotateEncryptionKeys(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Scanned != total || report.Updated != total || len(report.Conflicts) != 0 {
+		t.Errorf("report = scanned %d, updated %d, %d conflicts; want %d rows updated", report.Scanned, report.Updated, len(report.Conflicts), total)
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"go.uber.org/zap"
+)
+
+var ErrNoteNotFound = errors.New("note not found")
+
+// AddNote adds a note written by authorID to the customer.
+func (cs *CustomerService) AddNote(ctx context.Context, customerID, authorID uuid.UUID, req customer.NoteRequest) (customer.NoteResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.AddNote")
+	defer span.End()
+
+	note, err := cs.queries.CreateCustomerNote(ctx, sqlc.CreateCustomerNoteParams{
+		CustomerID: customerID,
+		AuthorID:   pgtype.UUID{Bytes: authorID, Valid: authorID != uuid.Nil},
+		Body:       req.Body,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return customer.NoteResponse{}, ErrCustomerNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to add note", err, zap.String("customer_id", customerID.String()))
+		return customer.NoteResponse{}, err
+	}
+
+	return customer.MapNote(sqlc.ListNotesByCustomerIDsRow(note)), nil
+}
+
+// ListNotes returns the notes of the customer, newest first.
+func (cs *CustomerService) ListNotes(ctx context.Context, customerID uuid.UUID) ([]customer.NoteResponse, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ListNotes")
+	defer span.End()
+
+	if _, err := cs.queries.GetCustomerByID(ctx, customerID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCustomerNotFound
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	notes, err := cs.queries.ListNotesByCustomerIDs(ctx, []uuid.UUID{customerID})
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to list notes", err, zap.String("customer_id", customerID.String()))
+		return nil, err
+	}
+	return customer.MapNotes(notes), nil
+}
+
+func (cs *CustomerService) DeleteNote(ctx context.Context, customerID uuid.UUID, noteID int32) error {
+	ctx, span := tracing.Start(ctx, "CustomerService.DeleteNote")
+	defer span.End()
+
+	n, err := cs.queries.DeleteCustomerNote(ctx, sqlc.DeleteCustomerNoteParams{ID: noteID, CustomerID: customerID})
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to delete note", err,
+			zap.String("customer_id", customerID.String()),
+			zap.Int32("note_id", noteID))
+		return err
+	}
+	if n == 0 {
+		return ErrNoteNotFound
+	}
+	return nil
+}
@@ -2,27 +2,34 @@ package services
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
 	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/metrics"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
 	"github.com/josevitorrodriguess/client-manager/internal/validators/service"
 )
 
+var ErrServiceNotFound = errors.New("service not found")
+
 type ServiceService struct {
-	pool    *pgxpool.Pool
-	queries *sqlc.Queries
+	queries sqlc.Querier
 }
 
-func NewServiceService(pool *pgxpool.Pool) *ServiceService {
+func NewServiceService(queries sqlc.Querier) *ServiceService {
 	return &ServiceService{
-		pool:    pool,
-		queries: sqlc.New(pool),
+		queries: queries,
 	}
 }
 
 func (ss *ServiceService) CreateService(ctx context.Context, service service.ServiceRequest) (int32, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.CreateService")
+	defer span.End()
+
 	data := sqlc.CreateServiceParams{
 		CustomerID:  service.CustomerID,
 		TypeProduct: service.TypeProduct,
@@ -34,74 +41,108 @@ func (ss *ServiceService) CreateService(ctx context.Context, service service.Ser
 	}
 
 	serviceID, err := ss.queries.CreateService(ctx, data)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return 0, ErrCustomerNotFound
+	}
 	if err != nil {
-		logger.Error("Failed to create service to customer", err)
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to create service to customer", err)
 		return 0, err
 	}
+	metrics.ServicesCreated.Inc()
 	return serviceID, nil
 }
 
 func (ss *ServiceService) GetServicesByCustomerID(ctx context.Context, customerID uuid.UUID) ([]sqlc.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.GetServicesByCustomerID")
+	defer span.End()
+
 	services, err := ss.queries.GetServicesByCustomerID(ctx, customerID)
 	if err != nil {
-		logger.Error("Failed to get services for customer", err)
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to get services for customer", err)
 		return nil, err
 	}
 	return services, nil
 }
 
 func (ss *ServiceService) CountServicesByCustomerID(ctx context.Context, customerID uuid.UUID) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.CountServicesByCustomerID")
+	defer span.End()
+
 	count, err := ss.queries.CountServicesByCustomerID(ctx, customerID)
 	if err != nil {
-		logger.Error("Failed to count services for customer", err)
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to count services for customer", err)
 		return 0, err
 	}
 	return count, nil
 }
 
 func (ss *ServiceService) ListAllServices(ctx context.Context) ([]sqlc.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.ListAllServices")
+	defer span.End()
+
 	services, err := ss.queries.ListAllServices(ctx)
 	if err != nil {
-		logger.Error("Failed to list all services", err)
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to list all services", err)
 		return nil, err
 	}
 	return services, nil
 }
 
 func (ss *ServiceService) DeleteService(ctx context.Context, id int32) error {
+	ctx, span := tracing.Start(ctx, "ServiceService.DeleteService")
+	defer span.End()
+
 	err := ss.queries.DeleteService(ctx, id)
 	if err != nil {
-		logger.Error("Failed to delete service", err)
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to delete service", err)
 		return err
 	}
 	return nil
 }
 
-
 func (ss *ServiceService) UpdateServiceFinishStatus(ctx context.Context, id int32, isFinished bool) (sqlc.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.UpdateServiceFinishStatus")
+	defer span.End()
+
 	params := sqlc.UpdateServiceFinishStatusParams{
 		ID:         id,
 		IsFinished: isFinished,
 	}
 
 	service, err := ss.queries.UpdateServiceFinishStatus(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.Service{}, ErrServiceNotFound
+	}
 	if err != nil {
-		logger.Error("Failed to update service finish status", err)
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to update service finish status", err)
 		return sqlc.Service{}, err
 	}
 	return service, nil
 }
 
-
 func (ss *ServiceService) UpdateServicePaymentStatus(ctx context.Context, id int32, isPaid bool) (sqlc.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.UpdateServicePaymentStatus")
+	defer span.End()
+
 	params := sqlc.UpdateServicePaymentStatusParams{
 		ID:     id,
 		IsPaid: isPaid,
 	}
 
 	service, err := ss.queries.UpdateServicePaymentStatus(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.Service{}, ErrServiceNotFound
+	}
 	if err != nil {
-		logger.Error("Failed to update service payment status", err)
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to update service payment status", err)
 		return sqlc.Service{}, err
 	}
 	return service, nil
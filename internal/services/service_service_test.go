@@ -0,0 +1,55 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/josevitorrodriguess/client-manager/internal/db/memstore"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+	"github.com/josevitorrodriguess/client-manager/internal/testutil"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/service"
+)
+
+func TestServiceService(t *testing.T) {
+	store := memstore.New()
+	cs := services.NewCustomerService(store, store, testutil.Cipher(t))
+	ss := services.NewServiceService(store)
+	ctx := context.Background()
+
+	customerID, err := cs.CreatePFCustomer(ctx, pfRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := ss.CreateService(ctx, service.ServiceRequest{CustomerID: customerID, TypeProduct: "site", Description: "institutional site"})
+	if err != nil {
+		t.Fatalf("CreateService: %v", err)
+	}
+
+	count, err := ss.CountServicesByCustomerID(ctx, customerID)
+	if err != nil || count != 1 {
+		t.Errorf("CountServicesByCustomerID = %d, %v; want 1", count, err)
+	}
+
+	updated, err := ss.UpdateServicePaymentStatus(ctx, id, true)
+	if err != nil || !updated.IsPaid {
+		t.Errorf("UpdateServicePaymentStatus = %+v, %v", updated, err)
+	}
+
+	updated, err = ss.UpdateServiceFinishStatus(ctx, id, true)
+	if err != nil || !updated.IsFinished {
+		t.Errorf("UpdateServiceFinishStatus = %+v, %v", updated, err)
+	}
+
+	if err := ss.DeleteService(ctx, id); err != nil {
+		t.Fatalf("DeleteService: %v", err)
+	}
+	all, err := ss.ListAllServices(ctx)
+	if err != nil || len(all) != 0 {
+		t.Errorf("ListAllServices = %v, %v; want empty", all, err)
+	}
+
+	if _, err := ss.UpdateServicePaymentStatus(ctx, id, false); err == nil {
+		t.Error("expected an error updating a deleted service")
+	}
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/customer"
+	"go.uber.org/zap"
+)
+
+// SetTags replaces the tags of the customer and returns them normalized.
+func (cs *CustomerService) SetTags(ctx context.Context, customerID uuid.UUID, tags []string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.SetTags")
+	defer span.End()
+
+	tags = customer.NormalizeTags(tags)
+	err := cs.tx.WithTx(ctx, func(q sqlc.Querier) error {
+		if _, err := q.GetCustomerByID(ctx, customerID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrCustomerNotFound
+			}
+			return err
+		}
+		if _, err := q.DeleteCustomerTags(ctx, customerID); err != nil {
+			return err
+		}
+		return q.AddCustomerTags(ctx, sqlc.AddCustomerTagsParams{CustomerID: customerID, Tags: tags})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrCustomerNotFound) {
+			tracing.RecordError(span, err)
+			logger.FromContext(ctx).Error("Failed to set tags", err, zap.String("customer_id", customerID.String()))
+		}
+		return nil, err
+	}
+	return tags, nil
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"go.uber.org/zap"
+)
+
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+
+	defaultTxMaxRetries = 3
+	defaultTxBackoff    = 50 * time.Millisecond
+)
+
+// Transactor runs fn atomically: either every query made through q is
+// committed or none is. Services depend on this interface so tests can swap
+// the database for an in-memory implementation. CustomerService runs every
+// change that spans several statements through it; ServiceService and
+// UserService only issue single statements, which are atomic on their own.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(q sqlc.Querier) error) error
+}
+
+// TxManager is the Postgres Transactor. Every call to WithTx opens a
+// transaction, hands fn a *sqlc.Queries bound to it and commits when fn
+// returns nil. Serialization failures and deadlocks are retried with a
+// linear backoff, so fn must not have side effects outside the transaction.
+type TxManager struct {
+	pool       *pgxpool.Pool
+	queries    *sqlc.Queries
+	maxRetries int
+	backoff    time.Duration
+}
+
+func NewTxManager(pool *pgxpool.Pool) *TxManager {
+	return &TxManager{
+		pool:       pool,
+		queries:    sqlc.New(pool),
+		maxRetries: defaultTxMaxRetries,
+		backoff:    defaultTxBackoff,
+	}
+}
+
+func (tm *TxManager) WithTx(ctx context.Context, fn func(q sqlc.Querier) error) error {
+	for attempt := 1; ; attempt++ {
+		err := tm.run(ctx, fn)
+		if err == nil || !isRetryableTxError(err) || attempt > tm.maxRetries {
+			return err
+		}
+
+		logger.FromContext(ctx).Warn("Retrying transaction",
+			zap.Int("attempt", attempt),
+			zap.String("error", err.Error()))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * tm.backoff):
+		}
+	}
+}
+
+func (tm *TxManager) run(ctx context.Context, fn func(q sqlc.Querier) error) error {
+	tx, err := tm.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tm.queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
@@ -10,10 +10,12 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/josevitorrodriguess/client-manager/internal/config/logger"
 	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/metrics"
+	"github.com/josevitorrodriguess/client-manager/internal/tracing"
 	"github.com/josevitorrodriguess/client-manager/internal/utils"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
 	"github.com/josevitorrodriguess/client-manager/internal/validators/user"
 	"go.uber.org/zap"
 )
@@ -21,24 +23,27 @@ import (
 var (
 	ErrDuplicatedEmailOrUsername = errors.New("username or email already exists")
 	ErrInvalidCredentials        = errors.New("invalid credentials")
+	ErrUserNotFound              = errors.New("user not found")
 )
 
 type UserService struct {
-	pool    *pgxpool.Pool
-	queries *sqlc.Queries
+	queries sqlc.Querier
 }
 
-func NewUserService(pool *pgxpool.Pool) *UserService {
+func NewUserService(queries sqlc.Querier) *UserService {
 	return &UserService{
-		pool:    pool,
-		queries: sqlc.New(pool),
+		queries: queries,
 	}
 }
 
 func (us *UserService) Create(ctx context.Context, user user.UserRequest) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Create")
+	defer span.End()
+
 	hashPass, err := utils.EncryptPassword(user.Password)
 	if err != nil {
-		logger.Error("Failed to encrypt password", err, zap.String("email", user.Email))
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to encrypt password", err, zap.String("email", user.Email))
 		return uuid.Nil, fmt.Errorf("error encrypting password: %w", err)
 	}
 
@@ -53,49 +58,149 @@ func (us *UserService) Create(ctx context.Context, user user.UserRequest) (uuid.
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			logger.Warn("Duplicate user creation attempt", zap.String("email", user.Email))
+			logger.FromContext(ctx).Warn("Duplicate user creation attempt", zap.String("email", user.Email))
 			return uuid.UUID{}, ErrDuplicatedEmailOrUsername
 		}
-		logger.Error("Failed to create user in database", err, zap.String("email", user.Email))
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to create user in database", err, zap.String("email", user.Email))
 		return uuid.UUID{}, err
 	}
 
-	logger.Info("User created successfully",
-		zap.String("user_id", id.String()),
+	logger.FromContext(ctx).Info("User created successfully",
+		zap.String("new_user_id", id.String()),
 		zap.String("email", user.Email))
 	return id, nil
 }
 
 func (us *UserService) AuthenticateUser(ctx context.Context, email, password string) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx, "UserService.AuthenticateUser")
+	defer span.End()
+
 	user, err := us.queries.GetUserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			logger.Warn("Login attempt with non-existent email", zap.String("email", email))
+			logger.FromContext(ctx).Warn("Login attempt with non-existent email", zap.String("email", email))
+			metrics.LoginAttempts.WithLabelValues(metrics.LoginInvalidCredentials).Inc()
 			return uuid.UUID{}, ErrInvalidCredentials
 		}
-		logger.Error("Failed to get user from database", err, zap.String("email", email))
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to get user from database", err, zap.String("email", email))
+		metrics.LoginAttempts.WithLabelValues(metrics.LoginError).Inc()
 		return uuid.UUID{}, err
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
 		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-			logger.Warn("Invalid password attempt", zap.String("email", email))
+			logger.FromContext(ctx).Warn("Invalid password attempt", zap.String("email", email))
+			metrics.LoginAttempts.WithLabelValues(metrics.LoginInvalidCredentials).Inc()
 			return uuid.UUID{}, ErrInvalidCredentials
 		}
-		logger.Error("Failed to compare passwords", err, zap.String("email", email))
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to compare passwords", err, zap.String("email", email))
+		metrics.LoginAttempts.WithLabelValues(metrics.LoginError).Inc()
 		return uuid.UUID{}, err
 	}
 
+	metrics.LoginAttempts.WithLabelValues(metrics.LoginSuccess).Inc()
 	return user.ID, nil
 }
 
 func (us *UserService) CheckIsAdmin(ctx context.Context, id uuid.UUID) (bool, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CheckIsAdmin")
+	defer span.End()
+
 	ok, err := us.queries.CheckIfUserIsAdmin(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, ErrUserNotFound
+	}
 	if err != nil {
-		logger.Error("Failed to check admin status", err, zap.String("user_id", id.String()))
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to check admin status", err, zap.String("user_id", id.String()))
 		return false, err
 	}
 
 	return ok, nil
 }
+
+func (us *UserService) List(ctx context.Context) ([]sqlc.ListUsersRow, error) {
+	ctx, span := tracing.Start(ctx, "UserService.List")
+	defer span.End()
+
+	users, err := us.queries.ListUsers(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to list users", err)
+		return nil, err
+	}
+	return users, nil
+}
+
+// ResetPassword replaces the password of the user with email. The password
+// follows the same rules as on sign up.
+func (us *UserService) ResetPassword(ctx context.Context, email, password string) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ResetPassword")
+	defer span.End()
+
+	if err := validators.Check(
+		validators.Field("password", password, validators.Required[string](), validators.Length(8, 100)),
+	); err != nil {
+		return uuid.Nil, err
+	}
+
+	hashPass, err := utils.EncryptPassword(password)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return uuid.Nil, fmt.Errorf("error encrypting password: %w", err)
+	}
+
+	id, err := us.queries.UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{Email: email, Password: hashPass})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, ErrUserNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to reset password", err, zap.String("email", email))
+		return uuid.Nil, err
+	}
+
+	logger.FromContext(ctx).Info("Password reset", zap.String("user_id", id.String()), zap.String("email", email))
+	return id, nil
+}
+
+// SetAdmin grants or revokes admin rights of the user with email.
+func (us *UserService) SetAdmin(ctx context.Context, email string, isAdmin bool) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetAdmin")
+	defer span.End()
+
+	id, err := us.queries.SetUserAdmin(ctx, sqlc.SetUserAdminParams{Email: email, IsAdmin: isAdmin})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, ErrUserNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to change admin rights", err, zap.String("email", email))
+		return uuid.Nil, err
+	}
+
+	logger.FromContext(ctx).Info("Admin rights changed",
+		zap.String("user_id", id.String()),
+		zap.String("email", email),
+		zap.Bool("is_admin", isAdmin))
+	return id, nil
+}
+
+// PurgeExpiredSessions deletes expired sessions from the session store and
+// returns how many were removed.
+func (us *UserService) PurgeExpiredSessions(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "UserService.PurgeExpiredSessions")
+	defer span.End()
+
+	n, err := us.queries.DeleteExpiredSessions(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.FromContext(ctx).Error("Failed to purge expired sessions", err)
+		return 0, err
+	}
+	return n, nil
+}
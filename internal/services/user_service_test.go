@@ -0,0 +1,90 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/josevitorrodriguess/client-manager/internal/db/memstore"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/user"
+)
+
+func TestUserService(t *testing.T) {
+	us := services.NewUserService(memstore.New())
+	ctx := context.Background()
+
+	req := user.UserRequest{Name: "Admin User", Email: "admin@example.com", Password: "s3cret-pass", IsAdmin: true}
+	id, err := us.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err := us.Create(ctx, req); !errors.Is(err, services.ErrDuplicatedEmailOrUsername) {
+		t.Errorf("expected ErrDuplicatedEmailOrUsername, got %v", err)
+	}
+
+	got, err := us.AuthenticateUser(ctx, req.Email, req.Password)
+	if err != nil || got != id {
+		t.Errorf("AuthenticateUser = %v, %v; want %v", got, err, id)
+	}
+
+	for _, tc := range []struct{ email, password string }{
+		{req.Email, "wrong-password"},
+		{"nobody@example.com", req.Password},
+	} {
+		if _, err := us.AuthenticateUser(ctx, tc.email, tc.password); !errors.Is(err, services.ErrInvalidCredentials) {
+			t.Errorf("AuthenticateUser(%q): expected ErrInvalidCredentials, got %v", tc.email, err)
+		}
+	}
+
+	isAdmin, err := us.CheckIsAdmin(ctx, id)
+	if err != nil || !isAdmin {
+		t.Errorf("CheckIsAdmin = %v, %v; want true", isAdmin, err)
+	}
+}
+
+func TestUserServiceAdminCommands(t *testing.T) {
+	us := services.NewUserService(memstore.New())
+	ctx := context.Background()
+
+	req := user.UserRequest{Name: "Operator", Email: "operator@example.com", Password: "first-pass"}
+	id, err := us.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if got, err := us.SetAdmin(ctx, req.Email, true); err != nil || got != id {
+		t.Fatalf("SetAdmin = %v, %v; want %v", got, err, id)
+	}
+	if isAdmin, err := us.CheckIsAdmin(ctx, id); err != nil || !isAdmin {
+		t.Errorf("CheckIsAdmin after promote = %v, %v; want true", isAdmin, err)
+	}
+	if _, err := us.SetAdmin(ctx, "nobody@example.com", true); !errors.Is(err, services.ErrUserNotFound) {
+		t.Errorf("SetAdmin(unknown): expected ErrUserNotFound, got %v", err)
+	}
+
+	if _, err := us.ResetPassword(ctx, req.Email, "short"); err == nil {
+		t.Error("ResetPassword accepted a 5 character password")
+	}
+	if _, err := us.ResetPassword(ctx, req.Email, "second-pass"); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if _, err := us.AuthenticateUser(ctx, req.Email, req.Password); !errors.Is(err, services.ErrInvalidCredentials) {
+		t.Errorf("old password still works: %v", err)
+	}
+	if _, err := us.AuthenticateUser(ctx, req.Email, "second-pass"); err != nil {
+		t.Errorf("AuthenticateUser with new password: %v", err)
+	}
+	if _, err := us.ResetPassword(ctx, "nobody@example.com", "second-pass"); !errors.Is(err, services.ErrUserNotFound) {
+		t.Errorf("ResetPassword(unknown): expected ErrUserNotFound, got %v", err)
+	}
+
+	users, err := us.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(users) != 1 || users[0].Email != req.Email || !users[0].IsAdmin {
+		t.Errorf("List = %+v", users)
+	}
+}
@@ -0,0 +1,47 @@
+// Package testutil builds the fixtures shared by the tests of several
+// packages: a cipher with a throwaway key and the API wired to an in-memory
+// store.
+package testutil
+
+import (
+	"testing"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/josevitorrodriguess/client-manager/internal/api"
+	"github.com/josevitorrodriguess/client-manager/internal/db/memstore"
+	"github.com/josevitorrodriguess/client-manager/internal/fieldcrypt"
+	"github.com/josevitorrodriguess/client-manager/internal/services"
+)
+
+// Cipher returns a cipher using a key generated for the test.
+func Cipher(t testing.TB) *fieldcrypt.Cipher {
+	t.Helper()
+
+	kf, err := fieldcrypt.GenerateKeyFile("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := fieldcrypt.NewLocalKeyProvider(kf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fieldcrypt.New(keys)
+}
+
+// NewAPI wires the real router to a new in-memory store, as cmd/main.go does
+// with Postgres. No users exist yet.
+func NewAPI(t testing.TB) (*api.Api, *memstore.Store) {
+	t.Helper()
+
+	store := memstore.New()
+	a := &api.Api{
+		Router:          chi.NewMux(),
+		UserService:     *services.NewUserService(store),
+		CustomerService: *services.NewCustomerService(store, store, Cipher(t)),
+		ServiceService:  *services.NewServiceService(store),
+		Sessions:        scs.New(),
+	}
+	a.BindRoutes()
+	return a, store
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddlewareNamesSpanAfterRoutePattern(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	r := chi.NewRouter()
+	r.Use(Middleware)
+	r.Get("/customers/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, span := Start(r.Context(), "CustomerService.GetCustomerDetails")
+		span.End()
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/customers/42", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	child, server := spans[0], spans[1]
+	if server.Name() != "GET /customers/{id}" {
+		t.Errorf("server span name = %q", server.Name())
+	}
+	if child.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Error("service span is not a child of the request span")
+	}
+}
+
+func TestQueryName(t *testing.T) {
+	cases := map[string]string{
+		"-- name: GetCustomerByID :one\nSELECT 1": "GetCustomerByID",
+		"select 1": "SELECT",
+		"":         "query",
+	}
+	for sql, want := range cases {
+		if got := queryName(sql); got != want {
+			t.Errorf("queryName(%q) = %q, want %q", sql, got, want)
+		}
+	}
+}
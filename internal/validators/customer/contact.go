@@ -0,0 +1,76 @@
+package customer
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
+)
+
+// maxContactChannels caps the emails and the phones of a single contact.
+const maxContactChannels = 10
+
+// ContactRequest creates or replaces a contact of a customer. WhatsApp tells
+// whether the contact's phones can be reached on WhatsApp; a customer has at
+// most one primary contact, so marking one as primary demotes the previous.
+type ContactRequest struct {
+	Name      string      `json:"name"`
+	Role      pgtype.Text `json:"role"`
+	Emails    []string    `json:"emails"`
+	Phones    []string    `json:"phones"`
+	WhatsApp  bool        `json:"whatsapp"`
+	IsPrimary bool        `json:"is_primary"`
+}
+
+func (cr *ContactRequest) Validate() error {
+	checks := []validators.FieldCheck{
+		validators.Field("name", cr.Name, validators.Required[string](), validators.Length(2, 100)),
+		validators.Field("role", cr.Role.String, validators.Length(1, 100)),
+		validators.Field("emails", len(cr.Emails), validators.MaxItems(maxContactChannels)),
+		validators.Field("phones", len(cr.Phones), validators.MaxItems(maxContactChannels)),
+		validators.Field("phones", len(cr.Emails)+len(cr.Phones) > 0, validators.AtLeastOne("emails", "phones")),
+	}
+	checks = append(checks, validators.Each("emails", cr.Emails, validators.Required[string](), validators.Email())...)
+	checks = append(checks, validators.Each("phones", cr.Phones, validators.Required[string](), validators.Phone())...)
+	return validators.Check(checks...)
+}
+
+type ContactResponse struct {
+	ID        int32              `json:"id"`
+	Name      string             `json:"name"`
+	Role      pgtype.Text        `json:"role"`
+	Emails    []string           `json:"emails"`
+	Phones    []string           `json:"phones"`
+	WhatsApp  bool               `json:"whatsapp"`
+	IsPrimary bool               `json:"is_primary"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+func MapContact(c sqlc.Contact) ContactResponse {
+	resp := ContactResponse{
+		ID:        c.ID,
+		Name:      c.Name,
+		Role:      c.Role,
+		Emails:    c.Emails,
+		Phones:    c.Phones,
+		WhatsApp:  c.Whatsapp,
+		IsPrimary: c.IsPrimary,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+	if resp.Emails == nil {
+		resp.Emails = []string{}
+	}
+	if resp.Phones == nil {
+		resp.Phones = []string{}
+	}
+	return resp
+}
+
+func MapContacts(rows []sqlc.Contact) []ContactResponse {
+	contacts := make([]ContactResponse, 0, len(rows))
+	for _, c := range rows {
+		contacts = append(contacts, MapContact(c))
+	}
+	return contacts
+}
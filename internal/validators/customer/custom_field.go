@@ -0,0 +1,188 @@
+package customer
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
+)
+
+const (
+	maxCustomFieldOptions = 50
+	maxCustomTextLength   = 500
+)
+
+// customFieldKeyRegex keeps keys usable as JSON object keys in reports and
+// query tools, e.g. "segment" or "contract_start".
+var customFieldKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var customFieldTypes = []string{
+	string(sqlc.CustomFieldTypeText),
+	string(sqlc.CustomFieldTypeNumber),
+	string(sqlc.CustomFieldTypeDate),
+	string(sqlc.CustomFieldTypeSelect),
+}
+
+// CustomFieldRequest defines a custom field. Options are the allowed values
+// of a select field and are ignored for the other types.
+type CustomFieldRequest struct {
+	Key     string   `json:"key"`
+	Label   string   `json:"label"`
+	Type    string   `json:"type"`
+	Options []string `json:"options"`
+}
+
+func (cr *CustomFieldRequest) Validate() error {
+	checks := []validators.FieldCheck{
+		validators.Field("key", cr.Key, validators.Required[string](), validators.Length(1, 50), validators.Matches(customFieldKeyRegex)),
+		validators.Field("label", cr.Label, validators.Required[string](), validators.Length(1, 100)),
+		validators.Field("type", cr.Type, validators.Required[string](), validators.OneOf(customFieldTypes...)),
+	}
+	if cr.Type == string(sqlc.CustomFieldTypeSelect) {
+		checks = append(checks,
+			validators.Field("options", len(cr.Options), validators.MaxItems(maxCustomFieldOptions)),
+			validators.Field("options", len(cr.Options) > 0, validators.AtLeastOne("options")),
+		)
+		checks = append(checks, validators.Each("options", cr.Options, validators.Required[string](), validators.Length(1, 100))...)
+	}
+	return validators.Check(checks...)
+}
+
+type CustomFieldResponse struct {
+	ID        int32              `json:"id"`
+	Key       string             `json:"key"`
+	Label     string             `json:"label"`
+	Type      string             `json:"type"`
+	Options   []string           `json:"options"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+func MapCustomField(f sqlc.CustomField) CustomFieldResponse {
+	resp := CustomFieldResponse{
+		ID:        f.ID,
+		Key:       f.Key,
+		Label:     f.Label,
+		Type:      string(f.Type),
+		Options:   f.Options,
+		CreatedAt: f.CreatedAt,
+	}
+	if resp.Options == nil {
+		resp.Options = []string{}
+	}
+	return resp
+}
+
+func MapCustomFields(rows []sqlc.CustomField) []CustomFieldResponse {
+	fields := make([]CustomFieldResponse, 0, len(rows))
+	for _, f := range rows {
+		fields = append(fields, MapCustomField(f))
+	}
+	return fields
+}
+
+// CustomFieldValues replaces the custom field values of a customer, keyed by
+// custom field key. A null value leaves the field empty.
+type CustomFieldValues map[string]json.RawMessage
+
+// Check validates the values against the custom field definitions and
+// returns them typed, ready to be stored: JSON numbers for number fields and
+// strings for the others, dates as YYYY-MM-DD. Errors are keyed by field key.
+func (cv CustomFieldValues) Check(fields []sqlc.CustomField) (map[string]any, error) {
+	defs := make(map[string]sqlc.CustomField, len(fields))
+	for _, f := range fields {
+		defs[f.Key] = f
+	}
+
+	errs := validators.ValidationErrors{}
+	values := make(map[string]any, len(cv))
+	for key, raw := range cv {
+		def, ok := defs[key]
+		if !ok {
+			errs.Add(key, validators.Violation{Code: validators.CodeUnknownField})
+			continue
+		}
+		// raw was cut from a decoded request body, so it is valid JSON.
+		value, _ := decodeValue(raw)
+		if value == nil {
+			continue
+		}
+		if v := checkCustomFieldValue(def, value); v != nil {
+			errs.Add(key, *v)
+			continue
+		}
+		values[key] = value
+	}
+	if errs.HasErrors() {
+		return nil, errs
+	}
+	return values, nil
+}
+
+func checkCustomFieldValue(def sqlc.CustomField, value any) *validators.Violation {
+	if def.Type == sqlc.CustomFieldTypeNumber {
+		if _, ok := value.(json.Number); !ok {
+			return &validators.Violation{Code: validators.CodeType, Args: []any{"number", jsonKind(value)}}
+		}
+		return nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return &validators.Violation{Code: validators.CodeType, Args: []any{"string", jsonKind(value)}}
+	}
+	if v := validators.Required[string]()(s); v != nil {
+		return v
+	}
+	switch def.Type {
+	case sqlc.CustomFieldTypeText:
+		return validators.Length(1, maxCustomTextLength)(s)
+	case sqlc.CustomFieldTypeDate:
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return &validators.Violation{Code: validators.CodeDateFormat, Args: []any{"YYYY-MM-DD"}}
+		}
+	case sqlc.CustomFieldTypeSelect:
+		return validators.OneOf(def.Options...)(s)
+	}
+	return nil
+}
+
+// jsonKind names the JSON type of a value decoded with decodeValue.
+func jsonKind(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return "null"
+}
+
+// decodeValue decodes JSON keeping numbers as json.Number, so large or
+// precise values are stored exactly as sent.
+func decodeValue(raw []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	err := dec.Decode(&v)
+	return v, err
+}
+
+// MapCustomFieldValues decodes the custom_fields column. The column is a
+// JSONB object, so it only fails to decode when empty.
+func MapCustomFieldValues(raw []byte) map[string]any {
+	values, _ := decodeValue(raw)
+	if m, ok := values.(map[string]any); ok {
+		return m
+	}
+	return map[string]any{}
+}
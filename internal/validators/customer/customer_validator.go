@@ -3,11 +3,11 @@ package customer
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
-	"github.com/josevitorrodriguess/client-manager/internal/utils"
 	"github.com/josevitorrodriguess/client-manager/internal/validators"
 )
 
@@ -27,43 +27,15 @@ type CustomerPFRequest struct {
 	Cep         string      `json:"cep"`
 }
 
-func (cPFr *CustomerPFRequest) IsValid() (bool, error) {
-	validationErrs := validators.ValidationErrors{
-		Errors: make(map[string]string),
-	}
-
-	if !(utils.MinChars(cPFr.Name, 5) && utils.MaxChars(cPFr.Name, 100)) {
-		validationErrs.Errors["Name"] = "name must have between 5 and 100 characters"
-	}
-
-	if !utils.Matches(cPFr.Email, utils.EmailRegex) {
-		validationErrs.Errors["Email"] = "invalid email"
-	}
-
-	if !utils.Matches(cPFr.Phone, utils.PhoneRegex) {
-		validationErrs.Errors["Phone"] = "invalid phone"
-	}
-
-	if !utils.Matches(cPFr.Cpf, utils.CPFRegex) {
-		validationErrs.Errors["Cpf"] = "invalid cpf"
-	}
-
-	if cPFr.BirthDate.Valid {
-		dateStr := fmt.Sprintf("%04d-%02d-%02d", cPFr.BirthDate.Time.Year(), cPFr.BirthDate.Time.Month(), cPFr.BirthDate.Time.Day())
-		if !utils.Matches(dateStr, utils.BirthDateRegex) {
-			validationErrs.Errors["BirthDate"] = "invalid birth date format"
-		}
-	}
-
-	if !utils.Matches(cPFr.Cep, utils.CEPRegex) {
-		validationErrs.Errors["Cep"] = "invalid cep"
-	}
-
-	if !validationErrs.HasErrors() {
-		return true, nil
-	}
-
-	return false, validationErrs
+func (cPFr *CustomerPFRequest) Validate() error {
+	return validators.Check(append([]validators.FieldCheck{
+		validators.Field("type", cPFr.Type, validators.Required[string](), validators.OneOf(string(sqlc.CustomerTypePF))),
+		validators.Field("name", cPFr.Name, validators.Required[string](), validators.Length(5, 100)),
+		validators.Field("email", cPFr.Email, validators.Required[string](), validators.Email()),
+		validators.Field("phone", cPFr.Phone, validators.Required[string](), validators.Phone()),
+		validators.Field("cpf", cPFr.Cpf, validators.Required[string](), validators.CPF()),
+		validators.Field("birth_date", cPFr.BirthDate, validators.Required[pgtype.Date](), validators.DateBetween(minBirthDate, time.Now())),
+	}, addressChecks(cPFr.AddressType, cPFr.Street, cPFr.Number, cPFr.State, cPFr.City, cPFr.Cep)...)...)
 }
 
 type CustomerPJRequest struct {
@@ -81,36 +53,14 @@ type CustomerPJRequest struct {
 	Cep         string      `json:"cep"`
 }
 
-func (cPJr *CustomerPJRequest) IsValid() (bool, error) {
-	validationErrs := validators.ValidationErrors{
-		Errors: make(map[string]string),
-	}
-
-	if !(utils.MinChars(cPJr.CompanyName, 5) && utils.MaxChars(cPJr.CompanyName, 100)) {
-		validationErrs.Errors["CompanyName"] = "company name must have between 5 and 100 characters"
-	}
-
-	if !utils.Matches(cPJr.Email, utils.EmailRegex) {
-		validationErrs.Errors["Email"] = "invalid email"
-	}
-
-	if !utils.Matches(cPJr.Phone, utils.PhoneRegex) {
-		validationErrs.Errors["Phone"] = "invalid phone"
-	}
-
-	if !utils.Matches(cPJr.Cep, utils.CEPRegex) {
-		validationErrs.Errors["Cep"] = "invalid cep"
-	}
-
-	if !utils.Matches(cPJr.Cnpj, utils.CNPJRegex) {
-		validationErrs.Errors["Cnpj"] = "invalid cnpj"
-	}
-
-	if !validationErrs.HasErrors() {
-		return true, nil
-	}
-
-	return false, validationErrs
+func (cPJr *CustomerPJRequest) Validate() error {
+	return validators.Check(append([]validators.FieldCheck{
+		validators.Field("type", cPJr.Type, validators.Required[string](), validators.OneOf(string(sqlc.CustomerTypePJ))),
+		validators.Field("company_name", cPJr.CompanyName, validators.Required[string](), validators.Length(5, 100)),
+		validators.Field("email", cPJr.Email, validators.Required[string](), validators.Email()),
+		validators.Field("phone", cPJr.Phone, validators.Required[string](), validators.Phone()),
+		validators.Field("cnpj", cPJr.Cnpj, validators.Required[string](), validators.CNPJ()),
+	}, addressChecks(cPJr.AddressType, cPJr.Street, cPJr.Number, cPJr.State, cPJr.City, cPJr.Cep)...)...)
 }
 
 type AddAddressRequest struct {
@@ -124,64 +74,100 @@ type AddAddressRequest struct {
 	Cep         string      `json:"cep"`
 }
 
+func (ar *AddAddressRequest) Validate() error {
+	return validators.Check(append([]validators.FieldCheck{
+		validators.Field("customer_id", ar.CustomerID, validators.Required[uuid.UUID]()),
+	}, addressChecks(ar.AddressType, ar.Street, ar.Number, ar.State, ar.City, ar.Cep)...)...)
+}
+
+// minBirthDate is the earliest birth date accepted for PF customers.
+var minBirthDate = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// states are the Brazilian federative units accepted in addresses.
+var states = []string{
+	"AC", "AL", "AP", "AM", "BA", "CE", "DF", "ES", "GO", "MA", "MT", "MS", "MG", "PA",
+	"PB", "PR", "PE", "PI", "RJ", "RN", "RS", "RO", "RR", "SC", "SP", "SE", "TO",
+}
+
+func addressChecks(addressType, street, number, state, city, cep string) []validators.FieldCheck {
+	return []validators.FieldCheck{
+		validators.Field("address_type", addressType, validators.Required[string](), validators.Length(1, 50)),
+		validators.Field("street", street, validators.Required[string](), validators.Length(1, 255)),
+		validators.Field("number", number, validators.Required[string](), validators.Length(1, 50)),
+		validators.Field("state", state, validators.Required[string](), validators.OneOf(states...)),
+		validators.Field("city", city, validators.Required[string](), validators.Length(1, 50)),
+		validators.Field("cep", cep, validators.Required[string](), validators.CEP()),
+	}
+}
+
 type CustomerResponse struct {
-	ID          uuid.UUID          `json:"id"`
-	Type        sqlc.CustomerType  `json:"type"`
-	Email       string             `json:"email"`
-	Phone       string             `json:"phone"`
-	IsActive    bool               `json:"is_active"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	Cpf         interface{}        `json:"cpf,omitempty"`
-	PfName      interface{}        `json:"pf_name,omitempty"`
-	BirthDate   interface{}        `json:"birth_date,omitempty"`
-	Cnpj        interface{}        `json:"cnpj,omitempty"`
-	CompanyName interface{}        `json:"company_name,omitempty"`
-	Addresses   []AddressResponse  `json:"addresses"`
+	ID           uuid.UUID          `json:"id"`
+	Type         sqlc.CustomerType  `json:"type"`
+	Email        string             `json:"email"`
+	Phone        string             `json:"phone"`
+	IsActive     bool               `json:"is_active"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	AnonymizedAt pgtype.Timestamptz `json:"anonymized_at"`
+	Cpf          interface{}        `json:"cpf,omitempty"`
+	PfName       interface{}        `json:"pf_name,omitempty"`
+	BirthDate    interface{}        `json:"birth_date,omitempty"`
+	Cnpj         interface{}        `json:"cnpj,omitempty"`
+	CompanyName  interface{}        `json:"company_name,omitempty"`
+	Addresses    []AddressResponse  `json:"addresses"`
+	Contacts     []ContactResponse  `json:"contacts"`
+	Tags         []string           `json:"tags"`
+	// Notes is only filled for a single customer; lists carry NoteCount and
+	// LatestNote, and GET /customers/{id}/notes returns every note.
+	Notes        []NoteResponse `json:"notes,omitempty"`
+	NoteCount    int64          `json:"note_count"`
+	LatestNote   *NoteResponse  `json:"latest_note"`
+	CustomFields map[string]any `json:"custom_fields"`
 }
 
 func MapToCustomerResponse(row sqlc.GetAllCustomersRow) (*CustomerResponse, error) {
 	var addresses []AddressResponse
 	var addressesJSON string
 
-	// Verifica o tipo real de row.Addresses e faz o tratamento adequado
+	// row.Addresses comes back as a different type depending on the driver path
 	switch v := row.Addresses.(type) {
 	case string:
-		// Se já for uma string, use diretamente
+		// Already JSON text
 		addressesJSON = v
 	case []interface{}:
-		// Se for um slice de interfaces, converta para JSON
+		// Decoded values; encode them back to JSON
 		jsonBytes, err := json.Marshal(v)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal addresses slice: %w", err)
 		}
 		addressesJSON = string(jsonBytes)
 	case []byte:
-		// Se for um slice de bytes, converta para string
+		// Raw JSON bytes
 		addressesJSON = string(v)
 	default:
-		// Caso seja outro tipo, trate como erro
 		return nil, fmt.Errorf("unexpected type for addresses: %T", row.Addresses)
 	}
 
-	// Agora que temos uma string JSON, podemos fazer o Unmarshal
 	if err := json.Unmarshal([]byte(addressesJSON), &addresses); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal addresses: %w", err)
 	}
 
 	return &CustomerResponse{
-		ID:          row.CustomerID,
-		Email:       row.CustomerEmail,
-		Phone:       row.CustomerPhone,
-		CreatedAt:   row.CustomerCreatedAt,
-		UpdatedAt:   row.CustomerUpdatedAt,
-		IsActive:    row.CustomerIsActive,
-		Cpf:         row.PfCpf,
-		PfName:      row.PfName,
-		BirthDate:   row.PfBirthDate,
-		Cnpj:        row.PjCnpj,
-		CompanyName: row.PjCompanyName,
-		Addresses:   addresses,
+		ID:           row.CustomerID,
+		Email:        row.CustomerEmail,
+		Phone:        row.CustomerPhone,
+		CreatedAt:    row.CustomerCreatedAt,
+		UpdatedAt:    row.CustomerUpdatedAt,
+		IsActive:     row.CustomerIsActive,
+		AnonymizedAt: row.CustomerAnonymizedAt,
+		Cpf:          row.PfCpf,
+		PfName:       row.PfName,
+		BirthDate:    row.PfBirthDate,
+		Cnpj:         row.PjCnpj,
+		CompanyName:  row.PjCompanyName,
+		Addresses:    addresses,
+		Tags:         []string{},
+		CustomFields: MapCustomFieldValues(row.CustomerCustomFields),
 	}, nil
 }
 
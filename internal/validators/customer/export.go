@@ -0,0 +1,45 @@
+package customer
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+)
+
+// CustomerExport is everything held about a customer, returned for LGPD data
+// access requests. Payment information lives on the services (total value,
+// down payment and paid flag).
+type CustomerExport struct {
+	ExportedAt time.Time            `json:"exported_at"`
+	Customer   CustomerResponse     `json:"customer"`
+	Services   []sqlc.Service       `json:"services"`
+	AuditTrail []AuditEventResponse `json:"audit_trail"`
+}
+
+type AuditEventResponse struct {
+	ID        int64           `json:"id"`
+	ActorID   *uuid.UUID      `json:"actor_id"`
+	Action    string          `json:"action"`
+	Details   json.RawMessage `json:"details"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func MapAuditEvents(rows []sqlc.AuditEvent) []AuditEventResponse {
+	events := make([]AuditEventResponse, 0, len(rows))
+	for _, e := range rows {
+		event := AuditEventResponse{
+			ID:        e.ID,
+			Action:    e.Action,
+			Details:   json.RawMessage(e.Details),
+			CreatedAt: e.CreatedAt.Time,
+		}
+		if e.ActorID.Valid {
+			id := uuid.UUID(e.ActorID.Bytes)
+			event.ActorID = &id
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,269 @@
+package customer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/i18n"
+	"github.com/josevitorrodriguess/client-manager/internal/phone"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
+)
+
+type ImportMode string
+
+const (
+	ImportModeTransaction ImportMode = "transaction"
+	ImportModeBatch       ImportMode = "batch"
+
+	DefaultImportBatchSize = 100
+)
+
+const (
+	ImportStatusValid      = "valid"
+	ImportStatusCreated    = "created"
+	ImportStatusInvalid    = "invalid"
+	ImportStatusDuplicate  = "duplicate"
+	ImportStatusFailed     = "failed"
+	ImportStatusSkipped    = "skipped"
+	ImportStatusRolledBack = "rolled_back"
+)
+
+var (
+	ErrEmptyImport       = errors.New("csv file has no customer rows")
+	ErrMissingTypeColumn = errors.New("csv header must contain a type column")
+	ErrInvalidCSV        = errors.New("invalid csv")
+	ErrInvalidImportMode = errors.New("invalid import mode")
+)
+
+var importColumns = []string{
+	"type", "email", "phone", "cpf", "name", "birth_date", "cnpj", "company_name",
+	"address_type", "street", "number", "complement", "state", "city", "cep",
+}
+
+type ImportOptions struct {
+	DryRun    bool       `json:"dry_run"`
+	Mode      ImportMode `json:"mode"`
+	BatchSize int        `json:"batch_size"`
+}
+
+// ImportRow is a single CSV line already mapped to the request type that
+// matches its "type" column. ParseErrors holds problems found while reading
+// raw values (bad dates, unknown type) before Validate is even called.
+type ImportRow struct {
+	Line        int
+	Type        string
+	PF          *CustomerPFRequest
+	PJ          *CustomerPJRequest
+	ParseErrors map[string]validators.Violation
+}
+
+type ImportRowResult struct {
+	Line       int               `json:"line"`
+	Type       string            `json:"type"`
+	Status     string            `json:"status"`
+	CustomerID uuid.UUID         `json:"customer_id,omitempty"`
+	Errors     map[string]string `json:"errors,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Mode    ImportMode        `json:"mode"`
+	Total   int               `json:"total"`
+	Valid   int               `json:"valid"`
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+func (o ImportOptions) Normalize() (ImportOptions, error) {
+	if o.Mode == "" {
+		o.Mode = ImportModeTransaction
+	}
+	if o.Mode != ImportModeTransaction && o.Mode != ImportModeBatch {
+		return o, &i18n.Error{Err: ErrInvalidImportMode, Key: "import.invalid_mode", Args: []any{string(o.Mode)}}
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultImportBatchSize
+	}
+	return o, nil
+}
+
+// ParseImportCSV reads a CSV file whose header uses the same column names as
+// the JSON payloads of CustomerPFRequest and CustomerPJRequest. Columns that
+// do not apply to a row type are ignored.
+func ParseImportCSV(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrEmptyImport
+		}
+		return nil, invalidCSV(1, err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, col := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff")))] = i
+	}
+	if _, ok := index["type"]; !ok {
+		return nil, ErrMissingTypeColumn
+	}
+
+	var rows []ImportRow
+	line := 1
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, invalidCSV(line, err)
+		}
+
+		values := make(map[string]string, len(importColumns))
+		for _, col := range importColumns {
+			if i, ok := index[col]; ok && i < len(record) {
+				values[col] = strings.TrimSpace(record[i])
+			}
+		}
+
+		rows = append(rows, mapImportRow(line, values))
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	return rows, nil
+}
+
+func invalidCSV(line int, err error) error {
+	return &i18n.Error{Err: fmt.Errorf("%w: %w", ErrInvalidCSV, err), Key: "import.invalid_csv", Args: []any{line}}
+}
+
+func mapImportRow(line int, v map[string]string) ImportRow {
+	row := ImportRow{
+		Line:        line,
+		Type:        strings.ToUpper(v["type"]),
+		ParseErrors: make(map[string]validators.Violation),
+	}
+
+	complement := pgtype.Text{String: v["complement"], Valid: v["complement"] != ""}
+
+	switch row.Type {
+	case "PF":
+		birthDate, violation := parseImportDate(v["birth_date"])
+		if violation != nil {
+			row.ParseErrors["birth_date"] = *violation
+		}
+		row.PF = &CustomerPFRequest{
+			Type:        row.Type,
+			Email:       v["email"],
+			Phone:       v["phone"],
+			Cpf:         v["cpf"],
+			Name:        v["name"],
+			BirthDate:   birthDate,
+			AddressType: v["address_type"],
+			Street:      v["street"],
+			Number:      v["number"],
+			Complement:  complement,
+			State:       v["state"],
+			City:        v["city"],
+			Cep:         v["cep"],
+		}
+	case "PJ":
+		row.PJ = &CustomerPJRequest{
+			Type:        row.Type,
+			Email:       v["email"],
+			Phone:       v["phone"],
+			Cnpj:        v["cnpj"],
+			CompanyName: v["company_name"],
+			AddressType: v["address_type"],
+			Street:      v["street"],
+			Number:      v["number"],
+			Complement:  complement,
+			State:       v["state"],
+			City:        v["city"],
+			Cep:         v["cep"],
+		}
+	default:
+		row.ParseErrors["type"] = validators.Violation{Code: validators.CodeOneOf, Args: []any{"PF, PJ"}}
+	}
+
+	return row
+}
+
+func parseImportDate(value string) (pgtype.Date, *validators.Violation) {
+	if value == "" {
+		return pgtype.Date{}, &validators.Violation{Code: validators.CodeRequired}
+	}
+	for _, layout := range []string{"2006-01-02", "02/01/2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return pgtype.Date{Time: t, Valid: true}, nil
+		}
+	}
+	return pgtype.Date{}, &validators.Violation{Code: validators.CodeDateFormat, Args: []any{"YYYY-MM-DD, DD/MM/YYYY"}}
+}
+
+// Validate runs the parse checks and the regular request rules for the row,
+// returning every problem found keyed by field, in lang.
+func (ir ImportRow) Validate(lang string) map[string]string {
+	errs := make(map[string]string, len(ir.ParseErrors))
+	for k, v := range ir.ParseErrors {
+		errs[k] = validators.Message(lang, v)
+	}
+
+	var err error
+	switch {
+	case ir.PF != nil:
+		err = ir.PF.Validate()
+	case ir.PJ != nil:
+		err = ir.PJ.Validate()
+	}
+
+	var validationErrs validators.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for k, v := range validationErrs.Localize(lang) {
+			if _, ok := errs[k]; !ok {
+				errs[k] = v
+			}
+		}
+	}
+
+	return errs
+}
+
+// UniqueKeys returns the values that must be unique across customers,
+// keyed by the column they belong to. The phone is in E.164, as stored, and
+// the CPF/CNPJ is normalized the way its blind index is computed.
+func (ir ImportRow) UniqueKeys() map[string]string {
+	switch {
+	case ir.PF != nil:
+		return map[string]string{"email": ir.PF.Email, "phone": phone.Canonical(ir.PF.Phone), "cpf": NormalizeDocument(ir.PF.Cpf)}
+	case ir.PJ != nil:
+		return map[string]string{"email": ir.PJ.Email, "phone": phone.Canonical(ir.PJ.Phone), "cnpj": NormalizeDocument(ir.PJ.Cnpj)}
+	}
+	return nil
+}
+
+// NormalizeDocument drops the punctuation of a CPF/CNPJ and upper-cases it,
+// so 123.456.789-09 and 12345678909 compare equal.
+func NormalizeDocument(document string) string {
+	return strings.ToUpper(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, document))
+}
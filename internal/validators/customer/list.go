@@ -0,0 +1,15 @@
+package customer
+
+import "github.com/google/uuid"
+
+// MaxListLimit caps the page size of the customer list.
+const MaxListLimit = 500
+
+// ListOptions selects a page of the customer list, which is ordered by id:
+// customers carrying every one of Tags, with an id after After, at most Limit
+// of them. A zero Limit returns every customer left.
+type ListOptions struct {
+	Tags  []string
+	After uuid.UUID
+	Limit int
+}
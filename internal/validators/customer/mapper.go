@@ -19,20 +19,24 @@ func MapAddresses(rows []sqlc.GetCustomerAddressesRow) []AddressResponse {
 	return addrs
 }
 
-func MapCustomer(data sqlc.GetCustomerByIDRow, addresses []sqlc.GetCustomerAddressesRow) CustomerResponse {
+func MapCustomer(data sqlc.GetCustomerByIDRow, addresses []sqlc.GetCustomerAddressesRow, contacts []sqlc.Contact) CustomerResponse {
 	return CustomerResponse{
-		ID:          data.ID,
-		Type:        data.Type,
-		Email:       data.Email,
-		Phone:       data.Phone,
-		IsActive:    data.IsActive,
-		CreatedAt:   data.CreatedAt,
-		UpdatedAt:   data.UpdatedAt,
-		Cpf:         data.Cpf,
-		PfName:      data.PfName,
-		BirthDate:   data.BirthDate,
-		Cnpj:        data.Cnpj,
-		CompanyName: data.CompanyName,
-		Addresses:   MapAddresses(addresses),
+		ID:           data.ID,
+		Type:         data.Type,
+		Email:        data.Email,
+		Phone:        data.Phone,
+		IsActive:     data.IsActive,
+		CreatedAt:    data.CreatedAt,
+		UpdatedAt:    data.UpdatedAt,
+		AnonymizedAt: data.AnonymizedAt,
+		Cpf:          data.Cpf,
+		PfName:       data.PfName,
+		BirthDate:    data.BirthDate,
+		Cnpj:         data.Cnpj,
+		CompanyName:  data.CompanyName,
+		Addresses:    MapAddresses(addresses),
+		Contacts:     MapContacts(contacts),
+		Tags:         []string{},
+		CustomFields: MapCustomFieldValues(data.CustomFields),
 	}
 }
@@ -0,0 +1,65 @@
+package customer
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
+)
+
+// NoteRequest adds a note to a customer; the author is the logged in user.
+type NoteRequest struct {
+	Body string `json:"body"`
+}
+
+func (nr *NoteRequest) Validate() error {
+	return validators.Check(
+		validators.Field("body", nr.Body, validators.Required[string](), validators.Length(1, 5000)),
+	)
+}
+
+// NoteResponse is a note of a customer. The author is null when the user who
+// wrote it was deleted.
+type NoteResponse struct {
+	ID         int32              `json:"id"`
+	AuthorID   *uuid.UUID         `json:"author_id"`
+	AuthorName pgtype.Text        `json:"author_name"`
+	Body       string             `json:"body"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+func MapNote(n sqlc.ListNotesByCustomerIDsRow) NoteResponse {
+	note := NoteResponse{
+		ID:         n.ID,
+		AuthorName: n.AuthorName,
+		Body:       n.Body,
+		CreatedAt:  n.CreatedAt,
+	}
+	if n.AuthorID.Valid {
+		id := uuid.UUID(n.AuthorID.Bytes)
+		note.AuthorID = &id
+	}
+	return note
+}
+
+// MapLatestNote returns the note of a ListLatestNotesByCustomerIDs row.
+func MapLatestNote(n sqlc.ListLatestNotesByCustomerIDsRow) *NoteResponse {
+	note := MapNote(sqlc.ListNotesByCustomerIDsRow{
+		ID:         n.ID,
+		CustomerID: n.CustomerID,
+		AuthorID:   n.AuthorID,
+		AuthorName: n.AuthorName,
+		Body:       n.Body,
+		CreatedAt:  n.CreatedAt,
+	})
+	return &note
+}
+
+// MapNotes keeps the order of rows, newest first.
+func MapNotes(rows []sqlc.ListNotesByCustomerIDsRow) []NoteResponse {
+	notes := make([]NoteResponse, 0, len(rows))
+	for _, n := range rows {
+		notes = append(notes, MapNote(n))
+	}
+	return notes
+}
@@ -0,0 +1,68 @@
+package customer
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/josevitorrodriguess/client-manager/internal/db/sqlc"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
+)
+
+// maxTags caps the tags of a single customer.
+const maxTags = 20
+
+// tagRegex accepts lower-case letters, accented ones included, digits, "-"
+// and "_", e.g. "vip" or "inadimplência".
+var tagRegex = regexp.MustCompile(`^[\p{Ll}\p{N}][\p{Ll}\p{N}_-]*$`)
+
+// TagsRequest replaces the tags of a customer. Tags are compared after
+// NormalizeTags, so "VIP " and "vip" are the same tag.
+type TagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+func (tr *TagsRequest) Validate() error {
+	checks := []validators.FieldCheck{
+		validators.Field("tags", len(NormalizeTags(tr.Tags)), validators.MaxItems(maxTags)),
+	}
+	checks = append(checks, validators.Each("tags", tr.Tags, validators.Required[string](), tag())...)
+	return validators.Check(checks...)
+}
+
+func tag() validators.Rule[string] {
+	length, format := validators.Length(1, 50), validators.Matches(tagRegex)
+	return func(value string) *validators.Violation {
+		value = normalizeTag(value)
+		if v := length(value); v != nil {
+			return v
+		}
+		return format(value)
+	}
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// NormalizeTags trims and lower-cases tags, then sorts them and drops blanks
+// and duplicates.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t = normalizeTag(t); t != "" {
+			normalized = append(normalized, t)
+		}
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// MapTags returns the tag names of rows, in their order.
+func MapTags(rows []sqlc.CustomerTag) []string {
+	tags := make([]string, 0, len(rows))
+	for _, t := range rows {
+		tags = append(tags, t.Tag)
+	}
+	return tags
+}
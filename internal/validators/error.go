@@ -2,11 +2,48 @@ package validators
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
+// ValidationErrors maps JSON field names to what is wrong with them. Errors
+// holds the messages in DefaultLanguage; Violations keeps the rule behind
+// each message so it can be rendered in another language with Localize.
 type ValidationErrors struct {
-	Errors map[string]string
+	Errors     map[string]string    `json:"errors"`
+	Violations map[string]Violation `json:"-"`
+}
+
+// NewFieldError returns ValidationErrors with a single violation.
+func NewFieldError(field, code string, args ...any) ValidationErrors {
+	errs := ValidationErrors{}
+	errs.Add(field, Violation{Code: code, Args: args})
+	return errs
+}
+
+// Add records v for field, replacing any previous violation of that field.
+func (v *ValidationErrors) Add(field string, violation Violation) {
+	if v.Errors == nil {
+		v.Errors = make(map[string]string)
+	}
+	if v.Violations == nil {
+		v.Violations = make(map[string]Violation)
+	}
+	v.Errors[field] = Message(DefaultLanguage, violation)
+	v.Violations[field] = violation
+}
+
+// Localize returns the messages in lang. Fields added without a violation
+// keep their original message.
+func (v ValidationErrors) Localize(lang string) map[string]string {
+	msgs := make(map[string]string, len(v.Errors))
+	for field, msg := range v.Errors {
+		if violation, ok := v.Violations[field]; ok {
+			msg = Message(lang, violation)
+		}
+		msgs[field] = msg
+	}
+	return msgs
 }
 
 func (v ValidationErrors) Error() string {
@@ -14,10 +51,10 @@ func (v ValidationErrors) Error() string {
 	for field, msg := range v.Errors {
 		errMsgs = append(errMsgs, fmt.Sprintf("%s: %s", field, msg))
 	}
+	sort.Strings(errMsgs)
 	return strings.Join(errMsgs, "; ")
 }
 
-
 func (v ValidationErrors) HasErrors() bool {
 	return len(v.Errors) > 0
 }
@@ -0,0 +1,34 @@
+package validators
+
+import "github.com/josevitorrodriguess/client-manager/internal/i18n"
+
+// DefaultLanguage is the language of ValidationErrors.Errors, which is what
+// gets logged.
+const DefaultLanguage = i18n.En
+
+// Violation codes, one per rule.
+const (
+	CodeRequired     = "required"
+	CodeLength       = "length"
+	CodeFormat       = "format"
+	CodeEmail        = "email"
+	CodePhone        = "phone"
+	CodeCPF          = "cpf"
+	CodeCNPJ         = "cnpj"
+	CodeCEP          = "cep"
+	CodeOneOf        = "one_of"
+	CodeMoney        = "money"
+	CodeMoneyAtMost  = "money_at_most"
+	CodeDateRange    = "date_range"
+	CodeDateFormat   = "date_format"
+	CodeUnknownField = "unknown_field"
+	CodeType         = "type"
+	CodeAtLeastOne   = "at_least_one"
+	CodeMaxItems     = "max_items"
+)
+
+// Message renders v in lang using the "validation." messages of the i18n
+// catalogs.
+func Message(lang string, v Violation) string {
+	return i18n.T(lang, "validation."+v.Code, v.Args...)
+}
@@ -0,0 +1,236 @@
+package validators
+
+import (
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/josevitorrodriguess/client-manager/internal/phone"
+	"github.com/josevitorrodriguess/client-manager/internal/utils"
+)
+
+const dateLayout = "2006-01-02"
+
+// Weights of the second check digit; the first one drops the leading weight.
+var (
+	cpfWeights  = []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjWeights = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+// Required rejects the zero value; strings made only of spaces count as
+// empty.
+func Required[T comparable]() Rule[T] {
+	return func(value T) *Violation {
+		var zero T
+		if s, ok := any(value).(string); ok && !utils.NotBlank(s) || value == zero {
+			return &Violation{Code: CodeRequired}
+		}
+		return nil
+	}
+}
+
+// RequiredNumeric rejects a NULL number; pgtype.Numeric is not comparable, so
+// Required cannot be used with it.
+func RequiredNumeric() Rule[pgtype.Numeric] {
+	return func(value pgtype.Numeric) *Violation {
+		if !value.Valid {
+			return &Violation{Code: CodeRequired}
+		}
+		return nil
+	}
+}
+
+// Length requires between min and max characters.
+func Length(min, max int) Rule[string] {
+	return func(value string) *Violation {
+		if value != "" && !(utils.MinChars(value, min) && utils.MaxChars(value, max)) {
+			return &Violation{Code: CodeLength, Args: []any{min, max}}
+		}
+		return nil
+	}
+}
+
+// Matches requires the value to match rx.
+func Matches(rx *regexp.Regexp) Rule[string] {
+	return match(rx, CodeFormat)
+}
+
+func Email() Rule[string] {
+	return match(utils.EmailRegex, CodeEmail)
+}
+
+// Phone requires a Brazilian phone number with a known area code; see
+// package phone for the accepted formats.
+func Phone() Rule[string] {
+	return func(value string) *Violation {
+		if value == "" {
+			return nil
+		}
+		if _, err := phone.Parse(value); err != nil {
+			return &Violation{Code: CodePhone}
+		}
+		return nil
+	}
+}
+
+func CEP() Rule[string] {
+	return match(utils.CEPRegex, CodeCEP)
+}
+
+func match(rx *regexp.Regexp, code string) Rule[string] {
+	return func(value string) *Violation {
+		if value != "" && !utils.Matches(value, rx) {
+			return &Violation{Code: code}
+		}
+		return nil
+	}
+}
+
+// CPF requires a CPF, with or without punctuation, whose check digits are
+// correct. Sequences of one repeated digit are rejected.
+func CPF() Rule[string] {
+	return func(value string) *Violation {
+		if value != "" && !(utils.Matches(value, utils.CPFRegex) && validCheckDigits(digits(value), cpfWeights)) {
+			return &Violation{Code: CodeCPF}
+		}
+		return nil
+	}
+}
+
+// CNPJ requires a CNPJ, with or without punctuation, whose check digits are
+// correct. Sequences of one repeated digit are rejected.
+func CNPJ() Rule[string] {
+	return func(value string) *Violation {
+		if value != "" && !(utils.Matches(value, utils.CNPJRegex) && validCheckDigits(digits(value), cnpjWeights)) {
+			return &Violation{Code: CodeCNPJ}
+		}
+		return nil
+	}
+}
+
+func digits(value string) []int {
+	var ds []int
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			ds = append(ds, int(r-'0'))
+		}
+	}
+	return ds
+}
+
+// validCheckDigits verifies the two mod 11 check digits at the end of ds.
+func validCheckDigits(ds []int, weights []int) bool {
+	if len(ds) != len(weights)+1 || slices.Max(ds) == slices.Min(ds) {
+		return false
+	}
+	checkDigit := func(weights []int) int {
+		sum := 0
+		for i, w := range weights {
+			sum += ds[i] * w
+		}
+		if rem := sum % 11; rem >= 2 {
+			return 11 - rem
+		}
+		return 0
+	}
+	first, second := weights[1:], weights
+	return checkDigit(first) == ds[len(first)] && checkDigit(second) == ds[len(second)]
+}
+
+// OneOf requires one of the allowed values.
+func OneOf(allowed ...string) Rule[string] {
+	return func(value string) *Violation {
+		if value != "" && !slices.Contains(allowed, value) {
+			return &Violation{Code: CodeOneOf, Args: []any{strings.Join(allowed, ", ")}}
+		}
+		return nil
+	}
+}
+
+// Money requires a non-negative amount with at most two decimal places.
+func Money() Rule[pgtype.Numeric] {
+	return func(value pgtype.Numeric) *Violation {
+		if !value.Valid {
+			return nil
+		}
+		amount, ok := numericRat(value)
+		if !ok || amount.Sign() < 0 || !new(big.Rat).Mul(amount, big.NewRat(100, 1)).IsInt() {
+			return &Violation{Code: CodeMoney}
+		}
+		return nil
+	}
+}
+
+// MoneyAtMost requires the amount not to exceed limit, the value of the field
+// named limitField. It is skipped when either amount is missing.
+func MoneyAtMost(limitField string, limit pgtype.Numeric) Rule[pgtype.Numeric] {
+	return func(value pgtype.Numeric) *Violation {
+		amount, ok := numericRat(value)
+		most, mostOK := numericRat(limit)
+		if ok && mostOK && amount.Cmp(most) > 0 {
+			return &Violation{Code: CodeMoneyAtMost, Args: []any{limitField}}
+		}
+		return nil
+	}
+}
+
+func numericRat(n pgtype.Numeric) (*big.Rat, bool) {
+	if !n.Valid || n.NaN || n.InfinityModifier != pgtype.Finite || n.Int == nil {
+		return nil, false
+	}
+	r := new(big.Rat).SetInt(n.Int)
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(n.Exp))), nil)
+	if n.Exp >= 0 {
+		return r.Mul(r, new(big.Rat).SetInt(scale)), true
+	}
+	return r.Quo(r, new(big.Rat).SetInt(scale)), true
+}
+
+func abs(n int32) int32 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// DateBetween requires a date from first to last, both inclusive.
+func DateBetween(first, last time.Time) Rule[pgtype.Date] {
+	first, last = truncateDay(first), truncateDay(last)
+	return func(value pgtype.Date) *Violation {
+		if !value.Valid {
+			return nil
+		}
+		if d := truncateDay(value.Time); d.Before(first) || d.After(last) {
+			return &Violation{Code: CodeDateRange, Args: []any{first.Format(dateLayout), last.Format(dateLayout)}}
+		}
+		return nil
+	}
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// AtLeastOne is for rules that span several fields: present tells whether any
+// of fields has a value.
+func AtLeastOne(fields ...string) Rule[bool] {
+	return func(present bool) *Violation {
+		if !present {
+			return &Violation{Code: CodeAtLeastOne, Args: []any{strings.Join(fields, ", ")}}
+		}
+		return nil
+	}
+}
+
+// MaxItems requires a list of at most max items; pass len(list) as the value.
+func MaxItems(max int) Rule[int] {
+	return func(n int) *Violation {
+		if n > max {
+			return &Violation{Code: CodeMaxItems, Args: []any{max}}
+		}
+		return nil
+	}
+}
@@ -3,7 +3,6 @@ package service
 import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/josevitorrodriguess/client-manager/internal/utils"
 	"github.com/josevitorrodriguess/client-manager/internal/validators"
 )
 
@@ -17,27 +16,14 @@ type ServiceRequest struct {
 	IsFinished  bool           `json:"is_finished"`
 }
 
-func (pr *ServiceRequest) IsValid() (bool, error) {
-	validationErrs := validators.ValidationErrors{
-		Errors: make(map[string]string),
-	}
-	if !utils.NotBlank(pr.CustomerID.String()) {
-		validationErrs.Errors["customer_id"] = "customer id cannot be empty"
-	}
-
-	if !utils.NotBlank(pr.TypeProduct) {
-		validationErrs.Errors["type_product"] = "type product cannot be empty"
-	}
-
-	if !(utils.MinChars(pr.Description, 5) && utils.MaxChars(pr.Description, 255)) {
-		validationErrs.Errors["description"] = "description must have between 5 and 255 characters"
-	}
-
-	if !validationErrs.HasErrors() {
-		return true, nil
-	}
-
-	return false, validationErrs
+func (pr *ServiceRequest) Validate() error {
+	return validators.Check(
+		validators.Field("customer_id", pr.CustomerID, validators.Required[uuid.UUID]()),
+		validators.Field("type_product", pr.TypeProduct, validators.Required[string](), validators.Length(1, 255)),
+		validators.Field("description", pr.Description, validators.Required[string](), validators.Length(5, 255)),
+		validators.Field("total_value", pr.TotalValue, validators.RequiredNumeric(), validators.Money()),
+		validators.Field("down_payment", pr.DownPayment, validators.Money(), validators.MoneyAtMost("total_value", pr.TotalValue)),
+	)
 }
 
 type UpdateServiceStatusRequest struct {
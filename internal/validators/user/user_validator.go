@@ -1,9 +1,7 @@
 package user
 
 import (
-	"fmt"
-
-	"github.com/josevitorrodriguess/client-manager/internal/utils"
+	"github.com/josevitorrodriguess/client-manager/internal/validators"
 )
 
 type UserRequest struct {
@@ -13,18 +11,12 @@ type UserRequest struct {
 	IsAdmin  bool   `json:"role"`
 }
 
-func (ur *UserRequest) IsValid() (bool, error) {
-	if !(utils.MinChars(ur.Name, 5) && utils.MaxChars(ur.Name, 100)) {
-		return false, fmt.Errorf("name must have between 5 and 100 characters")
-	}
-	if !utils.Matches(ur.Email, utils.EmailRegex) {
-		return false, fmt.Errorf("invalid email")
-	}
-	if !(utils.MinChars(ur.Password, 8) && utils.MaxChars(ur.Password, 100)) {
-		return false, fmt.Errorf("password must have between 8 and 100 characters")
-	}
-
-	return true, nil
+func (ur *UserRequest) Validate() error {
+	return validators.Check(
+		validators.Field("name", ur.Name, validators.Required[string](), validators.Length(5, 100)),
+		validators.Field("email", ur.Email, validators.Required[string](), validators.Email()),
+		validators.Field("password", ur.Password, validators.Required[string](), validators.Length(8, 100)),
+	)
 }
 
 type UserRequestLogin struct {
@@ -32,14 +24,11 @@ type UserRequestLogin struct {
 	Password string `json:"password"`
 }
 
-func (url *UserRequestLogin) IsValid() (bool, error) {
-	if !utils.Matches(url.Email, utils.EmailRegex) {
-		return false, fmt.Errorf("invalid email")
-	}
-	if !(utils.MinChars(url.Password, 8) && utils.MaxChars(url.Password, 100)) {
-		return false, fmt.Errorf("password must have between 8 and 100 characters")
-	}
-	return true, nil
+func (url *UserRequestLogin) Validate() error {
+	return validators.Check(
+		validators.Field("email", url.Email, validators.Required[string](), validators.Email()),
+		validators.Field("password", url.Password, validators.Required[string](), validators.Length(8, 100)),
+	)
 }
 
 type UserResponse struct{}
@@ -0,0 +1,61 @@
+package validators
+
+import "fmt"
+
+// Violation is a failed rule: Code selects the message in the catalog and
+// Args fill its placeholders.
+type Violation struct {
+	Code string
+	Args []any
+}
+
+// Rule checks one value. Rules other than Required accept the zero value, so
+// optional fields are only checked when present.
+type Rule[T any] func(value T) *Violation
+
+// FieldCheck is the outcome of Field, ready to be passed to Check.
+type FieldCheck struct {
+	name      string
+	violation *Violation
+}
+
+// Field runs rules against value in order and keeps the first violation.
+// name is the JSON name of the field, used as the key in ValidationErrors.
+func Field[T any](name string, value T, rules ...Rule[T]) FieldCheck {
+	for _, rule := range rules {
+		if v := rule(value); v != nil {
+			return FieldCheck{name: name, violation: v}
+		}
+	}
+	return FieldCheck{name: name}
+}
+
+// Check collects the violations of every field. It returns nil when all
+// fields are valid and ValidationErrors otherwise.
+//
+//	return validators.Check(
+//		validators.Field("name", r.Name, validators.Required[string](), validators.Length(5, 100)),
+//		validators.Field("cpf", r.Cpf, validators.Required[string](), validators.CPF()),
+//	)
+func Check(fields ...FieldCheck) error {
+	errs := ValidationErrors{}
+	for _, f := range fields {
+		if f.violation != nil {
+			errs.Add(f.name, *f.violation)
+		}
+	}
+	if !errs.HasErrors() {
+		return nil
+	}
+	return errs
+}
+
+// Each runs rules against every item of values, naming the fields after
+// their position, e.g. "emails[1]".
+func Each[T any](name string, values []T, rules ...Rule[T]) []FieldCheck {
+	checks := make([]FieldCheck, 0, len(values))
+	for i, v := range values {
+		checks = append(checks, Field(fmt.Sprintf("%s[%d]", name, i), v, rules...))
+	}
+	return checks
+}
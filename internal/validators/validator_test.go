@@ -0,0 +1,162 @@
+package validators
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestDocumentRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  Rule[string]
+		value string
+		valid bool
+	}{
+		{"cpf with punctuation", CPF(), "123.456.789-09", true},
+		{"cpf digits only", CPF(), "52998224725", true},
+		{"cpf wrong check digit", CPF(), "123.456.789-00", false},
+		{"cpf repeated digits", CPF(), "111.111.111-11", false},
+		{"cpf too short", CPF(), "123", false},
+		{"cnpj with punctuation", CNPJ(), "11.222.333/0001-81", true},
+		{"cnpj digits only", CNPJ(), "12345678000195", true},
+		{"cnpj wrong check digit", CNPJ(), "11.222.333/0001-82", false},
+		{"cnpj repeated digits", CNPJ(), "00000000000000", false},
+		{"cep", CEP(), "01001-000", true},
+		{"bad cep", CEP(), "0100", false},
+		{"one of", OneOf("PF", "PJ"), "PJ", true},
+		{"not one of", OneOf("PF", "PJ"), "XX", false},
+		{"length", Length(5, 10), "Maria", true},
+		{"too short", Length(5, 10), "Bo", false},
+		{"empty skips rules", CPF(), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule(tt.value) == nil; got != tt.valid {
+				t.Errorf("valid = %v, want %v", got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestMoneyRules(t *testing.T) {
+	amount := func(cents int64, exp int32) pgtype.Numeric {
+		return pgtype.Numeric{Int: big.NewInt(cents), Exp: exp, Valid: true}
+	}
+
+	tests := []struct {
+		name  string
+		rule  Rule[pgtype.Numeric]
+		value pgtype.Numeric
+		valid bool
+	}{
+		{"two decimals", Money(), amount(150050, -2), true},
+		{"integer", Money(), amount(15, 2), true},
+		{"three decimals", Money(), amount(1001, -3), false},
+		{"negative", Money(), amount(-100, 0), false},
+		{"missing is optional", Money(), pgtype.Numeric{}, true},
+		{"required", RequiredNumeric(), pgtype.Numeric{}, false},
+		{"within limit", MoneyAtMost("total_value", amount(100, 0)), amount(5000, -2), true},
+		{"above limit", MoneyAtMost("total_value", amount(100, 0)), amount(10001, -2), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule(tt.value) == nil; got != tt.valid {
+				t.Errorf("valid = %v, want %v", got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestDateBetween(t *testing.T) {
+	rule := DateBetween(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2000, 12, 31, 23, 0, 0, 0, time.UTC))
+	date := func(y int, m time.Month, d int) pgtype.Date {
+		return pgtype.Date{Time: time.Date(y, m, d, 0, 0, 0, 0, time.UTC), Valid: true}
+	}
+
+	if v := rule(date(2000, 12, 31)); v != nil {
+		t.Errorf("last day rejected: %+v", v)
+	}
+	if v := rule(date(1899, 12, 31)); v == nil || v.Code != CodeDateRange {
+		t.Errorf("date before range = %+v", v)
+	}
+	if v := rule(date(2001, 1, 1)); v == nil {
+		t.Error("date after range accepted")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	err := Check(
+		Field("name", "  ", Required[string](), Length(5, 10)),
+		Field("cpf", "123", Required[string](), CPF()),
+		Field("email", "maria@example.com", Required[string](), Email()),
+	)
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("err = %v, want ValidationErrors", err)
+	}
+	want := map[string]string{"name": "is required", "cpf": "must be a valid CPF"}
+	if len(verrs.Errors) != len(want) {
+		t.Fatalf("errors = %v, want %v", verrs.Errors, want)
+	}
+	for field, msg := range want {
+		if verrs.Errors[field] != msg {
+			t.Errorf("%s = %q, want %q", field, verrs.Errors[field], msg)
+		}
+	}
+
+	if err := Check(Field("email", "maria@example.com", Email())); err != nil {
+		t.Errorf("valid fields returned %v", err)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	verrs := NewFieldError("name", CodeLength, 5, 100)
+
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"pt-BR", "deve ter entre 5 e 100 caracteres"},
+		{"pt", "deve ter entre 5 e 100 caracteres"},
+		{"en-US", "must have between 5 and 100 characters"},
+		{"fr", "deve ter entre 5 e 100 caracteres"},
+	}
+	for _, tt := range tests {
+		if got := verrs.Localize(tt.lang)["name"]; got != tt.want {
+			t.Errorf("Localize(%q) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestEachAndListRules(t *testing.T) {
+	emails := []string{"ana@example.com", "not-an-email"}
+	err := Check(append(Each("emails", emails, Email()),
+		Field("emails", len(emails), MaxItems(1)),
+		Field("phones", false, AtLeastOne("emails", "phones")),
+	)...)
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("err = %v, want ValidationErrors", err)
+	}
+	want := map[string]string{
+		"emails[1]": "must be a valid email address",
+		"emails":    "must have at most 1 items",
+		"phones":    "at least one of emails, phones is required",
+	}
+	if len(verrs.Errors) != len(want) {
+		t.Fatalf("errors = %v, want %v", verrs.Errors, want)
+	}
+	for field, msg := range want {
+		if verrs.Errors[field] != msg {
+			t.Errorf("%s = %q, want %q", field, verrs.Errors[field], msg)
+		}
+	}
+}
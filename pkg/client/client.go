@@ -0,0 +1,251 @@
+// Package client is a typed Go client for the client-manager HTTP API.
+//
+// A Client keeps the session cookie set by Login in its own cookie jar, so
+// one Client is one logged-in user. Services that already hold a session can
+// skip Login by passing the cookie value as Options.SessionToken. Errors
+// returned by the API are *Error values decoded from the problem+json body;
+// use errors.Is with ErrNotFound, ErrConflict and friends to branch on them.
+//
+//	c, err := client.New("http://localhost:3080", client.Options{})
+//	if err != nil { ... }
+//	if err := c.Login(ctx, "admin@example.com", "secret"); err != nil { ... }
+//	customer, err := c.GetCustomer(ctx, id)
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 2
+	DefaultRetryBackoff = 200 * time.Millisecond
+	DefaultCookieName   = "session"
+)
+
+// Options configures a Client. The zero value is ready to use.
+type Options struct {
+	// HTTPClient sends the requests. A copy is used, with its own cookie
+	// jar when it has none. Defaults to a client with a 30s timeout.
+	HTTPClient *http.Client
+	// MaxRetries is how many times idempotent requests (GET and DELETE) are
+	// retried after a network error or a 502, 503 or 504 answer. Negative
+	// disables retries; zero means DefaultMaxRetries.
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry; it doubles on every
+	// attempt and gets up to 50% of jitter. Zero means DefaultRetryBackoff.
+	RetryBackoff time.Duration
+	// SessionToken authenticates without Login, using the value of an
+	// existing session cookie.
+	SessionToken string
+	// CookieName is the name of the session cookie, as configured on the
+	// server. Zero means DefaultCookieName.
+	CookieName string
+	// AcceptLanguage selects the language of error messages, e.g. "en" or
+	// "pt-BR". The server defaults to pt-BR.
+	AcceptLanguage string
+}
+
+// Client calls the client-manager API. It is safe for concurrent use.
+type Client struct {
+	baseURL *url.URL
+	http    *http.Client
+	opts    Options
+}
+
+// New returns a Client for the API served at baseURL, e.g.
+// "https://clients.example.com".
+func New(baseURL string, opts Options) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base url %q: scheme must be http or https", baseURL)
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if opts.HTTPClient != nil {
+		c := *opts.HTTPClient
+		httpClient = &c
+	}
+	if httpClient.Jar == nil {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Jar = jar
+	}
+
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = DefaultMaxRetries
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = DefaultRetryBackoff
+	}
+	if opts.CookieName == "" {
+		opts.CookieName = DefaultCookieName
+	}
+
+	c := &Client{baseURL: u, http: httpClient, opts: opts}
+	if opts.SessionToken != "" {
+		c.SetSessionToken(opts.SessionToken)
+	}
+	return c, nil
+}
+
+// SessionToken returns the current session cookie value, or "" when the
+// client is not logged in. It can be handed to another Client through
+// Options.SessionToken.
+func (c *Client) SessionToken() string {
+	for _, cookie := range c.http.Jar.Cookies(c.baseURL) {
+		if cookie.Name == c.opts.CookieName {
+			return cookie.Value
+		}
+	}
+	return ""
+}
+
+// SetSessionToken replaces the session cookie with token.
+func (c *Client) SetSessionToken(token string) {
+	c.http.Jar.SetCookies(c.baseURL, []*http.Cookie{{Name: c.opts.CookieName, Value: token, Path: "/"}})
+}
+
+// request describes one API call. body is encoded as JSON unless it is an
+// io.Reader, which is sent as is with contentType.
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        any
+	contentType string
+}
+
+// do sends req and decodes a successful JSON answer into out, which may be
+// nil. Answers of 400 and above are returned as *Error.
+func (c *Client) do(ctx context.Context, req request, out any) error {
+	res, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding %s %s response: %w", req.method, req.path, err)
+	}
+	return nil
+}
+
+// send performs req with retries and returns the response when its status is
+// below 400. The caller must close the body.
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	var payload []byte
+	contentType := req.contentType
+	switch body := req.body.(type) {
+	case nil:
+	case io.Reader:
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		payload = b
+	default:
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s %s request: %w", req.method, req.path, err)
+		}
+		payload, contentType = b, "application/json"
+	}
+
+	u := *c.baseURL
+	u.Path += req.path
+	u.RawQuery = req.query.Encode()
+
+	retries := 0
+	if req.method == http.MethodGet || req.method == http.MethodDelete {
+		retries = max(c.opts.MaxRetries, 0)
+	}
+
+	for attempt := 0; ; attempt++ {
+		httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		if payload != nil {
+			httpReq.Header.Set("Content-Type", contentType)
+		}
+		httpReq.Header.Set("Accept", "application/json")
+		if c.opts.AcceptLanguage != "" {
+			httpReq.Header.Set("Accept-Language", c.opts.AcceptLanguage)
+		}
+
+		res, err := c.http.Do(httpReq)
+		if err == nil && res.StatusCode < http.StatusBadRequest {
+			return res, nil
+		}
+		if err == nil {
+			apiErr := decodeError(res)
+			res.Body.Close()
+			err = apiErr
+			if !retryable(res.StatusCode) {
+				return nil, err
+			}
+		}
+		if attempt >= retries || ctx.Err() != nil {
+			return nil, err
+		}
+
+		if err := sleep(ctx, c.backoff(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func retryable(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.opts.RetryBackoff << attempt
+	return d + time.Duration(rand.Int64N(int64(d)/2+1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// decodeError reads the problem+json body of res. Bodies that are not
+// problems (e.g. from a proxy) still produce an *Error with the status.
+func decodeError(res *http.Response) error {
+	apiErr := &Error{}
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Status == 0 {
+		*apiErr = Error{
+			Status: res.StatusCode,
+			Title:  http.StatusText(res.StatusCode),
+			Detail: strings.TrimSpace(string(body)),
+			body:   body,
+		}
+	}
+	return apiErr
+}
@@ -0,0 +1,365 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/josevitorrodriguess/client-manager/internal/testutil"
+	"github.com/josevitorrodriguess/client-manager/internal/validators/user"
+	"github.com/josevitorrodriguess/client-manager/pkg/client"
+)
+
+const (
+	adminEmail    = "admin@example.com"
+	adminPassword = "admin-password"
+)
+
+// newServer serves the real router over an in-memory store with one admin.
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	a, _ := testutil.NewAPI(t)
+	admin := user.UserRequest{Name: "Admin User", Email: adminEmail, Password: adminPassword, IsAdmin: true}
+	if _, err := a.UserService.Create(context.Background(), admin); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(a.Router)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newAdmin(t *testing.T, baseURL string) *client.Client {
+	t.Helper()
+
+	c, err := client.New(baseURL, client.Options{AcceptLanguage: "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Login(context.Background(), adminEmail, adminPassword); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func pfCustomer() client.CreatePFCustomerRequest {
+	return client.CreatePFCustomerRequest{
+		Email:     "maria@example.com",
+		Phone:     "11987654321",
+		Cpf:       "123.456.789-09",
+		Name:      "Maria Silva",
+		BirthDate: client.NewDate(1990, time.May, 10),
+		Address: client.Address{
+			AddressType: "home",
+			Street:      "Rua das Flores",
+			Number:      "100",
+			State:       "SP",
+			City:        "São Paulo",
+			Cep:         "01001-000",
+		},
+	}
+}
+
+func TestCustomersAndServices(t *testing.T) {
+	ctx := context.Background()
+	c := newAdmin(t, newServer(t).URL)
+
+	id, err := c.CreatePFCustomer(ctx, pfCustomer())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.GetCustomer(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "PF" || got.PfName != "Maria Silva" || got.Cpf != "123.456.789-09" || len(got.Addresses) != 1 {
+		t.Errorf("customer = %+v", got)
+	}
+	if found, err := c.FindCustomerByCpf(ctx, "123.456.789-09"); err != nil || found.ID != id {
+		t.Errorf("find by cpf = %v, %v", found.ID, err)
+	}
+
+	var listed int
+	for customer, err := range c.Customers(ctx) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if customer.ID == id {
+			listed++
+		}
+	}
+	if listed != 1 {
+		t.Errorf("customer listed %d times", listed)
+	}
+
+	serviceID, err := c.CreateService(ctx, client.CreateServiceRequest{
+		CustomerID:  id,
+		TypeProduct: "site",
+		Description: "institutional site",
+		TotalValue:  "1500.50",
+		DownPayment: "500",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc, err := c.SetServicePaid(ctx, serviceID, true)
+	if err != nil || !svc.IsPaid {
+		t.Errorf("set paid = %+v, %v", svc, err)
+	}
+	if n, err := c.CountCustomerServices(ctx, id); err != nil || n != 1 {
+		t.Errorf("count = %d, %v", n, err)
+	}
+	if err := c.DeleteService(ctx, serviceID); err != nil {
+		t.Fatal(err)
+	}
+	if services, err := c.ListServices(ctx); err != nil || len(services) != 0 {
+		t.Errorf("services after delete = %v, %v", services, err)
+	}
+}
+
+func TestContacts(t *testing.T) {
+	ctx := context.Background()
+	c := newAdmin(t, newServer(t).URL)
+
+	id, err := c.CreatePFCustomer(ctx, pfCustomer())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	role := "financeiro"
+	contact, err := c.AddContact(ctx, id, client.ContactRequest{Name: "Carlos Souza", Role: &role, Emails: []string{"carlos@example.com"}, IsPrimary: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contact.Role == nil || *contact.Role != role || !contact.IsPrimary {
+		t.Errorf("contact = %+v", contact)
+	}
+
+	contact, err = c.UpdateContact(ctx, id, contact.ID, client.ContactRequest{Name: "Carlos Souza", Phones: []string{"11 98765-4321"}, WhatsApp: true})
+	if err != nil || contact.Role != nil || len(contact.Emails) != 0 || !contact.WhatsApp {
+		t.Errorf("updated contact = %+v, %v", contact, err)
+	}
+	if got, err := c.GetCustomer(ctx, id); err != nil || len(got.Contacts) != 1 {
+		t.Errorf("customer contacts = %+v, %v", got.Contacts, err)
+	}
+
+	if err := c.DeleteContact(ctx, id, contact.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteContact(ctx, id, contact.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("second delete = %v, want ErrNotFound", err)
+	}
+	if contacts, err := c.ListContacts(ctx, id); err != nil || len(contacts) != 0 {
+		t.Errorf("contacts after delete = %v, %v", contacts, err)
+	}
+}
+
+func TestNotesTagsAndCustomFields(t *testing.T) {
+	ctx := context.Background()
+	c := newAdmin(t, newServer(t).URL)
+
+	id, err := c.CreatePFCustomer(ctx, pfCustomer())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	note, err := c.AddNote(ctx, id, "Prefers contact by email.")
+	if err != nil || note.AuthorName == nil || *note.AuthorName != "Admin User" {
+		t.Fatalf("note = %+v, %v", note, err)
+	}
+	if tags, err := c.SetTags(ctx, id, []string{"VIP", "recorrente"}); err != nil || len(tags) != 2 || tags[1] != "vip" {
+		t.Errorf("tags = %v, %v", tags, err)
+	}
+	if _, err := c.CreateCustomField(ctx, client.CustomFieldRequest{Key: "employees", Label: "Funcionários", Type: "number"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SetCustomFields(ctx, id, map[string]any{"employees": 40}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.GetCustomer(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Notes) != 1 || len(got.Tags) != 2 || got.CustomFields["employees"] != float64(40) {
+		t.Errorf("customer = %+v", got)
+	}
+	if list, err := c.ListCustomers(ctx, "vip"); err != nil || len(list) != 1 {
+		t.Errorf("list by tag = %v, %v", list, err)
+	}
+	if list, err := c.ListCustomers(ctx, "inadimplente"); err != nil || len(list) != 0 {
+		t.Errorf("list by unused tag = %v, %v", list, err)
+	}
+
+	if err := c.DeleteNote(ctx, id, note.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteCustomField(ctx, "employees"); err != nil {
+		t.Fatal(err)
+	}
+	if fields, err := c.ListCustomFields(ctx); err != nil || len(fields) != 0 {
+		t.Errorf("custom fields after delete = %v, %v", fields, err)
+	}
+}
+
+func TestImportCustomers(t *testing.T) {
+	c := newAdmin(t, newServer(t).URL)
+
+	csv := "type,email,phone,cnpj,company_name,address_type,street,number,state,city,cep\n" +
+		"PJ,contato@acme.com.br,11987651234,11.222.333/0001-81,Acme Ltda,office,Av Paulista,1000,SP,São Paulo,01310-100\n"
+	report, err := c.ImportCustomers(context.Background(), strings.NewReader(csv), client.ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.DryRun || report.Total != 1 || report.Valid != 1 {
+		t.Errorf("report = %+v", report)
+	}
+
+	// Nothing is created when a row fails in transaction mode, which the
+	// server answers with 422 and the report.
+	csv += "PJ,invalid,1,1,X,office,Rua,1,SP,São Paulo,1\n"
+	report, err = c.ImportCustomers(context.Background(), strings.NewReader(csv), client.ImportOptions{})
+	if err != nil {
+		t.Fatalf("failed import: %v", err)
+	}
+	if report.Total != 2 || report.Created != 0 || report.Failed != 1 || len(report.Rows) != 2 || report.Rows[1].Errors["email"] == "" {
+		t.Errorf("failed import report = %+v", report)
+	}
+}
+
+func TestListCustomersPage(t *testing.T) {
+	ctx := context.Background()
+	c := newAdmin(t, newServer(t).URL)
+
+	if list, err := c.ListCustomers(ctx); err != nil || list == nil || len(list) != 0 {
+		t.Fatalf("empty list = %#v, %v", list, err)
+	}
+
+	csv := "type,email,phone,cnpj,company_name,address_type,street,number,state,city,cep\n" +
+		"PJ,a@acme.com.br,11987651231,11.222.333/0001-81,A Ltda,office,Av Paulista,1,SP,São Paulo,01310-100\n" +
+		"PJ,b@acme.com.br,11987651232,11.444.777/0001-61,B Ltda,office,Av Paulista,2,SP,São Paulo,01310-100\n" +
+		"PJ,c@acme.com.br,11987651233,45.723.174/0001-10,C Ltda,office,Av Paulista,3,SP,São Paulo,01310-100\n"
+	if report, err := c.ImportCustomers(ctx, strings.NewReader(csv), client.ImportOptions{}); err != nil || report.Created != 3 {
+		t.Fatalf("import = %+v, %v", report, err)
+	}
+
+	first, err := c.ListCustomersPage(ctx, client.ListCustomersOptions{Limit: 2})
+	if err != nil || len(first) != 2 {
+		t.Fatalf("first page = %d customers, %v", len(first), err)
+	}
+	second, err := c.ListCustomersPage(ctx, client.ListCustomersOptions{Limit: 2, After: first[1].ID})
+	if err != nil || len(second) != 1 || second[0].ID == first[0].ID || second[0].ID == first[1].ID {
+		t.Fatalf("second page = %+v, %v", second, err)
+	}
+
+	var listed int
+	for _, err := range c.Customers(ctx) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed++
+	}
+	if listed != 3 {
+		t.Errorf("Customers yielded %d customers, want 3", listed)
+	}
+
+	if _, err := c.ListCustomersPage(ctx, client.ListCustomersOptions{Limit: 1000}); !errors.Is(err, client.ErrBadRequest) {
+		t.Errorf("oversized page err = %v", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	srv := newServer(t)
+	c := newAdmin(t, srv.URL)
+
+	_, err := c.GetCustomer(ctx, uuid.New())
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || !errors.Is(err, client.ErrNotFound) || apiErr.Detail != "customer not found" {
+		t.Errorf("not found err = %#v", err)
+	}
+
+	invalid := pfCustomer()
+	invalid.Cpf = "123"
+	_, err = c.CreatePFCustomer(ctx, invalid)
+	if !errors.As(err, &apiErr) || !errors.Is(err, client.ErrBadRequest) || apiErr.Errors["cpf"] != "must be a valid CPF" {
+		t.Errorf("validation err = %#v", err)
+	}
+
+	anon, err := client.New(srv.URL, client.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := anon.ListServices(ctx); !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("anonymous err = %v", err)
+	}
+	if err := anon.Login(ctx, adminEmail, "wrong-password"); !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("bad login err = %v", err)
+	}
+}
+
+func TestSessionToken(t *testing.T) {
+	ctx := context.Background()
+	srv := newServer(t)
+	admin := newAdmin(t, srv.URL)
+
+	token := admin.SessionToken()
+	if token == "" {
+		t.Fatal("no session token after login")
+	}
+	c, err := client.New(srv.URL, client.Options{SessionToken: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListServices(ctx); err != nil {
+		t.Errorf("list with session token: %v", err)
+	}
+
+	if err := c.Logout(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListServices(ctx); !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("after logout err = %v", err)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	c, err := client.New(srv.URL, client.Options{RetryBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListServices(context.Background()); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("calls = %d, want 3", n)
+	}
+
+	calls.Store(0)
+	c, err = client.New(srv.URL, client.Options{MaxRetries: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListServices(context.Background()); err == nil || calls.Load() != 1 {
+		t.Errorf("without retries: err = %v, calls = %d", err, calls.Load())
+	}
+}
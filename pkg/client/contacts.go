@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// AddContact adds a contact to a customer.
+func (c *Client) AddContact(ctx context.Context, customerID uuid.UUID, req ContactRequest) (Contact, error) {
+	var out Contact
+	err := c.do(ctx, request{method: http.MethodPost, path: contactsPath(customerID), body: req}, &out)
+	return out, err
+}
+
+// ListContacts returns the contacts of a customer, the primary one first.
+func (c *Client) ListContacts(ctx context.Context, customerID uuid.UUID) ([]Contact, error) {
+	var out []Contact
+	err := c.do(ctx, request{method: http.MethodGet, path: contactsPath(customerID)}, &out)
+	return out, err
+}
+
+// UpdateContact replaces every field of a contact.
+func (c *Client) UpdateContact(ctx context.Context, customerID uuid.UUID, contactID int32, req ContactRequest) (Contact, error) {
+	var out Contact
+	err := c.do(ctx, request{method: http.MethodPut, path: contactPath(customerID, contactID), body: req}, &out)
+	return out, err
+}
+
+func (c *Client) DeleteContact(ctx context.Context, customerID uuid.UUID, contactID int32) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: contactPath(customerID, contactID)}, nil)
+}
+
+func contactsPath(customerID uuid.UUID) string {
+	return "/api/v1/customers/" + customerID.String() + "/contacts"
+}
+
+func contactPath(customerID uuid.UUID, contactID int32) string {
+	return contactsPath(customerID) + "/" + strconv.Itoa(int(contactID))
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type createdCustomer struct {
+	CustomerID uuid.UUID `json:"customer_id"`
+}
+
+// CreatePFCustomer creates an individual customer and returns its id.
+func (c *Client) CreatePFCustomer(ctx context.Context, req CreatePFCustomerRequest) (uuid.UUID, error) {
+	body := struct {
+		Type string `json:"type"`
+		CreatePFCustomerRequest
+	}{"PF", req}
+
+	var out createdCustomer
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/customers/pf", body: body}, &out)
+	return out.CustomerID, err
+}
+
+// CreatePJCustomer creates a company customer and returns its id.
+func (c *Client) CreatePJCustomer(ctx context.Context, req CreatePJCustomerRequest) (uuid.UUID, error) {
+	body := struct {
+		Type string `json:"type"`
+		CreatePJCustomerRequest
+	}{"PJ", req}
+
+	var out createdCustomer
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/customers/pj", body: body}, &out)
+	return out.CustomerID, err
+}
+
+// AddAddress adds an address to a customer and returns the address id.
+func (c *Client) AddAddress(ctx context.Context, req AddAddressRequest) (int32, error) {
+	var out struct {
+		AddressID int32 `json:"address_id"`
+	}
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/customers/address", body: req}, &out)
+	return out.AddressID, err
+}
+
+func (c *Client) GetCustomer(ctx context.Context, id uuid.UUID) (Customer, error) {
+	var out Customer
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/customers/" + id.String()}, &out)
+	return out, err
+}
+
+// FindCustomerByCpf looks a customer up by exact CPF. It fails with
+// ErrNotFound when there is none.
+func (c *Client) FindCustomerByCpf(ctx context.Context, cpf string) (Customer, error) {
+	return c.findCustomer(ctx, url.Values{"cpf": {cpf}})
+}
+
+// FindCustomerByCnpj looks a customer up by exact CNPJ. It fails with
+// ErrNotFound when there is none.
+func (c *Client) FindCustomerByCnpj(ctx context.Context, cnpj string) (Customer, error) {
+	return c.findCustomer(ctx, url.Values{"cnpj": {cnpj}})
+}
+
+func (c *Client) findCustomer(ctx context.Context, query url.Values) (Customer, error) {
+	var out Customer
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/customers/search", query: query}, &out)
+	return out, err
+}
+
+// ListCustomers returns every customer, or only those carrying all of tags
+// when any are given, in a single response.
+func (c *Client) ListCustomers(ctx context.Context, tags ...string) ([]Customer, error) {
+	return c.ListCustomersPage(ctx, ListCustomersOptions{Tags: tags})
+}
+
+// ListCustomersPage returns one page of the customer list, which is ordered
+// by id. Pass the id of the last customer as After to get the next page.
+func (c *Client) ListCustomersPage(ctx context.Context, opts ListCustomersOptions) ([]Customer, error) {
+	query := url.Values{}
+	for _, tag := range opts.Tags {
+		query.Add("tag", tag)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.After != uuid.Nil {
+		query.Set("after", opts.After.String())
+	}
+
+	var out []Customer
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/customers/", query: query}, &out)
+	return out, err
+}
+
+// customersPageSize is the page size Customers requests.
+const customersPageSize = 100
+
+// Customers iterates over every customer, or only those carrying all of
+// tags, fetching them customersPageSize at a time. A failed page is yielded
+// as the error and ends the iteration.
+func (c *Client) Customers(ctx context.Context, tags ...string) iter.Seq2[Customer, error] {
+	return func(yield func(Customer, error) bool) {
+		opts := ListCustomersOptions{Tags: tags, Limit: customersPageSize}
+		for {
+			page, err := c.ListCustomersPage(ctx, opts)
+			if err != nil {
+				yield(Customer{}, err)
+				return
+			}
+			for _, customer := range page {
+				if !yield(customer, nil) {
+					return
+				}
+			}
+			if len(page) < opts.Limit {
+				return
+			}
+			opts.After = page[len(page)-1].ID
+		}
+	}
+}
+
+// ImportCustomers uploads a CSV file of customers. Row problems are reported
+// in the ImportReport, not as an error, including when the server refuses
+// the whole file with 422 because no row could be created.
+func (c *Client) ImportCustomers(ctx context.Context, csv io.Reader, opts ImportOptions) (ImportReport, error) {
+	query := url.Values{}
+	if opts.DryRun {
+		query.Set("dry_run", "true")
+	}
+	if opts.Mode != "" {
+		query.Set("mode", string(opts.Mode))
+	}
+	if opts.BatchSize > 0 {
+		query.Set("batch_size", strconv.Itoa(opts.BatchSize))
+	}
+
+	var out ImportReport
+	err := c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/api/v1/customers/import",
+		query:       query,
+		body:        csv,
+		contentType: "text/csv",
+	}, &out)
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusUnprocessableEntity && apiErr.body != nil {
+		if json.Unmarshal(apiErr.body, &out) == nil {
+			return out, nil
+		}
+	}
+	return out, err
+}
+
+// ExportCustomer returns everything held about a customer (LGPD data access
+// request). The export is recorded in the customer's audit trail.
+func (c *Client) ExportCustomer(ctx context.Context, id uuid.UUID) (CustomerExport, error) {
+	var out CustomerExport
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/customers/" + id.String() + "/export"}, &out)
+	return out, err
+}
+
+// AnonymizeCustomer erases a customer's personal data (LGPD erasure request)
+// and returns what is left.
+func (c *Client) AnonymizeCustomer(ctx context.Context, id uuid.UUID) (Customer, error) {
+	var out Customer
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/customers/" + id.String() + "/anonymize"}, &out)
+	return out, err
+}
+
+// each turns a list call into an iterator that yields the error, if any, as
+// its only element.
+func each[T any](list func() ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		items, err := list()
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
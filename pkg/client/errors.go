@@ -0,0 +1,63 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Error is an error answered by the API, decoded from its problem+json body.
+// Errors holds the message of each invalid field on validation failures.
+type Error struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail"`
+	Instance string            `json:"instance"`
+	Errors   map[string]string `json:"errors"`
+
+	// body is the raw response when it was not a problem, e.g. the import
+	// report answered with 422.
+	body []byte
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("client-manager: %d %s", e.Status, e.Title)
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if len(e.Errors) > 0 {
+		fields := make([]string, 0, len(e.Errors))
+		for field, fieldMsg := range e.Errors {
+			fields = append(fields, field+": "+fieldMsg)
+		}
+		sort.Strings(fields)
+		msg += " (" + strings.Join(fields, "; ") + ")"
+	}
+	return msg
+}
+
+// Is reports whether target is the sentinel for e's status, so
+// errors.Is(err, client.ErrNotFound) works on any 404.
+func (e *Error) Is(target error) bool {
+	s, ok := target.(statusError)
+	return ok && int(s) == e.Status
+}
+
+type statusError int
+
+func (s statusError) Error() string {
+	return http.StatusText(int(s))
+}
+
+// Sentinels matched by errors.Is against an *Error with the same status.
+var (
+	ErrBadRequest       error = statusError(http.StatusBadRequest)
+	ErrUnauthorized     error = statusError(http.StatusUnauthorized)
+	ErrForbidden        error = statusError(http.StatusForbidden)
+	ErrNotFound         error = statusError(http.StatusNotFound)
+	ErrConflict         error = statusError(http.StatusConflict)
+	ErrPayloadTooLarge  error = statusError(http.StatusRequestEntityTooLarge)
+	ErrUnsupportedMedia error = statusError(http.StatusUnsupportedMediaType)
+)
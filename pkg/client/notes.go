@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// AddNote adds a note to a customer, authored by the logged in user.
+func (c *Client) AddNote(ctx context.Context, customerID uuid.UUID, body string) (Note, error) {
+	req := struct {
+		Body string `json:"body"`
+	}{body}
+
+	var out Note
+	err := c.do(ctx, request{method: http.MethodPost, path: notesPath(customerID), body: req}, &out)
+	return out, err
+}
+
+// ListNotes returns the notes of a customer, newest first.
+func (c *Client) ListNotes(ctx context.Context, customerID uuid.UUID) ([]Note, error) {
+	var out []Note
+	err := c.do(ctx, request{method: http.MethodGet, path: notesPath(customerID)}, &out)
+	return out, err
+}
+
+func (c *Client) DeleteNote(ctx context.Context, customerID uuid.UUID, noteID int32) error {
+	path := notesPath(customerID) + "/" + strconv.Itoa(int(noteID))
+	return c.do(ctx, request{method: http.MethodDelete, path: path}, nil)
+}
+
+// SetTags replaces the tags of a customer and returns them as stored:
+// lower-cased, sorted and without duplicates.
+func (c *Client) SetTags(ctx context.Context, customerID uuid.UUID, tags []string) ([]string, error) {
+	body := struct {
+		Tags []string `json:"tags"`
+	}{tags}
+	if body.Tags == nil {
+		body.Tags = []string{}
+	}
+
+	var out struct {
+		Tags []string `json:"tags"`
+	}
+	err := c.do(ctx, request{method: http.MethodPut, path: customerPath(customerID) + "/tags", body: body}, &out)
+	return out.Tags, err
+}
+
+// CreateCustomField defines a custom field for every customer.
+func (c *Client) CreateCustomField(ctx context.Context, req CustomFieldRequest) (CustomField, error) {
+	var out CustomField
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/custom-fields/", body: req}, &out)
+	return out, err
+}
+
+func (c *Client) ListCustomFields(ctx context.Context) ([]CustomField, error) {
+	var out []CustomField
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/custom-fields/"}, &out)
+	return out, err
+}
+
+// DeleteCustomField deletes a custom field and its value on every customer.
+func (c *Client) DeleteCustomField(ctx context.Context, key string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/api/v1/custom-fields/" + url.PathEscape(key)}, nil)
+}
+
+// SetCustomFields replaces the custom field values of a customer. Numbers are
+// sent as JSON numbers and dates as "YYYY-MM-DD" strings; a nil value clears
+// the field.
+func (c *Client) SetCustomFields(ctx context.Context, customerID uuid.UUID, values map[string]any) (map[string]any, error) {
+	var out map[string]any
+	err := c.do(ctx, request{method: http.MethodPut, path: customerPath(customerID) + "/custom-fields", body: values}, &out)
+	return out, err
+}
+
+func notesPath(customerID uuid.UUID) string {
+	return customerPath(customerID) + "/notes"
+}
+
+func customerPath(customerID uuid.UUID) string {
+	return "/api/v1/customers/" + customerID.String()
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// CreateService creates a service for a customer and returns its id.
+func (c *Client) CreateService(ctx context.Context, req CreateServiceRequest) (int32, error) {
+	var out struct {
+		ServiceID int32 `json:"service_id"`
+	}
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/services/", body: req}, &out)
+	return out.ServiceID, err
+}
+
+// ListServices returns every service.
+func (c *Client) ListServices(ctx context.Context) ([]Service, error) {
+	var out []Service
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/services/"}, &out)
+	return out, err
+}
+
+// Services iterates over every service. The endpoint is not paged, so they
+// are fetched in a single response.
+func (c *Client) Services(ctx context.Context) iter.Seq2[Service, error] {
+	return each(func() ([]Service, error) { return c.ListServices(ctx) })
+}
+
+// ListCustomerServices returns the services of a customer.
+func (c *Client) ListCustomerServices(ctx context.Context, customerID uuid.UUID) ([]Service, error) {
+	var out []Service
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/services/customer/" + customerID.String()}, &out)
+	return out, err
+}
+
+func (c *Client) CountCustomerServices(ctx context.Context, customerID uuid.UUID) (int64, error) {
+	var out struct {
+		Count int64 `json:"count"`
+	}
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/services/count/" + customerID.String()}, &out)
+	return out.Count, err
+}
+
+func (c *Client) DeleteService(ctx context.Context, id int32) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/api/v1/services/",
+		query:  url.Values{"id": {strconv.Itoa(int(id))}},
+	}, nil)
+}
+
+// SetServiceFinished marks a service as finished or not and returns it.
+func (c *Client) SetServiceFinished(ctx context.Context, id int32, finished bool) (Service, error) {
+	return c.updateServiceStatus(ctx, "/api/v1/services/finish", id, finished)
+}
+
+// SetServicePaid marks a service as paid or not and returns it.
+func (c *Client) SetServicePaid(ctx context.Context, id int32, paid bool) (Service, error) {
+	return c.updateServiceStatus(ctx, "/api/v1/services/payment", id, paid)
+}
+
+func (c *Client) updateServiceStatus(ctx context.Context, path string, id int32, status bool) (Service, error) {
+	var out Service
+	err := c.do(ctx, request{
+		method: http.MethodPatch,
+		path:   path,
+		body:   map[string]any{"id": id, "status": status},
+	}, &out)
+	return out, err
+}
@@ -0,0 +1,247 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Date is a calendar date, sent as "2006-01-02". The zero value is sent as
+// null.
+type Date struct {
+	time.Time
+}
+
+const dateLayout = "2006-01-02"
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Format(dateLayout))
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s *string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == nil {
+		*d = Date{}
+		return nil
+	}
+	t, err := time.Parse(dateLayout, *s)
+	if err != nil {
+		return err
+	}
+	*d = Date{t}
+	return nil
+}
+
+// Money is a decimal amount kept as its exact JSON text, e.g. "1500.50".
+type Money = json.Number
+
+type CreateUserRequest struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	IsAdmin  bool   `json:"role"`
+}
+
+// Address is the address part of the customer requests.
+type Address struct {
+	AddressType string  `json:"address_type"`
+	Street      string  `json:"street"`
+	Number      string  `json:"number"`
+	Complement  *string `json:"complement"`
+	State       string  `json:"state"`
+	City        string  `json:"city"`
+	Cep         string  `json:"cep"`
+}
+
+type CreatePFCustomerRequest struct {
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+	Cpf       string `json:"cpf"`
+	Name      string `json:"name"`
+	BirthDate Date   `json:"birth_date"`
+	Address
+}
+
+type CreatePJCustomerRequest struct {
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	Cnpj        string `json:"cnpj"`
+	CompanyName string `json:"company_name"`
+	Address
+}
+
+type AddAddressRequest struct {
+	CustomerID uuid.UUID `json:"customer_id"`
+	Address
+}
+
+type Customer struct {
+	ID           uuid.UUID         `json:"id"`
+	Type         string            `json:"type"`
+	Email        string            `json:"email"`
+	Phone        string            `json:"phone"`
+	IsActive     bool              `json:"is_active"`
+	CreatedAt    *time.Time        `json:"created_at"`
+	UpdatedAt    *time.Time        `json:"updated_at"`
+	AnonymizedAt *time.Time        `json:"anonymized_at"`
+	Cpf          string            `json:"cpf,omitempty"`
+	PfName       string            `json:"pf_name,omitempty"`
+	BirthDate    *Date             `json:"birth_date,omitempty"`
+	Cnpj         string            `json:"cnpj,omitempty"`
+	CompanyName  string            `json:"company_name,omitempty"`
+	Addresses    []CustomerAddress `json:"addresses"`
+	Contacts     []Contact         `json:"contacts"`
+	Tags         []string          `json:"tags"`
+	// Notes is only filled by GetCustomer; lists carry NoteCount and
+	// LatestNote, and ListNotes returns every note.
+	Notes        []Note         `json:"notes,omitempty"`
+	NoteCount    int64          `json:"note_count"`
+	LatestNote   *Note          `json:"latest_note"`
+	CustomFields map[string]any `json:"custom_fields"`
+}
+
+type CustomerAddress struct {
+	ID int32 `json:"id"`
+	Address
+}
+
+// ContactRequest creates or replaces a contact. At least one email or phone
+// is required; a new primary contact demotes the previous one.
+type ContactRequest struct {
+	Name      string   `json:"name"`
+	Role      *string  `json:"role"`
+	Emails    []string `json:"emails"`
+	Phones    []string `json:"phones"`
+	WhatsApp  bool     `json:"whatsapp"`
+	IsPrimary bool     `json:"is_primary"`
+}
+
+type Contact struct {
+	ID        int32      `json:"id"`
+	Name      string     `json:"name"`
+	Role      *string    `json:"role"`
+	Emails    []string   `json:"emails"`
+	Phones    []string   `json:"phones"`
+	WhatsApp  bool       `json:"whatsapp"`
+	IsPrimary bool       `json:"is_primary"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// Note is a free-form note on a customer. AuthorID and AuthorName are nil once
+// the author's account is deleted.
+type Note struct {
+	ID         int32      `json:"id"`
+	AuthorID   *uuid.UUID `json:"author_id"`
+	AuthorName *string    `json:"author_name"`
+	Body       string     `json:"body"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+// CustomFieldRequest defines a custom field. Type is one of "text", "number",
+// "date" or "select"; Options are only used by select fields.
+type CustomFieldRequest struct {
+	Key     string   `json:"key"`
+	Label   string   `json:"label"`
+	Type    string   `json:"type"`
+	Options []string `json:"options,omitempty"`
+}
+
+type CustomField struct {
+	ID        int32      `json:"id"`
+	Key       string     `json:"key"`
+	Label     string     `json:"label"`
+	Type      string     `json:"type"`
+	Options   []string   `json:"options"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type CreateServiceRequest struct {
+	CustomerID  uuid.UUID `json:"customer_id"`
+	TypeProduct string    `json:"type_product"`
+	Description string    `json:"description"`
+	TotalValue  Money     `json:"total_value"`
+	DownPayment Money     `json:"down_payment,omitempty"`
+	IsPaid      bool      `json:"is_paid"`
+	IsFinished  bool      `json:"is_finished"`
+}
+
+type Service struct {
+	ID          int32     `json:"id"`
+	CustomerID  uuid.UUID `json:"customer_id"`
+	TypeProduct string    `json:"type_product"`
+	Description string    `json:"description"`
+	TotalValue  *Money    `json:"total_value"`
+	DownPayment *Money    `json:"down_payment"`
+	IsPaid      bool      `json:"is_paid"`
+	IsFinished  bool      `json:"is_finished"`
+}
+
+// ListCustomersOptions selects a page for ListCustomersPage: customers
+// carrying every one of Tags, with an id after After, at most Limit of them
+// (the server caps it at 500). A zero Limit returns every customer left.
+type ListCustomersOptions struct {
+	Tags  []string
+	After uuid.UUID
+	Limit int
+}
+
+// ImportMode is how ImportCustomers commits rows: ImportTransaction inserts
+// all rows or none, ImportBatch commits every BatchSize rows on their own.
+type ImportMode string
+
+const (
+	ImportTransaction ImportMode = "transaction"
+	ImportBatch       ImportMode = "batch"
+)
+
+type ImportOptions struct {
+	DryRun    bool
+	Mode      ImportMode
+	BatchSize int
+}
+
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Mode    ImportMode        `json:"mode"`
+	Total   int               `json:"total"`
+	Valid   int               `json:"valid"`
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+type ImportRowResult struct {
+	Line       int               `json:"line"`
+	Type       string            `json:"type"`
+	Status     string            `json:"status"`
+	CustomerID uuid.UUID         `json:"customer_id"`
+	Errors     map[string]string `json:"errors"`
+}
+
+// CustomerExport is the LGPD data access export of a customer.
+type CustomerExport struct {
+	ExportedAt time.Time    `json:"exported_at"`
+	Customer   Customer     `json:"customer"`
+	Services   []Service    `json:"services"`
+	AuditTrail []AuditEvent `json:"audit_trail"`
+}
+
+type AuditEvent struct {
+	ID        int64           `json:"id"`
+	ActorID   *uuid.UUID      `json:"actor_id"`
+	Action    string          `json:"action"`
+	Details   json.RawMessage `json:"details"`
+	CreatedAt time.Time       `json:"created_at"`
+}
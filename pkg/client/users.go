@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Login authenticates and keeps the session cookie for the following calls.
+func (c *Client) Login(ctx context.Context, email, password string) error {
+	return c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/api/v1/users/login",
+		body:   map[string]string{"email": email, "password": password},
+	}, nil)
+}
+
+// Logout ends the session.
+func (c *Client) Logout(ctx context.Context) error {
+	return c.do(ctx, request{method: http.MethodPost, path: "/api/v1/users/logout"}, nil)
+}
+
+// CreateUser registers a user. It requires an admin session.
+func (c *Client) CreateUser(ctx context.Context, req CreateUserRequest) (uuid.UUID, error) {
+	var out struct {
+		UserID uuid.UUID `json:"user_id"`
+	}
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/users/register", body: req}, &out)
+	return out.UserID, err
+}